@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+type AccessRequestsController struct {
+	*AppContext
+}
+
+// ReviewAccessRequestRequest is the request body of ApproveAccessRequest and DenyAccessRequest
+type ReviewAccessRequestRequest struct {
+	Comment string `json:"comment"`
+}
+
+func (c *AccessRequestsController) ListAccessRequests(_ *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	status := models.AccessRequestStatus(vars["status"])
+	switch status {
+	case "", models.AccessRequestPending, models.AccessRequestApproved, models.AccessRequestDenied:
+	default:
+		return BadRequest("status must be one of pending, approved or denied")
+	}
+
+	accessRequests, err := c.AccessRequestsService.ListAccessRequests(project.ID, status)
+	if err != nil {
+		log.Errorf("error fetching access requests of project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+	return Ok(accessRequests)
+}
+
+func (c *AccessRequestsController) CreateAccessRequest(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	accessRequest, ok := body.(*models.ProjectAccessRequest)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as access request")
+	}
+	if vars["user"] == "" {
+		return BadRequest("User-Email header is required to request access to a project")
+	}
+	accessRequest.ID = 0
+	accessRequest.Requester = vars["user"]
+
+	accessRequest, err = c.AccessRequestsService.CreateAccessRequest(r.Context(), project, accessRequest)
+	if err != nil {
+		log.Errorf("error creating access request for project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+	return Created(accessRequest)
+}
+
+func (c *AccessRequestsController) ApproveAccessRequest(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	return c.review(r, vars, body, c.AccessRequestsService.ApproveAccessRequest)
+}
+
+func (c *AccessRequestsController) DenyAccessRequest(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	return c.review(r, vars, body, c.AccessRequestsService.DenyAccessRequest)
+}
+
+// review fetches the project and the access request and calls the given review method of the service
+func (c *AccessRequestsController) review(r *http.Request, vars map[string]string, body interface{},
+	reviewFunc func(context.Context, *models.Project, *models.ProjectAccessRequest,
+		string) (*models.ProjectAccessRequest, error)) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+	accessRequestID, _ := models.ParseID(vars["access_request_id"])
+	accessRequest, err := c.AccessRequestsService.FindAccessRequestByID(project.ID, accessRequestID)
+	if err != nil {
+		log.Errorf("error fetching access request with ID %d: %s", accessRequestID, err)
+		return FromError(err)
+	}
+
+	reviewRequest, ok := body.(*ReviewAccessRequestRequest)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body")
+	}
+
+	accessRequest, err = reviewFunc(r.Context(), project, accessRequest, reviewRequest.Comment)
+	if err != nil {
+		log.Errorf("error reviewing access request with ID %d: %s", accessRequestID, err)
+		return FromError(err)
+	}
+	return Ok(accessRequest)
+}
+
+func (c *AccessRequestsController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/access_requests",
+			nil,
+			c.ListAccessRequests,
+			"ListAccessRequests",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/access_requests",
+			models.ProjectAccessRequest{},
+			c.CreateAccessRequest,
+			"CreateAccessRequest",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/access_requests/{access_request_id:[0-9]+}/approve",
+			ReviewAccessRequestRequest{},
+			c.ApproveAccessRequest,
+			"ApproveAccessRequest",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/access_requests/{access_request_id:[0-9]+}/deny",
+			ReviewAccessRequestRequest{},
+			c.DenyAccessRequest,
+			"DenyAccessRequest",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestAccessRequests() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	projectID := int(e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "access-project", Team: "dsp", Stream: "dsp",
+			Administrators: []string{"admin@example.com"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("id").Number().Raw())
+	accessRequestsPath := fmt.Sprintf("/v1/projects/%d/access_requests", projectID)
+
+	e.POST(accessRequestsPath).
+		WithJSON(models.ProjectAccessRequest{Role: "owner"}).
+		WithHeader("User-Email", "user@example.com").
+		Expect().
+		Status(http.StatusBadRequest)
+	accessRequest := e.POST(accessRequestsPath).
+		WithJSON(models.ProjectAccessRequest{Role: models.ProjectReaderRole, Reason: "debugging"}).
+		WithHeader("User-Email", "user@example.com").
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	accessRequest.Value("requester").IsEqual("user@example.com")
+	accessRequest.Value("status").IsEqual(models.AccessRequestPending)
+	accessRequestID := int(accessRequest.Value("id").Number().Raw())
+
+	e.POST(accessRequestsPath).
+		WithJSON(models.ProjectAccessRequest{Role: models.ProjectReaderRole}).
+		WithHeader("User-Email", "user@example.com").
+		Expect().
+		Status(http.StatusConflict)
+
+	e.GET(accessRequestsPath).
+		WithQuery("status", "pending").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	e.POST(fmt.Sprintf("%s/%d/approve", accessRequestsPath, accessRequestID)).
+		WithJSON(ReviewAccessRequestRequest{Comment: "welcome"}).
+		WithHeader("User-Email", "admin@example.com").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("status").IsEqual(models.AccessRequestApproved)
+	e.GET(fmt.Sprintf("/v1/projects/%d", projectID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("readers").Array().ContainsOnly("user@example.com")
+
+	// a request can only be reviewed once
+	e.POST(fmt.Sprintf("%s/%d/deny", accessRequestsPath, accessRequestID)).
+		WithJSON(ReviewAccessRequestRequest{}).
+		Expect().
+		Status(http.StatusBadRequest)
+}
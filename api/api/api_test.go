@@ -148,6 +148,12 @@ func (s *APITestSuite) SetupTest() {
 		&ProjectsController{AppContext: appCtx},
 		&SecretsController{AppContext: appCtx},
 		&SecretStoragesController{AppContext: appCtx},
+		&StreamsController{AppContext: appCtx},
+		&GroupsController{AppContext: appCtx},
+		&AccessRequestsController{AppContext: appCtx},
+		&ServiceAccountsController{AppContext: appCtx},
+		&RolesController{AppContext: appCtx},
+		&AuthorizationController{AppContext: appCtx},
 	}
 
 	r := NewRouter(appCtx, controllers)
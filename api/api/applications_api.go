@@ -2,6 +2,8 @@ package api
 
 import (
 	http "net/http"
+
+	"github.com/caraml-dev/mlp/api/middleware"
 )
 
 type ApplicationsController struct {
@@ -24,6 +26,7 @@ func (c *ApplicationsController) Routes() []Route {
 			nil,
 			c.ListApplications,
 			"ListApplications",
+			middleware.Public(),
 		},
 	}
 }
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// decisionsPaginator paginates the authorization decision log
+var decisionsPaginator = pagination.NewPaginator(1, 100, 1000)
+
+type AuthorizationController struct {
+	*AppContext
+}
+
+// AuthorizationDecisionList is returned by ListDecisions
+type AuthorizationDecisionList struct {
+	Results []*models.AuthorizationDecision `json:"results"`
+	Paging  *pagination.Paging              `json:"paging"`
+}
+
+func (c *AuthorizationController) GetCurrentUser(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	var projectID *models.ID
+	if project, ok := vars["project"]; ok {
+		id, err := models.ParseID(project)
+		if err != nil {
+			return BadRequest("Invalid project ID: " + project)
+		}
+		projectID = &id
+	}
+
+	authorization, err := c.AuthorizationService.GetUserAuthorization(r.Context(), vars["user"], projectID)
+	if err != nil {
+		log.Errorf("error fetching authorization of user %s: %s", vars["user"], err)
+		return FromError(err)
+	}
+	return Ok(authorization)
+}
+
+func (c *AuthorizationController) CheckPermissions(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	request, ok := body.(*models.PermissionCheckRequest)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as permission checks")
+	}
+
+	checks, err := c.AuthorizationService.CheckPermissions(r.Context(), vars["user"], request.Checks)
+	if err != nil {
+		log.Errorf("error checking permissions on behalf of user %s: %s", vars["user"], err)
+		return FromError(err)
+	}
+	return Ok(checks)
+}
+
+func (c *AuthorizationController) ListDecisions(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	filter, err := newAuthorizationDecisionFilter(vars)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+
+	decisions, paging, err := c.AuthorizationDecisionsService.ListDecisions(filter)
+	if err != nil {
+		log.Errorf("error listing authorization decisions: %s", err)
+		return FromError(err)
+	}
+	return Ok(AuthorizationDecisionList{Results: decisions, Paging: paging})
+}
+
+func (c *AuthorizationController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/me",
+			nil,
+			c.GetCurrentUser,
+			"GetCurrentUser",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/authz/check",
+			models.PermissionCheckRequest{},
+			c.CheckPermissions,
+			"CheckPermissions",
+			middleware.Public(),
+		},
+		{
+			http.MethodGet,
+			"/authz/decisions",
+			nil,
+			c.ListDecisions,
+			"ListAuthorizationDecisions",
+			middleware.Requires("authz.decisions", "get"),
+		},
+	}
+}
+
+// newAuthorizationDecisionFilter parses the query parameters of ListDecisions
+func newAuthorizationDecisionFilter(vars map[string]string) (repository.AuthorizationDecisionFilter, error) {
+	filter := repository.AuthorizationDecisionFilter{
+		Subject:    vars["subject"],
+		Permission: vars["permission"],
+		RequestID:  vars["request_id"],
+	}
+
+	if vars["allowed"] != "" {
+		allowed, err := strconv.ParseBool(vars["allowed"])
+		if err != nil {
+			return filter, fmt.Errorf("allowed must be a boolean")
+		}
+		filter.Allowed = &allowed
+	}
+
+	for param, value := range map[string]**time.Time{
+		"after":  &filter.After,
+		"before": &filter.Before,
+	} {
+		if vars[param] == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, vars[param])
+		if err != nil {
+			return filter, fmt.Errorf("%s must be a RFC3339 timestamp", param)
+		}
+		*value = &t
+	}
+
+	options, err := newPaginationOptions(vars, decisionsPaginator)
+	if err != nil {
+		return filter, err
+	}
+	if options == nil {
+		defaultOptions := decisionsPaginator.NewPaginationOptions(nil, nil)
+		options = &defaultOptions
+	}
+	filter.Options = *options
+
+	return filter, nil
+}
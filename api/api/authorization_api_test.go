@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestAuthorization() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	// users can do everything if the authorization is disabled
+	me := e.GET("/v1/me").
+		WithHeader("User-Email", "user@example.com").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	me.Value("user").IsEqual("user@example.com")
+	me.Value("authorization_enabled").IsEqual(false)
+	me.Value("permissions").Array().IsEmpty()
+	e.GET("/v1/me").
+		WithQuery("project", "abc").
+		Expect().
+		Status(http.StatusBadRequest)
+
+	checks := e.POST("/v1/authz/check").
+		WithHeader("User-Email", "user@example.com").
+		WithJSON(models.PermissionCheckRequest{Checks: []models.PermissionCheck{
+			{Permission: "mlp.projects.1.get"},
+			{Subject: "other@example.com", Permission: "mlp.projects.post"},
+		}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array()
+	checks.Length().IsEqual(2)
+	checks.Value(0).Object().Value("subject").IsEqual("user@example.com")
+	checks.Value(0).Object().Value("allowed").IsEqual(true)
+	checks.Value(1).Object().Value("subject").IsEqual("other@example.com")
+
+	e.POST("/v1/authz/check").
+		WithJSON(models.PermissionCheckRequest{Checks: []models.PermissionCheck{}}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// the decisions can only be queried if the decision log is stored in the database
+	e.GET("/v1/authz/decisions").
+		Expect().
+		Status(http.StatusPreconditionFailed)
+	e.GET("/v1/authz/decisions").
+		WithQuery("allowed", "maybe").
+		Expect().
+		Status(http.StatusBadRequest)
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+type GroupsController struct {
+	*AppContext
+}
+
+func (c *GroupsController) ListGroups(_ *http.Request, _ map[string]string, _ interface{}) *Response {
+	groups, err := c.GroupsService.ListGroups()
+	if err != nil {
+		log.Errorf("error fetching groups: %s", err)
+		return FromError(err)
+	}
+	return Ok(groups)
+}
+
+func (c *GroupsController) GetGroup(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	groupID, _ := models.ParseID(vars["group_id"])
+	group, err := c.GroupsService.FindGroupByID(groupID)
+	if err != nil {
+		log.Errorf("error fetching group with ID %d: %s", groupID, err)
+		return FromError(err)
+	}
+	return Ok(group)
+}
+
+func (c *GroupsController) CreateGroup(r *http.Request, _ map[string]string, body interface{}) *Response {
+	group, ok := body.(*models.Group)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as group")
+	}
+	group.ID = 0
+
+	group, err := c.GroupsService.CreateGroup(r.Context(), group)
+	if err != nil {
+		log.Errorf("error creating group: %s", err)
+		return FromError(err)
+	}
+	return Created(group)
+}
+
+func (c *GroupsController) UpdateGroup(r *http.Request, vars map[string]string, body interface{}) *Response {
+	groupID, _ := models.ParseID(vars["group_id"])
+	group, err := c.GroupsService.FindGroupByID(groupID)
+	if err != nil {
+		log.Errorf("error fetching group with ID %d: %s", groupID, err)
+		return FromError(err)
+	}
+
+	updateRequest, ok := body.(*models.Group)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as group")
+	}
+	group.Name = updateRequest.Name
+	group.Description = updateRequest.Description
+	group.Members = updateRequest.Members
+	group.Owners = updateRequest.Owners
+
+	group, err = c.GroupsService.UpdateGroup(r.Context(), group)
+	if err != nil {
+		log.Errorf("error updating group with ID %d: %s", groupID, err)
+		return FromError(err)
+	}
+	return Ok(group)
+}
+
+func (c *GroupsController) DeleteGroup(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	groupID, _ := models.ParseID(vars["group_id"])
+	group, err := c.GroupsService.FindGroupByID(groupID)
+	if err != nil {
+		log.Errorf("error fetching group with ID %d: %s", groupID, err)
+		return FromError(err)
+	}
+
+	if err := c.GroupsService.DeleteGroup(r.Context(), group); err != nil {
+		log.Errorf("error deleting group with ID %d: %s", groupID, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+func (c *GroupsController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/groups",
+			nil,
+			c.ListGroups,
+			"ListGroups",
+			middleware.Public(),
+		},
+		{
+			http.MethodGet,
+			"/groups/{group_id:[0-9]+}",
+			nil,
+			c.GetGroup,
+			"GetGroup",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/groups",
+			models.Group{},
+			c.CreateGroup,
+			"CreateGroup",
+			middleware.Requires("groups", "post"),
+		},
+		{
+			http.MethodPut,
+			"/groups/{group_id:[0-9]+}",
+			models.Group{},
+			c.UpdateGroup,
+			"UpdateGroup",
+			middleware.Requires("groups.{group_id}", "put"),
+		},
+		{
+			http.MethodDelete,
+			"/groups/{group_id:[0-9]+}",
+			nil,
+			c.DeleteGroup,
+			"DeleteGroup",
+			middleware.Requires("groups.{group_id}", "delete"),
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestGroups() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	group := e.POST("/v1/groups").
+		WithJSON(models.Group{Name: "credit-risk", Members: []string{"alice@example.com"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	group.Value("members").Array().ContainsOnly("alice@example.com")
+	groupID := int(group.Value("id").Number().Raw())
+
+	e.POST("/v1/groups").
+		WithJSON(models.Group{Name: "credit-risk"}).
+		Expect().
+		Status(http.StatusConflict)
+	e.POST("/v1/groups").
+		WithJSON(models.Group{Name: "nested", Members: []string{"group:credit-risk"}}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// projects can only be granted to existing groups
+	e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "group-project", Team: "dsp", Stream: "dsp",
+			Readers: []string{"group:unknown"}}).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "group-project", Team: "dsp", Stream: "dsp",
+			Readers: []string{"group:credit-risk"}}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.PUT(fmt.Sprintf("/v1/groups/%d", groupID)).
+		WithJSON(models.Group{Name: "credit-risk", Members: []string{"alice@example.com", "bob@example.com"}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("members").Array().Length().IsEqual(2)
+
+	// a group granted access to projects can be neither renamed nor deleted
+	e.PUT(fmt.Sprintf("/v1/groups/%d", groupID)).
+		WithJSON(models.Group{Name: "risk"}).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.DELETE(fmt.Sprintf("/v1/groups/%d", groupID)).
+		Expect().
+		Status(http.StatusBadRequest)
+}
@@ -3,26 +3,63 @@ package api
 import (
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
 	"github.com/caraml-dev/mlp/api/models"
 	apperror "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/labels"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
 	"github.com/caraml-dev/mlp/api/pkg/webhooks"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/service"
 )
 
+// projectsPaginator is used to paginate the projects listing when page or page_size is specified
+var projectsPaginator = pagination.NewPaginator(1, 50, 1000)
+
 type ProjectsController struct {
 	*AppContext
 }
 
+// ProjectAuditLogList is returned by ListProjectHistory
+type ProjectAuditLogList struct {
+	Results []*models.ProjectAuditLog `json:"results"`
+	Paging  *pagination.Paging        `json:"paging"`
+}
+
+// RenameProjectRequest is the request body of RenameProject
+type RenameProjectRequest struct {
+	Name string `json:"name" validate:"required,min=3,max=50,subdomain_rfc1123"`
+}
+
+// ProjectList is returned by ListProjects when pagination is requested
+type ProjectList struct {
+	Results []*models.Project  `json:"results"`
+	Paging  *pagination.Paging `json:"paging"`
+}
+
 func (c *ProjectsController) ListProjects(r *http.Request, vars map[string]string, _ interface{}) *Response {
-	projects, err := c.ProjectsService.ListProjects(r.Context(), vars["name"], vars["user"])
+	filter, err := newProjectFilter(vars)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+
+	projects, paging, err := c.ProjectsService.ListProjects(r.Context(), filter, vars["user"])
 	if err != nil {
 		log.Errorf("error fetching projects: %s", err)
 		return FromError(err)
 	}
 
+	if paging != nil {
+		return Ok(ProjectList{Results: projects, Paging: paging})
+	}
 	return Ok(projects)
 }
 
@@ -69,7 +106,7 @@ func (c *ProjectsController) CreateProject(r *http.Request, vars map[string]stri
 		return FromError(err)
 	}
 
-	return Created(project)
+	return Created(project).WithHeader("ETag", projectETag(project))
 }
 
 func (c *ProjectsController) UpdateProject(r *http.Request, vars map[string]string, body interface{}) *Response {
@@ -80,6 +117,11 @@ func (c *ProjectsController) UpdateProject(r *http.Request, vars map[string]stri
 		return FromError(err)
 	}
 
+	if !matchesETag(r.Header.Get("If-Match"), projectETag(project)) {
+		return PreconditionFailed(fmt.Sprintf("Project %s has been modified, fetch the latest version and retry",
+			project.Name))
+	}
+
 	newProject, ok := body.(*models.Project)
 	if !ok {
 		log.Errorf("invalid request body %v", body)
@@ -91,6 +133,13 @@ func (c *ProjectsController) UpdateProject(r *http.Request, vars map[string]stri
 	project.Team = newProject.Team
 	project.Stream = newProject.Stream
 	project.Labels = newProject.Labels
+	// clients unaware of secret readers and member expiries keep the existing ones
+	if newProject.SecretReaders != nil {
+		project.SecretReaders = newProject.SecretReaders
+	}
+	if newProject.MemberExpiries != nil {
+		project.MemberExpiries = newProject.MemberExpiries
+	}
 	updatedProject, response, err := c.ProjectsService.UpdateProject(r.Context(), project)
 	if err != nil {
 		log.Errorf("error updating project %s: %s", project.Name, err)
@@ -98,10 +147,48 @@ func (c *ProjectsController) UpdateProject(r *http.Request, vars map[string]stri
 	}
 
 	if response != nil {
-		return Ok(response)
+		return Ok(response).WithHeader("ETag", projectETag(updatedProject))
+	}
+
+	return Ok(updatedProject).WithHeader("ETag", projectETag(updatedProject))
+}
+
+func (c *ProjectsController) PatchProject(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	patchType := service.PatchType(mediaType)
+	if err != nil || (patchType != service.MergePatchType && patchType != service.JSONPatchType) {
+		return UnsupportedMediaType(fmt.Sprintf("Content-Type must be either %s or %s",
+			service.MergePatchType, service.JSONPatchType))
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return BadRequest(fmt.Sprintf("Failed to read request body: %s", err))
+	}
+
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	if !matchesETag(r.Header.Get("If-Match"), projectETag(project)) {
+		return PreconditionFailed(fmt.Sprintf("Project %s has been modified, fetch the latest version and retry",
+			project.Name))
+	}
+
+	updatedProject, response, err := c.ProjectsService.PatchProject(r.Context(), project, patchType, patch)
+	if err != nil {
+		log.Errorf("error patching project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+
+	if response != nil {
+		return Ok(response).WithHeader("ETag", projectETag(updatedProject))
 	}
 
-	return Ok(updatedProject)
+	return Ok(updatedProject).WithHeader("ETag", projectETag(updatedProject))
 }
 
 func (c *ProjectsController) GetProject(_ *http.Request, vars map[string]string, _ interface{}) *Response {
@@ -112,7 +199,132 @@ func (c *ProjectsController) GetProject(_ *http.Request, vars map[string]string,
 		return FromError(err)
 	}
 
-	return Ok(project)
+	return Ok(project).WithHeader("ETag", projectETag(project))
+}
+
+func (c *ProjectsController) ArchiveProject(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	archivedProject, err := c.ProjectsService.ArchiveProject(r.Context(), project)
+	if err != nil {
+		log.Errorf("error archiving project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+
+	return Ok(archivedProject).WithHeader("ETag", projectETag(archivedProject))
+}
+
+func (c *ProjectsController) UnarchiveProject(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	unarchivedProject, err := c.ProjectsService.UnarchiveProject(r.Context(), project)
+	if err != nil {
+		log.Errorf("error unarchiving project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+
+	return Ok(unarchivedProject).WithHeader("ETag", projectETag(unarchivedProject))
+}
+
+func (c *ProjectsController) RenameProject(r *http.Request, vars map[string]string, body interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	if !matchesETag(r.Header.Get("If-Match"), projectETag(project)) {
+		return PreconditionFailed(fmt.Sprintf("Project %s has been modified, fetch the latest version and retry",
+			project.Name))
+	}
+
+	request, ok := body.(*RenameProjectRequest)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body")
+	}
+
+	renamedProject, err := c.ProjectsService.RenameProject(r.Context(), project, request.Name)
+	if err != nil {
+		log.Errorf("error renaming project %s to %s: %s", project.Name, request.Name, err)
+		return FromError(err)
+	}
+
+	return Ok(renamedProject).WithHeader("ETag", projectETag(renamedProject))
+}
+
+func (c *ProjectsController) DeleteProject(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	err = c.ProjectsService.DeleteProject(r.Context(), project)
+	if err != nil {
+		log.Errorf("error deleting project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+
+	return NoContent()
+}
+
+func (c *ProjectsController) ListProjectHistory(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	options, err := newPaginationOptions(vars, projectsPaginator)
+	if err != nil {
+		return BadRequest(err.Error())
+	}
+	if options == nil {
+		defaultOptions := projectsPaginator.NewPaginationOptions(nil, nil)
+		options = &defaultOptions
+	}
+
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	auditLogs, paging, err := c.ProjectsService.ListProjectHistory(project.ID, *options)
+	if err != nil {
+		log.Errorf("error fetching history of project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+
+	return Ok(ProjectAuditLogList{Results: auditLogs, Paging: paging})
+}
+
+func (c *ProjectsController) ListAuthorizationSyncs(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	// the authorization policies are not synced if authorization is disabled
+	syncs := make([]*models.AuthorizationSync, 0)
+	if c.AuthorizationSyncService != nil {
+		syncs, err = c.AuthorizationSyncService.ListProjectSyncs(project.ID)
+		if err != nil {
+			log.Errorf("error fetching authorization syncs of project %s: %s", project.Name, err)
+			return FromError(err)
+		}
+	}
+	return Ok(syncs)
 }
 
 func (c *ProjectsController) Routes() []Route {
@@ -123,6 +335,7 @@ func (c *ProjectsController) Routes() []Route {
 			nil,
 			c.GetProject,
 			"GetProject",
+			middleware.Requires("projects.{project_id}", "get"),
 		},
 		{
 			http.MethodGet,
@@ -130,6 +343,7 @@ func (c *ProjectsController) Routes() []Route {
 			nil,
 			c.ListProjects,
 			"ListProjects",
+			middleware.Public(),
 		},
 		{
 			http.MethodPost,
@@ -137,6 +351,7 @@ func (c *ProjectsController) Routes() []Route {
 			models.Project{},
 			c.CreateProject,
 			"CreateProject",
+			middleware.Public(),
 		},
 		{
 			http.MethodPut,
@@ -144,8 +359,158 @@ func (c *ProjectsController) Routes() []Route {
 			models.Project{},
 			c.UpdateProject,
 			"UpdateProject",
+			middleware.Requires("projects.{project_id}", "put"),
+		},
+		{
+			http.MethodPatch,
+			"/projects/{project_id:[0-9]+}",
+			nil,
+			c.PatchProject,
+			"PatchProject",
+			middleware.Requires("projects.{project_id}", "patch"),
+		},
+		{
+			http.MethodDelete,
+			"/projects/{project_id:[0-9]+}",
+			nil,
+			c.DeleteProject,
+			"DeleteProject",
+			middleware.Requires("projects.{project_id}", "delete"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/rename",
+			RenameProjectRequest{},
+			c.RenameProject,
+			"RenameProject",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/history",
+			nil,
+			c.ListProjectHistory,
+			"ListProjectHistory",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/authorization_syncs",
+			nil,
+			c.ListAuthorizationSyncs,
+			"ListAuthorizationSyncs",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/archive",
+			nil,
+			c.ArchiveProject,
+			"ArchiveProject",
+			middleware.Requires("projects.{project_id}", "post"),
 		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/unarchive",
+			nil,
+			c.UnarchiveProject,
+			"UnarchiveProject",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+	}
+}
+
+// projectETag returns the entity tag of the current version of the project
+func projectETag(project *models.Project) string {
+	return strconv.Quote(strconv.Itoa(project.Version))
+}
+
+// matchesETag returns true if the If-Match header is absent, is "*", or lists the given entity tag. Weak entity tags
+// are compared by their opaque value.
+func matchesETag(ifMatch string, etag string) bool {
+	if strings.TrimSpace(ifMatch) == "" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// newProjectFilter creates the filter for listing projects from the query parameters
+func newProjectFilter(vars map[string]string) (repository.ProjectFilter, error) {
+	filter := repository.ProjectFilter{
+		Name:          vars["name"],
+		Team:          vars["team"],
+		Stream:        vars["stream"],
+		Administrator: vars["administrator"],
+		Reader:        vars["reader"],
+		Sort:          vars["sort"],
+	}
+
+	var err error
+	filter.LabelSelector, err = labels.Parse(vars["label_selector"])
+	if err != nil {
+		return filter, err
+	}
+
+	for param, value := range map[string]**time.Time{
+		"created_after":  &filter.CreatedAfter,
+		"created_before": &filter.CreatedBefore,
+		"updated_after":  &filter.UpdatedAfter,
+		"updated_before": &filter.UpdatedBefore,
+	} {
+		if vars[param] == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, vars[param])
+		if err != nil {
+			return filter, fmt.Errorf("%s must be a RFC3339 timestamp", param)
+		}
+		*value = &t
+	}
+
+	options, err := newPaginationOptions(vars, projectsPaginator)
+	if err != nil {
+		return filter, err
+	}
+	if options != nil {
+		filter.Options = *options
+	}
+
+	return filter, nil
+}
+
+// newPaginationOptions creates the pagination options from the page and page_size query parameters. It returns nil if
+// neither of them is specified.
+func newPaginationOptions(vars map[string]string, paginator pagination.Paginator) (*pagination.Options, error) {
+	var page, pageSize *int32
+	for param, value := range map[string]**int32{
+		"page":      &page,
+		"page_size": &pageSize,
+	} {
+		if vars[param] == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(vars[param], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer", param)
+		}
+		i32 := int32(i)
+		*value = &i32
+	}
+	if page == nil && pageSize == nil {
+		return nil, nil
+	}
+
+	if err := paginator.ValidatePaginationParams(page, pageSize); err != nil {
+		return nil, err
 	}
+	options := paginator.NewPaginationOptions(page, pageSize)
+	return &options, nil
 }
 
 // addRequester add requester to users slice if it doesn't exists
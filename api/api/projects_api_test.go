@@ -5,12 +5,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gavv/httpexpect/v2"
 	mux2 "github.com/gorilla/mux"
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,7 @@ import (
 	"github.com/caraml-dev/mlp/api/config"
 	"github.com/caraml-dev/mlp/api/it/database"
 	"github.com/caraml-dev/mlp/api/models"
+	apperror "github.com/caraml-dev/mlp/api/pkg/errors"
 	"github.com/caraml-dev/mlp/api/repository"
 	"github.com/caraml-dev/mlp/api/service"
 )
@@ -208,8 +211,13 @@ func TestCreateProject(t *testing.T) {
 					assert.NoError(t, err)
 				}
 				projectService, err := service.NewProjectsService(
-					mlflowTrackingURL, prjRepository, nil, false, nil,
+					mlflowTrackingURL, prjRepository, nil, nil, nil, nil, false, nil,
 					config.UpdateProjectConfig{},
+					config.ProjectNamingConfig{},
+					nil,
+					nil,
+					nil,
+					nil,
 				)
 				assert.NoError(t, err)
 
@@ -261,6 +269,56 @@ func TestCreateProject(t *testing.T) {
 	}
 }
 
+func TestCreateProjectNamingPolicy(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		prjRepository := repository.NewProjectRepository(db)
+		projectService, err := service.NewProjectsService(
+			mlflowTrackingURL, prjRepository, nil, nil, nil, nil, false, nil,
+			config.UpdateProjectConfig{},
+			config.ProjectNamingConfig{
+				ReservedNames: []string{"monitoring"},
+				StreamConventions: map[string]config.StreamNamingConvention{
+					"dsp": {RequireTeamPrefix: true},
+				},
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+		assert.NoError(t, err)
+
+		appCtx := &AppContext{
+			ProjectsService:      projectService,
+			AuthorizationEnabled: false,
+		}
+		r := NewRouter(appCtx, []Controller{&ProjectsController{appCtx}})
+
+		requestByte, _ := json.Marshal(&models.Project{Name: "monitoring", Team: "fraud", Stream: "dsp"})
+		req, err := http.NewRequest(http.MethodPost, "/projects", bytes.NewReader(requestByte))
+		assert.NoError(t, err)
+		req.Header["User-Email"] = []string{adminUser}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		response := ValidationErrorMessage{}
+		err = json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, ValidationErrorMessage{
+			Message: "project name monitoring violates the naming policy",
+			Violations: []apperror.FieldViolation{
+				{Field: "name", Rule: "reserved_name", Message: "monitoring is a reserved project name"},
+				{
+					Field:   "name",
+					Rule:    "team_prefix",
+					Message: "names of projects in stream dsp must start with the team name followed by a hyphen: fraud-",
+				},
+			},
+		}, response)
+	})
+}
+
 func TestListProjects(t *testing.T) {
 	testCases := []struct {
 		desc             string
@@ -321,8 +379,13 @@ func TestListProjects(t *testing.T) {
 					}
 				}
 				projectService, err := service.NewProjectsService(
-					mlflowTrackingURL, prjRepository, nil, false, nil,
+					mlflowTrackingURL, prjRepository, nil, nil, nil, nil, false, nil,
 					config.UpdateProjectConfig{},
+					config.ProjectNamingConfig{},
+					nil,
+					nil,
+					nil,
+					nil,
 				)
 				assert.NoError(t, err)
 
@@ -601,8 +664,13 @@ func TestUpdateProject(t *testing.T) {
 				}
 
 				projectService, err := service.NewProjectsService(
-					mlflowTrackingURL, prjRepository, nil, false, nil,
+					mlflowTrackingURL, prjRepository, nil, nil, nil, nil, false, nil,
 					tC.updateProjectConfig,
+					config.ProjectNamingConfig{},
+					nil,
+					nil,
+					nil,
+					nil,
 				)
 				assert.NoError(t, err)
 
@@ -733,8 +801,13 @@ func TestGetProject(t *testing.T) {
 					assert.NoError(t, err)
 				}
 				projectService, err := service.NewProjectsService(
-					mlflowTrackingURL, prjRepository, nil, false, nil,
+					mlflowTrackingURL, prjRepository, nil, nil, nil, nil, false, nil,
 					config.UpdateProjectConfig{},
+					config.ProjectNamingConfig{},
+					nil,
+					nil,
+					nil,
+					nil,
 				)
 				assert.NoError(t, err)
 
@@ -783,3 +856,309 @@ func TestGetProject(t *testing.T) {
 		})
 	}
 }
+
+func (s *APITestSuite) TestDeleteProject() {
+	tests := []struct {
+		name string
+		path string
+		want *Response
+	}{
+		{
+			name: "success: delete project",
+			path: fmt.Sprintf("/v1/projects/%d", s.mainProject.ID),
+			want: &Response{
+				code: http.StatusNoContent,
+			},
+		},
+		{
+			name: "failed: project not found",
+			path: fmt.Sprintf("/v1/projects/%d", 123),
+			want: &Response{
+				code: http.StatusNotFound,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			server := httptest.NewServer(s.route)
+			defer server.Close()
+
+			e := httpexpect.Default(s.T(), server.URL)
+
+			e.DELETE(tt.path).
+				Expect().
+				Status(tt.want.code)
+		})
+	}
+
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+	e.GET(fmt.Sprintf("/v1/projects/%d", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusNotFound)
+	e.GET(fmt.Sprintf("/v1/projects/%d/secret_storages/%d", s.mainProject.ID, s.projectSecretStorage.ID)).
+		Expect().
+		Status(http.StatusNotFound)
+	e.GET(fmt.Sprintf("/v1/projects/%d", s.otherProject.ID)).
+		Expect().
+		Status(http.StatusOK)
+}
+
+func (s *APITestSuite) TestArchiveProject() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	e.POST(fmt.Sprintf("/v1/projects/%d/archive", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().ContainsKey("archived_at")
+
+	projects := e.GET("/v1/projects").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array()
+	projects.Length().IsEqual(1)
+	projects.Value(0).Object().Value("name").IsEqual(s.otherProject.Name)
+
+	e.GET(fmt.Sprintf("/v1/projects/%d", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST(fmt.Sprintf("/v1/projects/%d/unarchive", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().NotContainsKey("archived_at")
+
+	e.GET("/v1/projects").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(2)
+}
+
+func (s *APITestSuite) TestListProjectsWithFilter() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	projects := e.GET("/v1/projects").
+		WithQuery("sort", "-name").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array()
+	projects.Length().IsEqual(2)
+	projects.Value(0).Object().Value("name").IsEqual(s.mainProject.Name)
+	projects.Value(1).Object().Value("name").IsEqual(s.otherProject.Name)
+
+	e.GET("/v1/projects").
+		WithQuery("name", "other").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	paginated := e.GET("/v1/projects").
+		WithQuery("page", 2).
+		WithQuery("page_size", 1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	paginated.Value("results").Array().Length().IsEqual(1)
+	paginated.Value("results").Array().Value(0).Object().Value("name").IsEqual(s.mainProject.Name)
+	paginated.Value("paging").Object().IsEqual(map[string]interface{}{"page": 2, "pages": 2, "total": 2})
+
+	for query, value := range map[string]string{
+		"label_selector": "env in (staging",
+		"created_after":  "yesterday",
+		"page_size":      "0",
+		"sort":           "labels",
+	} {
+		e.GET("/v1/projects").
+			WithQuery(query, value).
+			Expect().
+			Status(http.StatusBadRequest)
+	}
+}
+
+func (s *APITestSuite) TestUpdateProjectWithETag() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+	path := fmt.Sprintf("/v1/projects/%d", s.mainProject.ID)
+
+	etag := e.GET(path).
+		Expect().
+		Status(http.StatusOK).
+		Header("ETag").NotEmpty().Raw()
+
+	updated := e.PUT(path).
+		WithHeader("If-Match", etag).
+		WithJSON(map[string]interface{}{"team": "dsp", "stream": "dsp"}).
+		Expect().
+		Status(http.StatusOK)
+	updated.JSON().Object().Value("team").IsEqual("dsp")
+	newETag := updated.Header("ETag").NotEqual(etag).Raw()
+
+	e.PUT(path).
+		WithHeader("If-Match", etag).
+		WithJSON(map[string]interface{}{"team": "growth", "stream": "dsp"}).
+		Expect().
+		Status(http.StatusPreconditionFailed)
+
+	e.GET(path).
+		Expect().
+		Status(http.StatusOK).
+		Header("ETag").IsEqual(newETag)
+
+	e.PUT(path).
+		WithHeader("If-Match", "*").
+		WithJSON(map[string]interface{}{"team": "growth", "stream": "dsp"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("team").IsEqual("growth")
+}
+
+func (s *APITestSuite) TestPatchProject() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+	path := fmt.Sprintf("/v1/projects/%d", s.mainProject.ID)
+
+	etag := e.PATCH(path).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"team": "dsp", "readers": ["reader@example.com"]}`)).
+		Expect().
+		Status(http.StatusOK).
+		Header("ETag").NotEmpty().Raw()
+
+	project := e.PATCH(path).
+		WithHeader("Content-Type", "application/json-patch+json").
+		WithHeader("If-Match", etag).
+		WithBytes([]byte(`[{"op": "add", "path": "/readers/-", "value": "other@example.com"}]`)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	project.Value("team").IsEqual("dsp")
+	project.Value("readers").Array().IsEqual([]string{"reader@example.com", "other@example.com"})
+
+	e.PATCH(path).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithHeader("If-Match", etag).
+		WithBytes([]byte(`{"team": "growth"}`)).
+		Expect().
+		Status(http.StatusPreconditionFailed)
+
+	e.PATCH(path).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"name": "renamed-project"}`)).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	e.PATCH(path).
+		WithHeader("Content-Type", "application/merge-patch+json").
+		WithBytes([]byte(`{"team": ""}`)).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	e.PATCH(path).
+		WithJSON(map[string]interface{}{"team": "growth"}).
+		Expect().
+		Status(http.StatusUnsupportedMediaType)
+}
+
+func (s *APITestSuite) TestListProjectHistory() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+	path := fmt.Sprintf("/v1/projects/%d", s.mainProject.ID)
+
+	e.PUT(path).
+		WithHeader("User-Email", "admin@example.com").
+		WithHeader("X-Request-ID", "request-1").
+		WithJSON(map[string]interface{}{"team": "dsp", "stream": "dsp", "readers": []string{"reader@example.com"}}).
+		Expect().
+		Status(http.StatusOK).
+		Header("X-Request-ID").IsEqual("request-1")
+
+	history := e.GET(path+"/history").
+		WithQuery("page_size", 1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	history.Value("paging").Object().Value("total").IsEqual(2)
+
+	auditLog := history.Value("results").Array().Value(0).Object()
+	auditLog.Value("action").IsEqual("updated")
+	auditLog.Value("actor").IsEqual("admin@example.com")
+	auditLog.Value("request_id").IsEqual("request-1")
+	auditLog.Value("changes").Object().Value("readers").Object().
+		Value("after").Array().IsEqual([]string{"reader@example.com"})
+
+	e.GET(path+"/history").
+		WithQuery("page", 2).
+		WithQuery("page_size", 1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("results").Array().Value(0).Object().Value("action").IsEqual("created")
+}
+
+func (s *APITestSuite) TestListAuthorizationSyncs() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	// the authorization policies are not synced as authorization is disabled
+	e.GET(fmt.Sprintf("/v1/projects/%d/authorization_syncs", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().IsEmpty()
+
+	e.GET("/v1/projects/999999/authorization_syncs").
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func (s *APITestSuite) TestRenameProject() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	e.POST(fmt.Sprintf("/v1/projects/%d/rename", s.mainProject.ID)).
+		WithJSON(RenameProjectRequest{Name: "renamed-project"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("name").IsEqual("renamed-project")
+
+	// secrets stored in vault are moved to the path of the new project name
+	secrets := e.GET(fmt.Sprintf("/v1/projects/%d/secrets", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array()
+	secrets.Length().IsEqual(len(s.existingSecrets))
+	secretData := make(map[string]interface{})
+	for _, value := range secrets.Iter() {
+		object := value.Object()
+		secretData[object.Value("name").String().Raw()] = object.Value("data").Raw()
+	}
+	for _, secret := range s.existingSecrets {
+		s.Assert().Equal(secret.Data, secretData[secret.Name])
+	}
+
+	e.POST(fmt.Sprintf("/v1/projects/%d/rename", s.otherProject.ID)).
+		WithJSON(RenameProjectRequest{Name: "renamed-project"}).
+		Expect().
+		Status(http.StatusConflict)
+
+	e.POST(fmt.Sprintf("/v1/projects/%d/rename", s.otherProject.ID)).
+		WithJSON(RenameProjectRequest{Name: "-invalid-project"}).
+		Expect().
+		Status(http.StatusBadRequest)
+}
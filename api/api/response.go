@@ -9,15 +9,34 @@ import (
 )
 
 type Response struct {
-	code int
-	data interface{}
+	code    int
+	data    interface{}
+	headers map[string]string
 }
 
 type ErrorMessage struct {
 	Message string `json:"error"`
 }
 
+// ValidationErrorMessage is the response body of a request that failed validation
+type ValidationErrorMessage struct {
+	Message    string                    `json:"error"`
+	Violations []apperror.FieldViolation `json:"violations"`
+}
+
+// WithHeader sets an additional header to be written with the response
+func (r *Response) WithHeader(key string, value string) *Response {
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+	r.headers[key] = value
+	return r
+}
+
 func (r *Response) WriteTo(w http.ResponseWriter) {
+	for key, value := range r.headers {
+		w.Header().Set(key, value)
+	}
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(r.code)
 
@@ -66,6 +85,10 @@ func InternalServerError(msg string) *Response {
 	return Error(http.StatusInternalServerError, msg)
 }
 
+func Unauthorized(msg string) *Response {
+	return Error(http.StatusUnauthorized, msg)
+}
+
 func Forbidden(msg string) *Response {
 	return Error(http.StatusForbidden, msg)
 }
@@ -74,13 +97,37 @@ func Conflict(msg string) *Response {
 	return Error(http.StatusConflict, msg)
 }
 
+func UnsupportedMediaType(msg string) *Response {
+	return Error(http.StatusUnsupportedMediaType, msg)
+}
+
+func PreconditionFailed(msg string) *Response {
+	return Error(http.StatusPreconditionFailed, msg)
+}
+
+func ValidationFailed(err *apperror.ValidationError) *Response {
+	return &Response{
+		code: http.StatusBadRequest,
+		data: ValidationErrorMessage{Message: err.Message(), Violations: err.Violations},
+	}
+}
+
 func FromError(err error) *Response {
+	var validationErr *apperror.ValidationError
+	if errors.As(err, &validationErr) {
+		return ValidationFailed(validationErr)
+	}
+
 	if errors.Is(err, &apperror.NotFoundError{}) {
 		return NotFound(err.Error())
 	} else if errors.Is(err, &apperror.AlreadyExistsError{}) {
 		return Conflict(err.Error())
 	} else if errors.Is(err, &apperror.InvalidArgumentError{}) {
 		return BadRequest(err.Error())
+	} else if errors.Is(err, &apperror.PreconditionFailedError{}) {
+		return PreconditionFailed(err.Error())
+	} else if errors.Is(err, &apperror.UnauthenticatedError{}) {
+		return Unauthorized(err.Error())
 	}
 
 	return InternalServerError(err.Error())
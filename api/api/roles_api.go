@@ -0,0 +1,218 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+type RolesController struct {
+	*AppContext
+}
+
+func (c *RolesController) ListRoles(_ *http.Request, _ map[string]string, _ interface{}) *Response {
+	roles, err := c.RolesService.ListRoles()
+	if err != nil {
+		log.Errorf("error fetching roles: %s", err)
+		return FromError(err)
+	}
+	return Ok(roles)
+}
+
+func (c *RolesController) GetRole(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	roleID, _ := models.ParseID(vars["role_id"])
+	role, err := c.RolesService.FindRoleByID(roleID)
+	if err != nil {
+		log.Errorf("error fetching role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+	return Ok(role)
+}
+
+func (c *RolesController) CreateRole(r *http.Request, _ map[string]string, body interface{}) *Response {
+	role, ok := body.(*models.Role)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as role")
+	}
+	role.ID = 0
+
+	role, err := c.RolesService.CreateRole(r.Context(), role)
+	if err != nil {
+		log.Errorf("error creating role: %s", err)
+		return FromError(err)
+	}
+	return Created(role)
+}
+
+func (c *RolesController) UpdateRole(r *http.Request, vars map[string]string, body interface{}) *Response {
+	roleID, _ := models.ParseID(vars["role_id"])
+	role, err := c.RolesService.FindRoleByID(roleID)
+	if err != nil {
+		log.Errorf("error fetching role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+
+	updateRequest, ok := body.(*models.Role)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as role")
+	}
+	role.Name = updateRequest.Name
+	role.Description = updateRequest.Description
+	role.Permissions = updateRequest.Permissions
+	role.ProjectID = updateRequest.ProjectID
+
+	role, err = c.RolesService.UpdateRole(r.Context(), role)
+	if err != nil {
+		log.Errorf("error updating role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+	return Ok(role)
+}
+
+func (c *RolesController) DeleteRole(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	roleID, _ := models.ParseID(vars["role_id"])
+	role, err := c.RolesService.FindRoleByID(roleID)
+	if err != nil {
+		log.Errorf("error fetching role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+
+	if err := c.RolesService.DeleteRole(r.Context(), role); err != nil {
+		log.Errorf("error deleting role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+func (c *RolesController) ListRoleAssignments(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	assignments, err := c.RolesService.ListRoleAssignments(project.ID)
+	if err != nil {
+		log.Errorf("error fetching role assignments of project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+	return Ok(assignments)
+}
+
+func (c *RolesController) AssignRole(r *http.Request, vars map[string]string, body interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+	roleID, _ := models.ParseID(vars["role_id"])
+	role, err := c.RolesService.FindRoleByID(roleID)
+	if err != nil {
+		log.Errorf("error fetching role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+
+	assignment, ok := body.(*models.RoleAssignment)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as role assignment")
+	}
+
+	assignment, err = c.RolesService.AssignRole(r.Context(), project, role, assignment.Members)
+	if err != nil {
+		log.Errorf("error assigning role %s in project %s: %s", role.Name, project.Name, err)
+		return FromError(err)
+	}
+	return Ok(assignment)
+}
+
+func (c *RolesController) UnassignRole(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	roleID, _ := models.ParseID(vars["role_id"])
+	assignment, err := c.RolesService.FindRoleAssignment(projectID, roleID)
+	if err != nil {
+		log.Errorf("error fetching assignment of role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+
+	if err := c.RolesService.UnassignRole(r.Context(), assignment); err != nil {
+		log.Errorf("error unassigning role with ID %d: %s", roleID, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+func (c *RolesController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/roles",
+			nil,
+			c.ListRoles,
+			"ListRoles",
+			middleware.Public(),
+		},
+		{
+			http.MethodGet,
+			"/roles/{role_id:[0-9]+}",
+			nil,
+			c.GetRole,
+			"GetRole",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/roles",
+			models.Role{},
+			c.CreateRole,
+			"CreateRole",
+			middleware.Requires("roles", "post"),
+		},
+		{
+			http.MethodPut,
+			"/roles/{role_id:[0-9]+}",
+			models.Role{},
+			c.UpdateRole,
+			"UpdateRole",
+			middleware.Requires("roles", "put"),
+		},
+		{
+			http.MethodDelete,
+			"/roles/{role_id:[0-9]+}",
+			nil,
+			c.DeleteRole,
+			"DeleteRole",
+			middleware.Requires("roles", "delete"),
+		},
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/roles",
+			nil,
+			c.ListRoleAssignments,
+			"ListRoleAssignments",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodPut,
+			"/projects/{project_id:[0-9]+}/roles/{role_id:[0-9]+}",
+			models.RoleAssignment{},
+			c.AssignRole,
+			"AssignRole",
+			middleware.Requires("projects.{project_id}", "put"),
+		},
+		{
+			http.MethodDelete,
+			"/projects/{project_id:[0-9]+}/roles/{role_id:[0-9]+}",
+			nil,
+			c.UnassignRole,
+			"UnassignRole",
+			middleware.Requires("projects.{project_id}", "delete"),
+		},
+	}
+}
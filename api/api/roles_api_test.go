@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestRoles() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	role := e.POST("/v1/roles").
+		WithJSON(models.Role{Name: "deployer", Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	roleID := int(role.Value("id").Number().Raw())
+
+	e.POST("/v1/roles").
+		WithJSON(models.Role{Name: "deployer", Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"}}).
+		Expect().
+		Status(http.StatusConflict)
+	// custom roles cannot grant permissions outside of the project in which they are assigned
+	e.POST("/v1/roles").
+		WithJSON(models.Role{Name: "creator", Permissions: []string{"mlp.projects.post"}}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	assignment := e.PUT(fmt.Sprintf("/v1/projects/%d/roles/%d", s.mainProject.ID, roleID)).
+		WithJSON(models.RoleAssignment{Members: []string{"deployer@example.com"}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object()
+	assignment.Value("members").Array().ContainsOnly("deployer@example.com")
+	e.PUT(fmt.Sprintf("/v1/projects/%d/roles/%d", s.mainProject.ID, roleID)).
+		WithJSON(models.RoleAssignment{Members: []string{}}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	assignments := e.GET(fmt.Sprintf("/v1/projects/%d/roles", s.mainProject.ID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array()
+	assignments.Length().IsEqual(1)
+	assignments.Value(0).Object().Value("role").Object().Value("name").IsEqual("deployer")
+
+	// an assigned role can be neither renamed nor deleted
+	e.PUT(fmt.Sprintf("/v1/roles/%d", roleID)).
+		WithJSON(models.Role{Name: "releaser", Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"}}).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.DELETE(fmt.Sprintf("/v1/roles/%d", roleID)).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	e.DELETE(fmt.Sprintf("/v1/projects/%d/roles/%d", s.mainProject.ID, roleID)).
+		Expect().
+		Status(http.StatusNoContent)
+	e.DELETE(fmt.Sprintf("/v1/projects/%d/roles/%d", s.mainProject.ID, roleID)).
+		Expect().
+		Status(http.StatusNotFound)
+	e.DELETE(fmt.Sprintf("/v1/roles/%d", roleID)).
+		Expect().
+		Status(http.StatusNoContent)
+}
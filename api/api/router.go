@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"reflect"
 
 	"github.com/go-playground/validator"
@@ -12,8 +14,10 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/database"
 	"github.com/caraml-dev/mlp/api/middleware"
 	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authn"
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/newrelic"
 	"github.com/caraml-dev/mlp/api/pkg/secretstorage"
@@ -28,12 +32,29 @@ type Controller interface {
 }
 
 type AppContext struct {
-	ApplicationService   service.ApplicationService
-	ProjectsService      service.ProjectsService
-	SecretService        service.SecretService
-	SecretStorageService service.SecretStorageService
-	DefaultSecretStorage *models.SecretStorage
-
+	ApplicationService     service.ApplicationService
+	ProjectsService        service.ProjectsService
+	SecretService          service.SecretService
+	SecretStorageService   service.SecretStorageService
+	ProjectBundleService   service.ProjectBundleService
+	ProjectReconciler      service.ProjectReconciler
+	StreamsService         service.StreamsService
+	GroupsService          service.GroupsService
+	AccessRequestsService  service.AccessRequestsService
+	ServiceAccountsService service.ServiceAccountsService
+	RolesService           service.RolesService
+	AuthorizationService   service.AuthorizationService
+	// AuthorizationReconciler repairs the authorization relations of the resources, if authorization is enabled
+	AuthorizationReconciler service.AuthorizationReconciler
+	// AuthorizationSyncService applies the outbox of the authorization policies of the projects, if authorization
+	// is enabled
+	AuthorizationSyncService service.AuthorizationSyncService
+	// AuthorizationDecisionsService queries the authorization decision log, if it is stored in the database
+	AuthorizationDecisionsService service.AuthorizationDecisionsService
+	DefaultSecretStorage          *models.SecretStorage
+
+	// Authenticator verifies the users of the requests, if authentication is enabled
+	Authenticator              *middleware.Authenticator
 	AuthorizationEnabled       bool
 	UseAuthorizationMiddleware bool
 	Enforcer                   enforcer.Enforcer
@@ -44,11 +65,24 @@ func NewAppContext(db *gorm.DB, cfg *config.Config) (ctx *AppContext, err error)
 	if cfg.Authorization.Enabled {
 		enforcerCfg := enforcer.NewEnforcerBuilder()
 		enforcerCfg.KetoEndpoints(cfg.Authorization.KetoRemoteRead, cfg.Authorization.KetoRemoteWrite)
-		if cfg.Authorization.Caching.Enabled {
+		if cfg.Authorization.Backend == config.DatabaseAuthorizationBackend {
+			enforcerCfg.Database(db.DB())
+		} else if cfg.Authorization.Caching.Enabled {
 			enforcerCfg = enforcerCfg.WithCaching(
 				cfg.Authorization.Caching.KeyExpirySeconds,
 				cfg.Authorization.Caching.CacheCleanUpIntervalSeconds,
 			)
+			if channel := cfg.Authorization.Caching.InvalidationChannel; channel != "" {
+				enforcerCfg.WithCacheInvalidationBroadcaster(
+					enforcer.NewPostgresBroadcaster(db.DB(), database.ConnectionString(cfg.Database), channel))
+			}
+		}
+		if cfg.Authorization.DecisionLog.Enabled {
+			decisionSink, err := newDecisionSink(db, cfg.Authorization.DecisionLog)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize authorization decision log: %v", err)
+			}
+			enforcerCfg.WithDecisionLog(decisionSink)
 		}
 		authEnforcer, err = enforcerCfg.Build()
 
@@ -57,6 +91,25 @@ func NewAppContext(db *gorm.DB, cfg *config.Config) (ctx *AppContext, err error)
 		}
 	}
 
+	var authenticator *middleware.Authenticator
+	if cfg.Authentication.Enabled {
+		verifier, err := authn.NewVerifier(authn.VerifierConfig{
+			Issuer:              cfg.Authentication.Issuer,
+			Audience:            cfg.Authentication.Audience,
+			JWKSURL:             cfg.Authentication.JWKSURL,
+			JWKSFile:            cfg.Authentication.JWKSFile,
+			JWKSRefreshInterval: cfg.Authentication.JWKSRefreshInterval,
+			UserClaim:           cfg.Authentication.UserClaim,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize token verifier: %v", err)
+		}
+		authenticator, err = middleware.NewAuthenticator(verifier, cfg.Authentication.TrustedProxies)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize authenticator: %v", err)
+		}
+	}
+
 	applicationService, err := service.NewApplicationService(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize applications service: %v", err)
@@ -70,20 +123,25 @@ func NewAppContext(db *gorm.DB, cfg *config.Config) (ctx *AppContext, err error)
 		}
 	}
 
-	projectsService, err := service.NewProjectsService(
-		cfg.Mlflow.TrackingURL,
-		repository.NewProjectRepository(db),
-		authEnforcer,
-		cfg.Authorization.Enabled, projectsWebhookManager,
-		*cfg.UpdateProjectConfig)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize projects service: %v", err)
-	}
-
 	secretRepository := repository.NewSecretRepository(db)
 	storageRepository := repository.NewSecretStorageRepository(db)
 	projectRepository := repository.NewProjectRepository(db)
+	projectAuditLogRepository := repository.NewProjectAuditLogRepository(db)
+	streamRepository := repository.NewStreamRepository(db)
+	teamRepository := repository.NewTeamRepository(db)
+
+	streamsService := service.NewStreamsService(streamRepository, teamRepository, projectRepository, authEnforcer,
+		cfg.Authorization.Enabled, cfg.Catalogue.AllowCustomStreams, cfg.Catalogue.AllowCustomTeams)
+	groupRepository := repository.NewGroupRepository(db)
+	roleAssignmentRepository := repository.NewRoleAssignmentRepository(db)
+	groupsService := service.NewGroupsService(groupRepository, projectRepository, authEnforcer,
+		cfg.Authorization.Enabled)
+	serviceAccountsService := service.NewServiceAccountsService(repository.NewServiceAccountRepository(db),
+		repository.NewServiceAccountTokenRepository(db), projectRepository, roleAssignmentRepository)
+	// the streams of the configuration are kept as the initial catalogue
+	if err := streamsService.SyncStreams(context.Background(), cfg.Streams); err != nil {
+		return nil, fmt.Errorf("failed to initialize streams: %v", err)
+	}
 
 	// get all secret storages and create corresponding clients
 	allSecretStorages, err := storageRepository.ListAll()
@@ -95,6 +153,31 @@ func NewAppContext(db *gorm.DB, cfg *config.Config) (ctx *AppContext, err error)
 		return nil, fmt.Errorf("failed to initialize secret storage registry: %v", err)
 	}
 
+	var authorizationSyncService service.AuthorizationSyncService
+	if cfg.Authorization.Enabled {
+		authorizationSyncService = service.NewAuthorizationSyncService(repository.NewAuthorizationSyncRepository(db),
+			projectRepository, authEnforcer)
+	}
+
+	projectsService, err := service.NewProjectsService(
+		cfg.Mlflow.TrackingURL,
+		projectRepository,
+		storageRepository,
+		projectAuditLogRepository,
+		storageClientRegistry,
+		authEnforcer,
+		cfg.Authorization.Enabled, projectsWebhookManager,
+		*cfg.UpdateProjectConfig,
+		cfg.ProjectNaming,
+		streamsService,
+		groupsService,
+		serviceAccountsService,
+		authorizationSyncService)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize projects service: %v", err)
+	}
+
 	secretStorageService := service.NewSecretStorageService(storageRepository, projectRepository, storageClientRegistry)
 	// initialize default secret storage or create one
 	defaultSecretStorage, err := initializeDefaultSecretStorage(storageRepository, secretStorageService, cfg)
@@ -103,20 +186,73 @@ func NewAppContext(db *gorm.DB, cfg *config.Config) (ctx *AppContext, err error)
 	}
 
 	secretService := service.NewSecretService(secretRepository, storageRepository,
-		projectRepository, storageClientRegistry, defaultSecretStorage)
+		projectRepository, storageClientRegistry, defaultSecretStorage, authEnforcer, cfg.Authorization.Enabled)
+
+	projectBundleService := service.NewProjectBundleService(projectRepository, projectsService,
+		secretStorageService, secretService)
+
+	projectReconciler := service.NewProjectReconciler(projectRepository, projectsService)
+
+	accessRequestsService := service.NewAccessRequestsService(repository.NewProjectAccessRequestRepository(db),
+		projectsService, projectsWebhookManager)
+
+	rolesService := service.NewRolesService(repository.NewRoleRepository(db),
+		roleAssignmentRepository, authEnforcer, cfg.Authorization.Enabled)
+
+	authorizationService := service.NewAuthorizationService(groupsService, authEnforcer, cfg.Authorization.Enabled)
+
+	decisionLog := cfg.Authorization.DecisionLog
+	authorizationDecisionsService := service.NewAuthorizationDecisionsService(
+		repository.NewAuthorizationDecisionRepository(db),
+		cfg.Authorization.Enabled && decisionLog.Enabled && decisionLog.Sink == config.DatabaseDecisionLogSink)
+
+	var authorizationReconciler service.AuthorizationReconciler
+	if cfg.Authorization.Enabled {
+		authorizationReconciler = service.NewAuthorizationReconciler(projectRepository, roleAssignmentRepository,
+			groupRepository, streamRepository, teamRepository, authEnforcer)
+	}
 
 	return &AppContext{
-		ApplicationService:         applicationService,
-		ProjectsService:            projectsService,
-		SecretService:              secretService,
-		SecretStorageService:       secretStorageService,
-		AuthorizationEnabled:       cfg.Authorization.Enabled,
-		UseAuthorizationMiddleware: cfg.Authorization.UseMiddleware,
-		Enforcer:                   authEnforcer,
-		DefaultSecretStorage:       defaultSecretStorage,
+		ApplicationService:            applicationService,
+		ProjectsService:               projectsService,
+		SecretService:                 secretService,
+		SecretStorageService:          secretStorageService,
+		ProjectBundleService:          projectBundleService,
+		ProjectReconciler:             projectReconciler,
+		StreamsService:                streamsService,
+		GroupsService:                 groupsService,
+		AccessRequestsService:         accessRequestsService,
+		ServiceAccountsService:        serviceAccountsService,
+		RolesService:                  rolesService,
+		AuthorizationService:          authorizationService,
+		AuthorizationReconciler:       authorizationReconciler,
+		AuthorizationSyncService:      authorizationSyncService,
+		AuthorizationDecisionsService: authorizationDecisionsService,
+		Authenticator:                 authenticator,
+		AuthorizationEnabled:          cfg.Authorization.Enabled,
+		UseAuthorizationMiddleware:    cfg.Authorization.UseMiddleware,
+		Enforcer:                      authEnforcer,
+		DefaultSecretStorage:          defaultSecretStorage,
 	}, nil
 }
 
+// newDecisionSink returns the sink of the authorization decision log
+func newDecisionSink(db *gorm.DB, cfg config.DecisionLogConfig) (enforcer.DecisionSink, error) {
+	switch cfg.Sink {
+	case config.FileDecisionLogSink:
+		file, err := os.OpenFile(cfg.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return enforcer.NewJSONDecisionSink(file), nil
+	case config.DatabaseDecisionLogSink:
+		decisionRepository := repository.NewAuthorizationDecisionRepository(db)
+		return enforcer.NewBatchingDecisionSink(decisionRepository.SaveAll), nil
+	default:
+		return enforcer.NewJSONDecisionSink(os.Stdout), nil
+	}
+}
+
 func initializeDefaultSecretStorage(
 	secretStorageRepository repository.SecretStorageRepository,
 	secretStorageService service.SecretStorageService,
@@ -152,6 +288,8 @@ type Route struct {
 	Body    interface{}
 	Handler Handler
 	Name    string
+	// Permission is the permission required to call the route, which is enforced by the authorization middleware
+	Permission middleware.RoutePermission
 }
 
 func (route Route) HandlerFunc(validate *validator.Validate) http.HandlerFunc {
@@ -161,13 +299,17 @@ func (route Route) HandlerFunc(validate *validator.Validate) http.HandlerFunc {
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-
+		// the path variables take precedence over the query parameters, as the permission of the route is checked
+		// against the path variables only
+		vars := make(map[string]string)
 		for k, v := range r.URL.Query() {
 			if len(v) > 0 {
 				vars[k] = v[0]
 			}
 		}
+		for k, v := range mux.Vars(r) {
+			vars[k] = v
+		}
 
 		response := func() *Response {
 			vars["user"] = r.Header.Get("User-Email")
@@ -193,14 +335,32 @@ func NewRouter(appCtx *AppContext, controllers []Controller) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	validator := validation.NewValidator()
 
+	// the user is authenticated, and the subject of a service account resolved from its token, before the user is
+	// used by the other middlewares
+	if appCtx.Authenticator != nil {
+		router.Use(appCtx.Authenticator.AuthenticationMiddleware)
+	}
+	if appCtx.ServiceAccountsService != nil {
+		router.Use(middleware.ServiceAccountMiddleware(appCtx.ServiceAccountsService))
+	}
+	router.Use(middleware.RequestContextMiddleware)
+
+	var authorizer *middleware.Authorizer
 	if appCtx.AuthorizationEnabled && appCtx.UseAuthorizationMiddleware {
-		authzMiddleware := middleware.NewAuthorizer(appCtx.Enforcer)
-		router.Use(authzMiddleware.AuthorizationMiddleware)
+		authorizer = middleware.NewAuthorizer(appCtx.Enforcer)
 	}
 
 	for _, c := range controllers {
 		for _, r := range c.Routes() {
+			// every route has to declare its permission, whether the authorization middleware is enabled or not
+			if err := r.Permission.Validate(r.Path); err != nil {
+				panic(fmt.Sprintf("invalid permission of route %s: %s", r.Name, err))
+			}
+
 			_, handler := newrelic.WrapHandle(r.Name, r.HandlerFunc(validator))
+			if authorizer != nil {
+				handler = authorizer.RouteAuthorizationMiddleware(r.Permission)(handler)
+			}
 
 			if r.Name == "CreateProject" {
 				handler = middleware.ProjectCreationMiddleware(handler)
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/mlp/api/validation"
+)
+
+func TestRoutes_Permissions(t *testing.T) {
+	appCtx := &AppContext{}
+	controllers := []Controller{
+		&ApplicationsController{AppContext: appCtx},
+		&ProjectsController{AppContext: appCtx},
+		&SecretsController{AppContext: appCtx},
+		&SecretStoragesController{AppContext: appCtx},
+		&StreamsController{AppContext: appCtx},
+		&GroupsController{AppContext: appCtx},
+		&AccessRequestsController{AppContext: appCtx},
+		&ServiceAccountsController{AppContext: appCtx},
+		&RolesController{AppContext: appCtx},
+		&AuthorizationController{AppContext: appCtx},
+	}
+
+	for _, c := range controllers {
+		for _, r := range c.Routes() {
+			assert.NoError(t, r.Permission.Validate(r.Path), r.Name)
+
+			// the secrets can be listed by the readers of the project, but only managed by its administrators
+			if strings.Contains(r.Path, "/secrets") && r.Method != http.MethodGet {
+				permission, err := r.Permission.Permission(map[string]string{"project_id": "1", "secret_id": "2"})
+				assert.NoError(t, err, r.Name)
+				assert.True(t, strings.HasPrefix(permission, "mlp.projects.1.secrets."), r.Name)
+			}
+			// only listing and creating projects, and requesting access to them, do not need a permission on a project
+			if r.Permission.Public && strings.HasPrefix(r.Path, "/projects") {
+				assert.Contains(t, []string{"ListProjects", "CreateProject", "CreateAccessRequest"}, r.Name)
+			}
+		}
+	}
+}
+
+func TestRoute_HandlerFuncPathVariablesTakePrecedence(t *testing.T) {
+	var vars map[string]string
+	route := Route{
+		Method: http.MethodGet,
+		Path:   "/projects/{project_id}/secrets",
+		Handler: func(r *http.Request, v map[string]string, body interface{}) *Response {
+			vars = v
+			return Ok(nil)
+		},
+	}
+
+	router := mux.NewRouter()
+	router.Handle(route.Path, route.HandlerFunc(validation.NewValidator())).Methods(route.Method)
+
+	request := httptest.NewRequest(http.MethodGet, "/projects/1/secrets?project_id=2&page=3", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "1", vars["project_id"])
+	assert.Equal(t, "3", vars["page"])
+}
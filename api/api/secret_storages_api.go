@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
 	"github.com/caraml-dev/mlp/api/models"
 )
 
@@ -172,6 +173,7 @@ func (c *SecretStoragesController) Routes() []Route {
 			nil,
 			c.ListSecretStorage,
 			"ListSecretStorage",
+			middleware.Requires("projects.{project_id}", "get"),
 		},
 		{
 			http.MethodGet,
@@ -179,6 +181,7 @@ func (c *SecretStoragesController) Routes() []Route {
 			nil,
 			c.GetSecretStorage,
 			"GetSecretStorage",
+			middleware.Requires("projects.{project_id}", "get"),
 		},
 		{
 			http.MethodPost,
@@ -186,6 +189,7 @@ func (c *SecretStoragesController) Routes() []Route {
 			models.SecretStorage{},
 			c.CreateSecretStorage,
 			"CreateSecretStorage",
+			middleware.Requires("projects.{project_id}", "post"),
 		},
 		{
 			http.MethodPatch,
@@ -193,6 +197,7 @@ func (c *SecretStoragesController) Routes() []Route {
 			models.SecretStorage{},
 			c.UpdateSecretStorage,
 			"UpdateSecretStorage",
+			middleware.Requires("projects.{project_id}", "patch"),
 		},
 		{
 			http.MethodDelete,
@@ -200,6 +205,7 @@ func (c *SecretStoragesController) Routes() []Route {
 			nil,
 			c.DeleteSecretStorage,
 			"DeleteSecretStorage",
+			middleware.Requires("projects.{project_id}", "delete"),
 		},
 	}
 }
@@ -1,20 +1,23 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/jinzhu/copier"
 
 	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
 	"github.com/caraml-dev/mlp/api/models"
+	apperror "github.com/caraml-dev/mlp/api/pkg/errors"
 )
 
 type SecretsController struct {
 	*AppContext
 }
 
-func (c *SecretsController) GetSecret(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+func (c *SecretsController) GetSecret(r *http.Request, vars map[string]string, _ interface{}) *Response {
 	projectID, _ := models.ParseID(vars["project_id"])
 	secretID, _ := models.ParseID(vars["secret_id"])
 	if projectID <= 0 || secretID <= 0 {
@@ -27,6 +30,20 @@ func (c *SecretsController) GetSecret(_ *http.Request, vars map[string]string, _
 		log.Errorf("error fetching secret with ID %d: %s", secretID, err)
 		return FromError(err)
 	}
+	if secret.ProjectID != projectID {
+		return NotFound(fmt.Sprintf("Secret with given `secret_id: %d` not found", secretID))
+	}
+
+	// readers of the project can list its secrets but only read the values they have been granted
+	readable, err := c.SecretService.IsReadable(r.Context(), vars["user"], secret)
+	if err != nil {
+		log.Errorf("error checking the permission to read secret with ID %d: %s", secretID, err)
+		return FromError(err)
+	}
+	if !readable {
+		return Unauthorized(fmt.Sprintf("%s does not have the permission to read the secret %s", vars["user"],
+			secret.Name))
+	}
 
 	return Ok(secret)
 }
@@ -72,12 +89,17 @@ func (c *SecretsController) UpdateSecret(_ *http.Request, vars map[string]string
 	if err != nil {
 		return FromError(err)
 	}
+	if secret.ProjectID != projectID {
+		return NotFound(fmt.Sprintf("Secret with given `secret_id: %d` not found", secretID))
+	}
 
 	err = copier.CopyWithOption(secret, updateRequest, copier.Option{IgnoreEmpty: true})
 	if err != nil {
 		log.Errorf("Failed copy secret with %s", err)
 		return InternalServerError(err.Error())
 	}
+	// the secret cannot be moved to another project
+	secret.ProjectID = projectID
 
 	updatedSecret, err := c.SecretService.Update(secret)
 	if err != nil {
@@ -95,6 +117,18 @@ func (c *SecretsController) DeleteSecret(_ *http.Request, vars map[string]string
 		return BadRequest("project_id and secret_id are not valid")
 	}
 
+	secret, err := c.SecretService.FindByID(secretID)
+	if err != nil {
+		// deleting a secret that does not exist is a no-op
+		if errors.Is(err, &apperror.NotFoundError{}) {
+			return NoContent()
+		}
+		return FromError(err)
+	}
+	if secret.ProjectID != projectID {
+		return NotFound(fmt.Sprintf("Secret with given `secret_id: %d` not found", secretID))
+	}
+
 	if err := c.SecretService.Delete(secretID); err != nil {
 		log.Errorf("error deleting secret with id %v", err)
 		return InternalServerError(err.Error())
@@ -102,7 +136,7 @@ func (c *SecretsController) DeleteSecret(_ *http.Request, vars map[string]string
 	return NoContent()
 }
 
-func (c *SecretsController) ListSecret(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+func (c *SecretsController) ListSecret(r *http.Request, vars map[string]string, _ interface{}) *Response {
 	projectID, _ := models.ParseID(vars["project_id"])
 	_, err := c.ProjectsService.FindByID(projectID)
 	if err != nil {
@@ -115,6 +149,10 @@ func (c *SecretsController) ListSecret(_ *http.Request, vars map[string]string,
 		log.Errorf("error retrieving secret from project id %s: %s", projectID, err)
 		return FromError(err)
 	}
+	if err := c.SecretService.RedactUnreadable(r.Context(), vars["user"], secrets); err != nil {
+		log.Errorf("error checking the permissions to read secrets of project id %s: %s", projectID, err)
+		return FromError(err)
+	}
 	return Ok(secrets)
 }
 
@@ -126,6 +164,7 @@ func (c *SecretsController) Routes() []Route {
 			nil,
 			c.ListSecret,
 			"ListSecret",
+			middleware.Requires("projects.{project_id}", "get"),
 		},
 		{
 			http.MethodGet,
@@ -133,6 +172,7 @@ func (c *SecretsController) Routes() []Route {
 			nil,
 			c.GetSecret,
 			"GetSecret",
+			middleware.Requires("projects.{project_id}", "get"),
 		},
 		{
 			http.MethodPost,
@@ -140,6 +180,7 @@ func (c *SecretsController) Routes() []Route {
 			models.Secret{},
 			c.CreateSecret,
 			"CreateSecret",
+			middleware.Requires("projects.{project_id}.secrets", "post"),
 		},
 		{
 			http.MethodPatch,
@@ -147,6 +188,7 @@ func (c *SecretsController) Routes() []Route {
 			models.Secret{},
 			c.UpdateSecret,
 			"UpdateSecret",
+			middleware.Requires("projects.{project_id}.secrets", "patch"),
 		},
 		{
 			http.MethodDelete,
@@ -154,6 +196,7 @@ func (c *SecretsController) Routes() []Route {
 			nil,
 			c.DeleteSecret,
 			"DeleteSecret",
+			middleware.Requires("projects.{project_id}.secrets", "delete"),
 		},
 	}
 }
@@ -291,6 +291,39 @@ func (s *APITestSuite) TestUpdateSecret() {
 				},
 			},
 		},
+		{
+			name: "success: project of the secret is not updated",
+			args: args{
+				path: fmt.Sprintf("/v1/projects/%d/secrets/%d", s.mainProject.ID, s.existingSecrets[3].ID),
+				body: &models.Secret{
+					ProjectID: s.otherProject.ID,
+					Data:      "new-value",
+				},
+			},
+			want: &Response{
+				code: http.StatusOK,
+				data: &models.Secret{
+					ID:              s.existingSecrets[3].ID,
+					SecretStorageID: s.existingSecrets[3].SecretStorageID,
+					ProjectID:       s.mainProject.ID,
+					Name:            s.existingSecrets[3].Name,
+					Data:            "new-value",
+				},
+			},
+		},
+		{
+			name: "error: secret of another project",
+			args: args{
+				path: fmt.Sprintf("/v1/projects/%d/secrets/%d", s.otherProject.ID, s.existingSecrets[4].ID),
+				body: &models.Secret{
+					Data: "new-value",
+				},
+			},
+			want: &Response{
+				code: http.StatusNotFound,
+				data: ErrorMessage{fmt.Sprintf("Secret with given `secret_id: %d` not found", s.existingSecrets[4].ID)},
+			},
+		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
@@ -356,6 +389,15 @@ func (s *APITestSuite) TestDeleteSecret() {
 				code: http.StatusNoContent,
 			},
 		},
+		{
+			name: "error: secret of another project",
+			args: args{
+				path: fmt.Sprintf("/v1/projects/%d/secrets/%d", s.otherProject.ID, s.existingSecrets[1].ID),
+			},
+			want: &Response{
+				code: http.StatusNotFound,
+			},
+		},
 	}
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
@@ -370,6 +412,14 @@ func (s *APITestSuite) TestDeleteSecret() {
 				Status(tt.want.code)
 		})
 	}
+
+	// the secret is not deleted through another project
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+	httpexpect.Default(s.T(), server.URL).
+		GET(fmt.Sprintf("/v1/projects/%d/secrets/%d", s.mainProject.ID, s.existingSecrets[1].ID)).
+		Expect().
+		Status(http.StatusOK)
 }
 
 func (s *APITestSuite) TestListSecret() {
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+type ServiceAccountsController struct {
+	*AppContext
+}
+
+func (c *ServiceAccountsController) ListServiceAccounts(_ *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	serviceAccounts, err := c.ServiceAccountsService.ListServiceAccounts(project.ID)
+	if err != nil {
+		log.Errorf("error fetching service accounts of project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+	return Ok(serviceAccounts)
+}
+
+func (c *ServiceAccountsController) GetServiceAccount(_ *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	serviceAccount, response := c.findServiceAccount(vars)
+	if response != nil {
+		return response
+	}
+	return Ok(serviceAccount)
+}
+
+func (c *ServiceAccountsController) CreateServiceAccount(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return FromError(err)
+	}
+
+	serviceAccount, ok := body.(*models.ServiceAccount)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as service account")
+	}
+	serviceAccount.ID = 0
+
+	serviceAccount, err = c.ServiceAccountsService.CreateServiceAccount(r.Context(), project, serviceAccount)
+	if err != nil {
+		log.Errorf("error creating service account for project %s: %s", project.Name, err)
+		return FromError(err)
+	}
+	return Created(serviceAccount)
+}
+
+func (c *ServiceAccountsController) DeleteServiceAccount(r *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	serviceAccount, response := c.findServiceAccount(vars)
+	if response != nil {
+		return response
+	}
+
+	if err := c.ServiceAccountsService.DeleteServiceAccount(r.Context(), serviceAccount); err != nil {
+		log.Errorf("error deleting service account %s: %s", serviceAccount.Name, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+func (c *ServiceAccountsController) ListServiceAccountTokens(_ *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	serviceAccount, response := c.findServiceAccount(vars)
+	if response != nil {
+		return response
+	}
+
+	tokens, err := c.ServiceAccountsService.ListTokens(serviceAccount)
+	if err != nil {
+		log.Errorf("error fetching tokens of service account %s: %s", serviceAccount.Name, err)
+		return FromError(err)
+	}
+	return Ok(tokens)
+}
+
+func (c *ServiceAccountsController) CreateServiceAccountToken(r *http.Request, vars map[string]string,
+	body interface{}) *Response {
+	serviceAccount, response := c.findServiceAccount(vars)
+	if response != nil {
+		return response
+	}
+
+	token, ok := body.(*models.ServiceAccountToken)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as service account token")
+	}
+	token.ID = 0
+
+	token, err := c.ServiceAccountsService.CreateToken(r.Context(), serviceAccount, token)
+	if err != nil {
+		log.Errorf("error creating token for service account %s: %s", serviceAccount.Name, err)
+		return FromError(err)
+	}
+	return Created(token)
+}
+
+func (c *ServiceAccountsController) RevokeServiceAccountToken(r *http.Request, vars map[string]string,
+	_ interface{}) *Response {
+	serviceAccount, response := c.findServiceAccount(vars)
+	if response != nil {
+		return response
+	}
+	tokenID, _ := models.ParseID(vars["token_id"])
+	token, err := c.ServiceAccountsService.FindTokenByID(serviceAccount, tokenID)
+	if err != nil {
+		log.Errorf("error fetching token with ID %d: %s", tokenID, err)
+		return FromError(err)
+	}
+
+	token, err = c.ServiceAccountsService.RevokeToken(r.Context(), token)
+	if err != nil {
+		log.Errorf("error revoking token with ID %d: %s", tokenID, err)
+		return FromError(err)
+	}
+	return Ok(token)
+}
+
+// findServiceAccount fetches the project and the service account of the request, or returns the error response
+func (c *ServiceAccountsController) findServiceAccount(vars map[string]string) (*models.ServiceAccount, *Response) {
+	projectID, _ := models.ParseID(vars["project_id"])
+	project, err := c.ProjectsService.FindByID(projectID)
+	if err != nil {
+		log.Errorf("error fetching project with id %s: %s", projectID, err)
+		return nil, FromError(err)
+	}
+	serviceAccountID, _ := models.ParseID(vars["service_account_id"])
+	serviceAccount, err := c.ServiceAccountsService.FindServiceAccountByID(project.ID, serviceAccountID)
+	if err != nil {
+		log.Errorf("error fetching service account with ID %d: %s", serviceAccountID, err)
+		return nil, FromError(err)
+	}
+	return serviceAccount, nil
+}
+
+func (c *ServiceAccountsController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/service_accounts",
+			nil,
+			c.ListServiceAccounts,
+			"ListServiceAccounts",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/service_accounts",
+			models.ServiceAccount{},
+			c.CreateServiceAccount,
+			"CreateServiceAccount",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/service_accounts/{service_account_id:[0-9]+}",
+			nil,
+			c.GetServiceAccount,
+			"GetServiceAccount",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodDelete,
+			"/projects/{project_id:[0-9]+}/service_accounts/{service_account_id:[0-9]+}",
+			nil,
+			c.DeleteServiceAccount,
+			"DeleteServiceAccount",
+			middleware.Requires("projects.{project_id}", "delete"),
+		},
+		{
+			http.MethodGet,
+			"/projects/{project_id:[0-9]+}/service_accounts/{service_account_id:[0-9]+}/tokens",
+			nil,
+			c.ListServiceAccountTokens,
+			"ListServiceAccountTokens",
+			middleware.Requires("projects.{project_id}", "get"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/service_accounts/{service_account_id:[0-9]+}/tokens",
+			models.ServiceAccountToken{},
+			c.CreateServiceAccountToken,
+			"CreateServiceAccountToken",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+		{
+			http.MethodPost,
+			"/projects/{project_id:[0-9]+}/service_accounts/{service_account_id:[0-9]+}/tokens/{token_id:[0-9]+}/revoke",
+			nil,
+			c.RevokeServiceAccountToken,
+			"RevokeServiceAccountToken",
+			middleware.Requires("projects.{project_id}", "post"),
+		},
+	}
+}
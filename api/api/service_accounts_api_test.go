@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestServiceAccounts() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	projectPath := fmt.Sprintf("/v1/projects/%d", int(e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "robot-project", Team: "dsp", Stream: "dsp",
+			Administrators: []string{"admin@example.com"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("id").Number().Raw()))
+	serviceAccountsPath := projectPath + "/service_accounts"
+
+	e.POST(serviceAccountsPath).
+		WithJSON(models.ServiceAccount{Name: "CI"}).
+		Expect().
+		Status(http.StatusBadRequest)
+	serviceAccount := e.POST(serviceAccountsPath).
+		WithJSON(models.ServiceAccount{Name: "ci-pipeline", Description: "deploys the models"}).
+		WithHeader("User-Email", "admin@example.com").
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	serviceAccount.Value("created_by").IsEqual("admin@example.com")
+	subject := serviceAccount.Value("subject").String().HasPrefix(models.ServiceAccountSubjectPrefix).Raw()
+	serviceAccountPath := fmt.Sprintf("%s/%d", serviceAccountsPath, int(serviceAccount.Value("id").Number().Raw()))
+	e.POST(serviceAccountsPath).
+		WithJSON(models.ServiceAccount{Name: "ci-pipeline"}).
+		Expect().
+		Status(http.StatusConflict)
+
+	token := e.POST(serviceAccountPath + "/tokens").
+		WithJSON(models.ServiceAccountToken{Name: "github-actions"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	bearerToken := token.Value("token").String().HasPrefix(models.ServiceAccountTokenPrefix).Raw()
+	token.Value("expires_at").NotNull()
+	tokenID := int(token.Value("id").Number().Raw())
+	e.GET(serviceAccountPath + "/tokens").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Value(0).Object().NotContainsKey("token")
+
+	// the service account is granted a role like any other member
+	e.PUT(projectPath).
+		WithJSON(models.Project{Team: "dsp", Stream: "dsp",
+			Administrators: []string{"admin@example.com", models.ServiceAccountSubjectPrefix + "999999"}}).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.PUT(projectPath).
+		WithJSON(models.Project{Team: "dsp", Stream: "dsp", Administrators: []string{"admin@example.com"},
+			Readers: []string{subject}}).
+		Expect().
+		Status(http.StatusOK)
+	e.DELETE(serviceAccountPath).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.PUT(projectPath).
+		WithJSON(models.Project{Team: "dsp", Stream: "dsp", Administrators: []string{"admin@example.com"},
+			SecretReaders: []string{subject}}).
+		Expect().
+		Status(http.StatusOK)
+	e.DELETE(serviceAccountPath).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// the service account cannot be granted a role in another project
+	e.PUT(fmt.Sprintf("/v1/projects/%d", s.otherProject.ID)).
+		WithJSON(models.Project{Team: "dsp", Stream: "dsp", Readers: []string{subject}}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// the token authenticates the service account, whose subject cannot be claimed with the User-Email header
+	e.GET(serviceAccountPath).
+		WithHeader("Authorization", "Bearer "+bearerToken).
+		Expect().
+		Status(http.StatusOK)
+	e.GET(serviceAccountPath).
+		WithHeader("User-Email", subject).
+		Expect().
+		Status(http.StatusUnauthorized)
+	e.GET(serviceAccountPath).
+		WithHeader("Authorization", "Bearer "+models.ServiceAccountTokenPrefix+"unknown").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	e.POST(fmt.Sprintf("%s/tokens/%d/revoke", serviceAccountPath, tokenID)).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("revoked_at").NotNull()
+	e.GET(serviceAccountPath).
+		WithHeader("Authorization", "Bearer "+bearerToken).
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	e.PUT(projectPath).
+		WithJSON(models.Project{Team: "dsp", Stream: "dsp", Administrators: []string{"admin@example.com"}}).
+		Expect().
+		Status(http.StatusOK)
+	e.DELETE(serviceAccountPath).
+		Expect().
+		Status(http.StatusNoContent)
+}
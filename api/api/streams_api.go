@@ -0,0 +1,259 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/middleware"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+type StreamsController struct {
+	*AppContext
+}
+
+func (c *StreamsController) ListStreams(_ *http.Request, _ map[string]string, _ interface{}) *Response {
+	streams, err := c.StreamsService.ListStreams()
+	if err != nil {
+		log.Errorf("error fetching streams: %s", err)
+		return FromError(err)
+	}
+	return Ok(streams)
+}
+
+func (c *StreamsController) GetStream(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	streamID, _ := models.ParseID(vars["stream_id"])
+	stream, err := c.StreamsService.FindStreamByID(streamID)
+	if err != nil {
+		log.Errorf("error fetching stream with ID %d: %s", streamID, err)
+		return FromError(err)
+	}
+	return Ok(stream)
+}
+
+func (c *StreamsController) CreateStream(r *http.Request, _ map[string]string, body interface{}) *Response {
+	stream, ok := body.(*models.Stream)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as stream")
+	}
+	stream.ID = 0
+
+	stream, err := c.StreamsService.CreateStream(r.Context(), stream)
+	if err != nil {
+		log.Errorf("error creating stream: %s", err)
+		return FromError(err)
+	}
+	return Created(stream)
+}
+
+func (c *StreamsController) UpdateStream(r *http.Request, vars map[string]string, body interface{}) *Response {
+	streamID, _ := models.ParseID(vars["stream_id"])
+	stream, err := c.StreamsService.FindStreamByID(streamID)
+	if err != nil {
+		log.Errorf("error fetching stream with ID %d: %s", streamID, err)
+		return FromError(err)
+	}
+
+	updateRequest, ok := body.(*models.Stream)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as stream")
+	}
+	stream.Name = updateRequest.Name
+	stream.Description = updateRequest.Description
+	stream.Owners = updateRequest.Owners
+
+	stream, err = c.StreamsService.UpdateStream(r.Context(), stream)
+	if err != nil {
+		log.Errorf("error updating stream with ID %d: %s", streamID, err)
+		return FromError(err)
+	}
+	return Ok(stream)
+}
+
+func (c *StreamsController) DeleteStream(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	streamID, _ := models.ParseID(vars["stream_id"])
+	stream, err := c.StreamsService.FindStreamByID(streamID)
+	if err != nil {
+		log.Errorf("error fetching stream with ID %d: %s", streamID, err)
+		return FromError(err)
+	}
+
+	if err := c.StreamsService.DeleteStream(r.Context(), stream); err != nil {
+		log.Errorf("error deleting stream with ID %d: %s", streamID, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+// ListTeams lists all teams, or the teams of a stream if stream_id is specified
+func (c *StreamsController) ListTeams(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	var streamID *models.ID
+	if value, ok := vars["stream_id"]; ok {
+		id, err := models.ParseID(value)
+		if err != nil || id <= 0 {
+			return BadRequest("stream_id is not valid")
+		}
+		streamID = &id
+	}
+
+	teams, err := c.StreamsService.ListTeams(streamID)
+	if err != nil {
+		log.Errorf("error fetching teams: %s", err)
+		return FromError(err)
+	}
+	return Ok(teams)
+}
+
+func (c *StreamsController) GetTeam(_ *http.Request, vars map[string]string, _ interface{}) *Response {
+	teamID, _ := models.ParseID(vars["team_id"])
+	team, err := c.StreamsService.FindTeamByID(teamID)
+	if err != nil {
+		log.Errorf("error fetching team with ID %d: %s", teamID, err)
+		return FromError(err)
+	}
+	return Ok(team)
+}
+
+func (c *StreamsController) CreateTeam(r *http.Request, _ map[string]string, body interface{}) *Response {
+	team, ok := body.(*models.Team)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as team")
+	}
+	team.ID = 0
+
+	team, err := c.StreamsService.CreateTeam(r.Context(), team)
+	if err != nil {
+		log.Errorf("error creating team: %s", err)
+		return FromError(err)
+	}
+	return Created(team)
+}
+
+func (c *StreamsController) UpdateTeam(r *http.Request, vars map[string]string, body interface{}) *Response {
+	teamID, _ := models.ParseID(vars["team_id"])
+	team, err := c.StreamsService.FindTeamByID(teamID)
+	if err != nil {
+		log.Errorf("error fetching team with ID %d: %s", teamID, err)
+		return FromError(err)
+	}
+
+	updateRequest, ok := body.(*models.Team)
+	if !ok {
+		log.Errorf("invalid request body %v", body)
+		return BadRequest("Unable to parse request body as team")
+	}
+	team.StreamID = updateRequest.StreamID
+	team.Name = updateRequest.Name
+	team.Description = updateRequest.Description
+	team.Owners = updateRequest.Owners
+
+	team, err = c.StreamsService.UpdateTeam(r.Context(), team)
+	if err != nil {
+		log.Errorf("error updating team with ID %d: %s", teamID, err)
+		return FromError(err)
+	}
+	return Ok(team)
+}
+
+func (c *StreamsController) DeleteTeam(r *http.Request, vars map[string]string, _ interface{}) *Response {
+	teamID, _ := models.ParseID(vars["team_id"])
+	team, err := c.StreamsService.FindTeamByID(teamID)
+	if err != nil {
+		log.Errorf("error fetching team with ID %d: %s", teamID, err)
+		return FromError(err)
+	}
+
+	if err := c.StreamsService.DeleteTeam(r.Context(), team); err != nil {
+		log.Errorf("error deleting team with ID %d: %s", teamID, err)
+		return FromError(err)
+	}
+	return NoContent()
+}
+
+func (c *StreamsController) Routes() []Route {
+	return []Route{
+		{
+			http.MethodGet,
+			"/streams",
+			nil,
+			c.ListStreams,
+			"ListStreams",
+			middleware.Public(),
+		},
+		{
+			http.MethodGet,
+			"/streams/{stream_id:[0-9]+}",
+			nil,
+			c.GetStream,
+			"GetStream",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/streams",
+			models.Stream{},
+			c.CreateStream,
+			"CreateStream",
+			middleware.Requires("streams", "post"),
+		},
+		{
+			http.MethodPut,
+			"/streams/{stream_id:[0-9]+}",
+			models.Stream{},
+			c.UpdateStream,
+			"UpdateStream",
+			middleware.Requires("streams.{stream_id}", "put"),
+		},
+		{
+			http.MethodDelete,
+			"/streams/{stream_id:[0-9]+}",
+			nil,
+			c.DeleteStream,
+			"DeleteStream",
+			middleware.Requires("streams.{stream_id}", "delete"),
+		},
+		{
+			http.MethodGet,
+			"/teams",
+			nil,
+			c.ListTeams,
+			"ListTeams",
+			middleware.Public(),
+		},
+		{
+			http.MethodGet,
+			"/teams/{team_id:[0-9]+}",
+			nil,
+			c.GetTeam,
+			"GetTeam",
+			middleware.Public(),
+		},
+		{
+			http.MethodPost,
+			"/teams",
+			models.Team{},
+			c.CreateTeam,
+			"CreateTeam",
+			middleware.Requires("teams", "post"),
+		},
+		{
+			http.MethodPut,
+			"/teams/{team_id:[0-9]+}",
+			models.Team{},
+			c.UpdateTeam,
+			"UpdateTeam",
+			middleware.Requires("teams.{team_id}", "put"),
+		},
+		{
+			http.MethodDelete,
+			"/teams/{team_id:[0-9]+}",
+			nil,
+			c.DeleteTeam,
+			"DeleteTeam",
+			middleware.Requires("teams.{team_id}", "delete"),
+		},
+	}
+}
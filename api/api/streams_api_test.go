@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func (s *APITestSuite) TestStreamsAndTeams() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	stream := e.POST("/v1/streams").
+		WithJSON(models.Stream{Name: "credit", Owners: []string{"owner@example.com"}}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	stream.Value("name").IsEqual("credit")
+	streamID := int(stream.Value("id").Number().Raw())
+
+	e.POST("/v1/streams").
+		WithJSON(models.Stream{Name: "credit"}).
+		Expect().
+		Status(http.StatusConflict)
+
+	team := e.POST("/v1/teams").
+		WithJSON(models.Team{StreamID: models.ID(streamID), Name: "risk"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	teamID := int(team.Value("id").Number().Raw())
+
+	e.POST("/v1/teams").
+		WithJSON(models.Team{StreamID: models.ID(streamID + 100), Name: "risk"}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	e.GET("/v1/teams").
+		WithQuery("stream_id", streamID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(1)
+
+	e.PUT(fmt.Sprintf("/v1/teams/%d", teamID)).
+		WithJSON(models.Team{StreamID: models.ID(streamID), Name: "risk", Description: "Credit risk"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("description").IsEqual("Credit risk")
+
+	// a stream cannot be deleted while it has teams
+	e.DELETE(fmt.Sprintf("/v1/streams/%d", streamID)).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	e.DELETE(fmt.Sprintf("/v1/teams/%d", teamID)).
+		Expect().
+		Status(http.StatusNoContent)
+	e.GET(fmt.Sprintf("/v1/teams/%d", teamID)).
+		Expect().
+		Status(http.StatusNotFound)
+
+	e.DELETE(fmt.Sprintf("/v1/streams/%d", streamID)).
+		Expect().
+		Status(http.StatusNoContent)
+	e.GET("/v1/streams").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Array().Length().IsEqual(0)
+}
+
+func (s *APITestSuite) TestProjectsValidatedAgainstCatalogue() {
+	server := httptest.NewServer(s.route)
+	defer server.Close()
+
+	e := httpexpect.Default(s.T(), server.URL)
+
+	// any stream and team can be used until the catalogue contains a stream
+	e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "uncatalogued-project", Team: "dsp", Stream: "dsp"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	streamID := int(e.POST("/v1/streams").
+		WithJSON(models.Stream{Name: "credit"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("id").Number().Raw())
+	e.POST("/v1/teams").
+		WithJSON(models.Team{StreamID: models.ID(streamID), Name: "risk"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "typo-project", Team: "risk", Stream: "crdit"}).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.POST("/v1/projects").
+		WithJSON(models.Project{Name: "catalogued-project", Team: "risk", Stream: "credit"}).
+		Expect().
+		Status(http.StatusCreated)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/middleware"
 	"github.com/caraml-dev/mlp/api/models/v2"
 )
 
@@ -18,10 +19,11 @@ func (c *ApplicationsController) ListApplications(_ *http.Request, _ map[string]
 func (c *ApplicationsController) Routes() []api.Route {
 	return []api.Route{
 		{
-			Method:  http.MethodGet,
-			Path:    "/applications",
-			Handler: c.ListApplications,
-			Name:    "ListApplications",
+			Method:     http.MethodGet,
+			Path:       "/applications",
+			Handler:    c.ListApplications,
+			Name:       "ListApplications",
+			Permission: middleware.Public(),
 		},
 	}
 }
@@ -24,4 +24,5 @@ type Project struct {
 	Labels            []Label   `json:"labels,omitempty"`
 	CreatedAt         time.Time `json:"created_at,omitempty"`
 	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+	ArchivedAt        time.Time `json:"archived_at,omitempty"`
 }
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+var (
+	authzReconcileOptions service.AuthorizationReconcileOptions
+	authzCmd              = &cobra.Command{
+		Use:   "authz",
+		Short: "Manage the authorization relations",
+	}
+	authzReconcileCmd = &cobra.Command{
+		Use:   "reconcile",
+		Short: "Reconcile the authorization relations with the resources",
+		Long: "Recompute the authorization relations expected from the projects, their custom role assignments, the " +
+			"groups, the streams and the teams, and apply the differences with the relations stored by the " +
+			"authorization backend. It complements the bootstrap command, which sets the MLP administrators and the " +
+			"readers of all projects, by repairing the relations of the existing resources, e.g. those created " +
+			"before new permissions were introduced or whose update failed.",
+		Run: func(cmd *cobra.Command, _ []string) {
+			var changes []*service.AuthorizationChange
+			err := withAppContext(func(appCtx *api.AppContext) error {
+				if appCtx.AuthorizationReconciler == nil {
+					return errors.New("authorization is not enabled")
+				}
+				var err error
+				changes, err = appCtx.AuthorizationReconciler.Reconcile(context.Background(), authzReconcileOptions)
+				return err
+			})
+			// the changes are printed even on failure, to show what has been applied before the failure
+			printAuthorizationChanges(cmd.OutOrStdout(), changes, authzReconcileOptions.DryRun)
+			if err != nil {
+				log.Fatalf("unable to reconcile authorization: %v", err)
+			}
+		},
+	}
+)
+
+func init() {
+	authzReconcileCmd.Flags().StringSliceVarP(&configFiles, "config", "c", []string{},
+		"Comma separated list of config files to load. The last config file will take precedence over the "+
+			"previous ones.")
+	authzReconcileCmd.Flags().BoolVar(&authzReconcileOptions.DryRun, "dry-run", false,
+		"Print the changes that would be made without applying them")
+	authzReconcileCmd.Flags().BoolVar(&authzReconcileOptions.RevokeOnly, "revoke-only", false,
+		"Only remove the relations which are not expected, without adding the missing ones")
+	authzCmd.AddCommand(authzReconcileCmd)
+}
+
+func printAuthorizationChanges(w io.Writer, changes []*service.AuthorizationChange, dryRun bool) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "authorization is up to date")
+		return
+	}
+
+	for _, change := range changes {
+		if change.Group != "" {
+			fmt.Fprintf(w, "group %s\n", change.Group)
+		} else {
+			fmt.Fprintf(w, "role %s\n", change.Role)
+		}
+		printAuthorizationValues(w, "+ permission", change.AddedPermissions)
+		printAuthorizationValues(w, "- permission", change.RemovedPermissions)
+		printAuthorizationValues(w, "+ member", change.AddedMembers)
+		printAuthorizationValues(w, "- member", change.RemovedMembers)
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "%d changes planned, none applied (dry run)\n", len(changes))
+	} else {
+		fmt.Fprintf(w, "%d changes applied\n", len(changes))
+	}
+}
+
+func printAuthorizationValues(w io.Writer, prefix string, values []string) {
+	if len(values) > 0 {
+		fmt.Fprintf(w, "  %s: %s\n", prefix, strings.Join(values, ", "))
+	}
+}
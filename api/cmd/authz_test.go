@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+func TestPrintAuthorizationChanges(t *testing.T) {
+	changes := []*service.AuthorizationChange{
+		{
+			Role:               "mlp.projects.1.administrator",
+			AddedPermissions:   []string{"mlp.projects.1.secrets.get", "mlp.projects.1.secrets.post"},
+			RemovedPermissions: []string{"mlp.projects.2.get"},
+			RemovedMembers:     []string{"former-admin@example.com"},
+		},
+		{Group: "data-science", AddedMembers: []string{"alice@example.com"}},
+	}
+
+	var out bytes.Buffer
+	printAuthorizationChanges(&out, changes, true)
+	assert.Equal(t, `role mlp.projects.1.administrator
+  + permission: mlp.projects.1.secrets.get, mlp.projects.1.secrets.post
+  - permission: mlp.projects.2.get
+  - member: former-admin@example.com
+group data-science
+  + member: alice@example.com
+2 changes planned, none applied (dry run)
+`, out.String())
+
+	out.Reset()
+	printAuthorizationChanges(&out, nil, false)
+	assert.Equal(t, "authorization is up to date\n", out.String())
+}
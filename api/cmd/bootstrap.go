@@ -9,15 +9,20 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/database"
 	"github.com/caraml-dev/mlp/api/log"
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	"github.com/caraml-dev/mlp/api/service"
 )
 
 type BootstrapConfig struct {
 	KetoRemoteRead  string
 	KetoRemoteWrite string
-	ProjectReaders  []string
-	MLPAdmins       []string
+	// Database, if set, is the database of the database authorization backend, which is populated instead of Keto
+	Database       *config.DatabaseConfig
+	ProjectReaders []string
+	MLPAdmins      []string
 }
 
 var (
@@ -30,9 +35,17 @@ var (
 			if err != nil {
 				log.Panicf("unable to load role members from input file: %v", err)
 			}
-			authEnforcer, err := enforcer.NewEnforcerBuilder().
-				KetoEndpoints(bootstrapConfig.KetoRemoteRead, bootstrapConfig.KetoRemoteWrite).
-				Build()
+			enforcerBuilder := enforcer.NewEnforcerBuilder().
+				KetoEndpoints(bootstrapConfig.KetoRemoteRead, bootstrapConfig.KetoRemoteWrite)
+			if bootstrapConfig.Database != nil {
+				db, err := database.InitDB(bootstrapConfig.Database)
+				if err != nil {
+					log.Panicf("unable to connect to the database: %v", err)
+				}
+				defer db.Close()
+				enforcerBuilder.Database(db.DB())
+			}
+			authEnforcer, err := enforcerBuilder.Build()
 			if err != nil {
 				log.Panicf("unable to create keto enforcer: %v", err)
 			}
@@ -72,10 +85,9 @@ func loadBootstrapConfig(path string) (*BootstrapConfig, error) {
 }
 
 func startKetoBootstrap(authEnforcer enforcer.Enforcer, projectReaders []string, mlpAdmins []string) error {
-	defaultMLPAdminPermissions := []string{"mlp.projects.post"}
 	updateRequest := enforcer.NewAuthorizationUpdateRequest()
 	updateRequest.SetRoleMembers(enforcer.MLPProjectsReaderRole, projectReaders)
 	updateRequest.SetRoleMembers(enforcer.MLPAdminRole, mlpAdmins)
-	updateRequest.AddRolePermissions(enforcer.MLPAdminRole, defaultMLPAdminPermissions)
+	updateRequest.AddRolePermissions(enforcer.MLPAdminRole, service.MLPAdminPermissions())
 	return authEnforcer.UpdateAuthorization(context.Background(), updateRequest)
 }
@@ -23,12 +23,18 @@ func TestStartKetoBootsrap(t *testing.T) {
 			[]string{"admin1"},
 			enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
-					"mlp.administrator": {"mlp.projects.post"},
+					"mlp.administrator": {"mlp.projects.post", "mlp.streams.post", "mlp.teams.post",
+						"mlp.groups.post", "mlp.roles.post", "mlp.roles.put", "mlp.roles.delete", "mlp.authz.check",
+						"mlp.authz.decisions.get"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.reader": {},
 					"mlp.administrator":   {"admin1"},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 		},
 		{
@@ -37,12 +43,18 @@ func TestStartKetoBootsrap(t *testing.T) {
 			[]string{},
 			enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
-					"mlp.administrator": {"mlp.projects.post"},
+					"mlp.administrator": {"mlp.projects.post", "mlp.streams.post", "mlp.teams.post",
+						"mlp.groups.post", "mlp.roles.post", "mlp.roles.put", "mlp.roles.delete", "mlp.authz.check",
+						"mlp.authz.decisions.get"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.reader": {},
 					"mlp.administrator":   {},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 		},
 		{
@@ -51,12 +63,18 @@ func TestStartKetoBootsrap(t *testing.T) {
 			[]string{},
 			enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
-					"mlp.administrator": {"mlp.projects.post"},
+					"mlp.administrator": {"mlp.projects.post", "mlp.streams.post", "mlp.teams.post",
+						"mlp.groups.post", "mlp.roles.post", "mlp.roles.put", "mlp.roles.delete", "mlp.authz.check",
+						"mlp.authz.decisions.get"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.reader": {"readers1", "readers2"},
 					"mlp.administrator":   {},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 		},
 		{
@@ -65,12 +83,18 @@ func TestStartKetoBootsrap(t *testing.T) {
 			[]string{"admin1"},
 			enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
-					"mlp.administrator": {"mlp.projects.post"},
+					"mlp.administrator": {"mlp.projects.post", "mlp.streams.post", "mlp.teams.post",
+						"mlp.groups.post", "mlp.roles.post", "mlp.roles.put", "mlp.roles.delete", "mlp.authz.check",
+						"mlp.authz.decisions.get"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.reader": {"readers1", "readers2"},
 					"mlp.administrator":   {"admin1"},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 		},
 	}
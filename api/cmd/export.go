@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+var (
+	exportOutputFile        string
+	exportFormat            string
+	exportProjects          []string
+	exportIncludeSecrets    bool
+	exportEncryptionKeyFile string
+	exportCmd               = &cobra.Command{
+		Use:   "export",
+		Short: "Export projects to a project bundle",
+		Long: "Export projects together with their labels, memberships, project-scoped secret storages and, " +
+			"optionally, their secrets to a versioned bundle that can be applied to another environment with the " +
+			"import command. The credentials of the secret storages, such as Vault tokens, are not exported.",
+		Run: func(_ *cobra.Command, _ []string) {
+			encryptor, err := loadEncryptor(exportEncryptionKeyFile)
+			if err != nil {
+				log.Fatalf("unable to load encryption key: %v", err)
+			}
+
+			err = withAppContext(func(appCtx *api.AppContext) error {
+				return exportProjectBundle(appCtx.ProjectBundleService, service.ExportOptions{
+					ProjectNames:   exportProjects,
+					IncludeSecrets: exportIncludeSecrets,
+					Encryptor:      encryptor,
+				})
+			})
+			if err != nil {
+				log.Fatalf("unable to export projects: %v", err)
+			}
+		},
+	}
+)
+
+func init() {
+	exportCmd.Flags().StringSliceVarP(&configFiles, "config", "c", []string{},
+		"Comma separated list of config files to load. The last config file will take precedence over the "+
+			"previous ones.")
+	exportCmd.Flags().StringVarP(&exportOutputFile, "output", "o", "-",
+		"Path of the bundle file to write, or - to write to the standard output")
+	exportCmd.Flags().StringVar(&exportFormat, "format", yamlBundleFormat, "Format of the bundle, yaml or json")
+	exportCmd.Flags().StringSliceVarP(&exportProjects, "project", "p", []string{},
+		"Comma separated list of the names of the projects to export. All projects are exported if not set.")
+	exportCmd.Flags().BoolVar(&exportIncludeSecrets, "include-secrets", false,
+		"Export the secrets of the projects. Secrets are written in plain text unless an encryption key is given.")
+	exportCmd.Flags().StringVar(&exportEncryptionKeyFile, "encryption-key-file", "",
+		"Path to a file containing a base64 encoded 32 bytes key used to encrypt the exported secrets")
+}
+
+func exportProjectBundle(bundleService service.ProjectBundleService, options service.ExportOptions) error {
+	bundle, err := bundleService.Export(context.Background(), options)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeProjectBundle(bundle, exportFormat)
+	if err != nil {
+		return err
+	}
+
+	if exportOutputFile == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(exportOutputFile, data, 0600); err != nil {
+		return err
+	}
+	log.Infof("exported %d projects to %s", len(bundle.Projects), exportOutputFile)
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+// importActor is recorded as the author of the changes made by the import command in the project history
+const importActor = "mlp-import"
+
+var (
+	importInputFile         string
+	importEncryptionKeyFile string
+	importCmd               = &cobra.Command{
+		Use:   "import",
+		Short: "Import projects from a project bundle",
+		Long: "Create or update the projects of a bundle written by the export command, together with their " +
+			"secret storages and secrets. Importing the same bundle more than once has no further effect.",
+		Run: func(_ *cobra.Command, _ []string) {
+			encryptor, err := loadEncryptor(importEncryptionKeyFile)
+			if err != nil {
+				log.Fatalf("unable to load encryption key: %v", err)
+			}
+
+			data, err := os.ReadFile(importInputFile)
+			if err != nil {
+				log.Fatalf("unable to read project bundle: %v", err)
+			}
+			bundle, err := decodeProjectBundle(data)
+			if err != nil {
+				log.Fatalf("unable to read project bundle: %v", err)
+			}
+
+			err = withAppContext(func(appCtx *api.AppContext) error {
+				ctx := requestctx.WithActor(context.Background(), importActor)
+				return appCtx.ProjectBundleService.Import(ctx, bundle, service.ImportOptions{Encryptor: encryptor})
+			})
+			if err != nil {
+				log.Fatalf("unable to import projects: %v", err)
+			}
+			log.Infof("imported %d projects from %s", len(bundle.Projects), importInputFile)
+		},
+	}
+)
+
+func init() {
+	importCmd.Flags().StringSliceVarP(&configFiles, "config", "c", []string{},
+		"Comma separated list of config files to load. The last config file will take precedence over the "+
+			"previous ones.")
+	importCmd.Flags().StringVarP(&importInputFile, "file", "f", "", "Path of the bundle file to import")
+	importCmd.Flags().StringVar(&importEncryptionKeyFile, "encryption-key-file", "",
+		"Path to a file containing the base64 encoded key the secrets of the bundle were encrypted with")
+	err := importCmd.MarkFlagRequired("file")
+	if err != nil {
+		log.Panicf("unable to mark flag as required: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/database"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/encryption"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+const (
+	yamlBundleFormat = "yaml"
+	jsonBundleFormat = "json"
+)
+
+// withAppContext loads the configuration, connects to the database and runs fn with the resulting application
+// context, for commands that operate on the MLP database without starting the server
+func withAppContext(fn func(appCtx *api.AppContext) error) error {
+	cfg, err := config.LoadAndValidate(configFiles...)
+	if err != nil {
+		return fmt.Errorf("failed initializing config: %w", err)
+	}
+
+	db, err := database.InitDB(cfg.Database)
+	if err != nil {
+		return fmt.Errorf("unable to initialize DB connectivity: %w", err)
+	}
+	defer db.Close()
+
+	appCtx, err := api.NewAppContext(db, cfg)
+	if err != nil {
+		return fmt.Errorf("unable to initialize application context: %w", err)
+	}
+	return fn(appCtx)
+}
+
+// loadEncryptor creates the encryptor of bundle secrets from a file containing a base64 encoded key. No encryptor is
+// returned if no file is given.
+func loadEncryptor(keyFile string) (service.SecretEncryptor, error) {
+	if keyFile == "" {
+		return nil, nil
+	}
+
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read encryption key: %w", err)
+	}
+	encryptor, err := encryption.NewAESEncryptor(string(key))
+	if err != nil {
+		return nil, err
+	}
+	return encryptor, nil
+}
+
+func encodeProjectBundle(bundle *models.ProjectBundle, format string) ([]byte, error) {
+	switch format {
+	case yamlBundleFormat:
+		return yaml.Marshal(bundle)
+	case jsonBundleFormat:
+		return json.MarshalIndent(bundle, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported bundle format %s, must be either %s or %s", format, yamlBundleFormat,
+			jsonBundleFormat)
+	}
+}
+
+// decodeProjectBundle parses a bundle in either format, since JSON is a subset of YAML
+func decodeProjectBundle(data []byte) (*models.ProjectBundle, error) {
+	bundle := &models.ProjectBundle{}
+	if err := yaml.UnmarshalStrict(data, bundle); err != nil {
+		return nil, fmt.Errorf("invalid project bundle: %w", err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func TestProjectBundleEncoding(t *testing.T) {
+	bundle := &models.ProjectBundle{
+		Version:    models.ProjectBundleVersion,
+		ExportedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Projects: []*models.BundledProject{
+			{
+				Name:           "project-a",
+				Administrators: []string{"admin@example.com"},
+				SecretReaders:  []string{"secret-reader@example.com"},
+				Team:           "team-a",
+				Stream:         "stream-a",
+				Labels:         models.Labels{{Key: "env", Value: "production"}},
+				SecretStorages: []*models.BundledSecretStorage{
+					{
+						Name: "project-secret-storage",
+						Type: models.VaultSecretStorageType,
+						Config: models.SecretStorageConfig{
+							VaultConfig: &models.VaultConfig{URL: "http://vault:8200", MountPath: "secret"},
+						},
+					},
+				},
+				Secrets: []*models.BundledSecret{
+					{Name: "secret-1", SecretStorage: "project-secret-storage", Data: "data-1"},
+				},
+			},
+		},
+	}
+
+	for _, format := range []string{yamlBundleFormat, jsonBundleFormat} {
+		t.Run(format, func(t *testing.T) {
+			data, err := encodeProjectBundle(bundle, format)
+			require.NoError(t, err)
+
+			decoded, err := decodeProjectBundle(data)
+			require.NoError(t, err)
+			assert.Equal(t, bundle, decoded)
+		})
+	}
+
+	_, err := encodeProjectBundle(bundle, "xml")
+	assert.EqualError(t, err, "unsupported bundle format xml, must be either yaml or json")
+
+	_, err = decodeProjectBundle([]byte("version: v1\nunknown: field\n"))
+	assert.ErrorContains(t, err, `json: unknown field "unknown"`)
+}
+
+func TestLoadEncryptor(t *testing.T) {
+	encryptor, err := loadEncryptor("")
+	assert.NoError(t, err)
+	assert.Nil(t, encryptor)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	require.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0600))
+
+	encryptor, err = loadEncryptor(keyFile)
+	require.NoError(t, err)
+	encrypted, err := encryptor.Encrypt("data")
+	require.NoError(t, err)
+	decrypted, err := encryptor.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "data", decrypted)
+
+	_, err = loadEncryptor(filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "unable to read encryption key")
+}
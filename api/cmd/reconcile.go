@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+// reconcileActor is recorded as the author of the changes made by the reconcile command in the project history
+const reconcileActor = "mlp-reconcile"
+
+var (
+	reconcileManifestsDir string
+	reconcileOptions      service.ReconcileOptions
+	reconcileCmd          = &cobra.Command{
+		Use:   "reconcile",
+		Short: "Reconcile projects with their manifests",
+		Long: "Create and update the projects so that their team, stream, administrators, readers and labels match " +
+			"the project manifests of a directory, typically a checkout of a git repository. Each YAML or JSON file " +
+			"of the directory defines a single project.",
+		Run: func(cmd *cobra.Command, _ []string) {
+			manifests, err := loadProjectManifests(reconcileManifestsDir)
+			if err != nil {
+				log.Fatalf("unable to load project manifests: %v", err)
+			}
+
+			var actions []*service.ReconcileAction
+			err = withAppContext(func(appCtx *api.AppContext) error {
+				ctx := requestctx.WithActor(context.Background(), reconcileActor)
+				actions, err = appCtx.ProjectReconciler.Reconcile(ctx, manifests, reconcileOptions)
+				return err
+			})
+			// the actions are printed even on failure, to show what has been applied before the failure
+			printReconcileActions(cmd.OutOrStdout(), actions, reconcileOptions.DryRun)
+			if err != nil {
+				log.Fatalf("unable to reconcile projects: %v", err)
+			}
+		},
+	}
+)
+
+func init() {
+	reconcileCmd.Flags().StringSliceVarP(&configFiles, "config", "c", []string{},
+		"Comma separated list of config files to load. The last config file will take precedence over the "+
+			"previous ones.")
+	reconcileCmd.Flags().StringVarP(&reconcileManifestsDir, "manifests", "m", "",
+		"Path to the directory containing the project manifests")
+	reconcileCmd.Flags().BoolVar(&reconcileOptions.DryRun, "dry-run", false,
+		"Print the changes that would be made without applying them")
+	reconcileCmd.Flags().BoolVar(&reconcileOptions.Prune, "prune", false,
+		"Archive the projects that have no manifest")
+	err := reconcileCmd.MarkFlagRequired("manifests")
+	if err != nil {
+		log.Panicf("unable to mark flag as required: %v", err)
+	}
+}
+
+// loadProjectManifests reads the project manifests of all the YAML and JSON files of the directory and its
+// subdirectories
+func loadProjectManifests(dir string) ([]*models.ProjectManifest, error) {
+	manifests := make([]*models.ProjectManifest, 0)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		manifest := &models.ProjectManifest{}
+		if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+			return fmt.Errorf("invalid project manifest %s: %w", path, err)
+		}
+		manifests = append(manifests, manifest)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifests, nil
+}
+
+func printReconcileActions(w io.Writer, actions []*service.ReconcileAction, dryRun bool) {
+	if len(actions) == 0 {
+		fmt.Fprintln(w, "projects are up to date")
+		return
+	}
+
+	for _, action := range actions {
+		fmt.Fprintf(w, "%s project %s\n", action.Type, action.Project)
+
+		fields := make([]string, 0, len(action.Changes))
+		for field := range action.Changes {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			before, _ := json.Marshal(action.Changes[field].Before)
+			after, _ := json.Marshal(action.Changes[field].After)
+			fmt.Fprintf(w, "  %s: %s -> %s\n", field, before, after)
+		}
+	}
+
+	if dryRun {
+		fmt.Fprintf(w, "%d changes planned, none applied (dry run)\n", len(actions))
+	} else {
+		fmt.Fprintf(w, "%d changes applied\n", len(actions))
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+func TestLoadProjectManifests(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "team-b"), 0700))
+	files := map[string]string{
+		"project-a.yaml": `
+name: project-a
+team: team-a
+stream: stream-a
+administrators:
+  - admin@example.com
+labels:
+  - key: env
+    value: production
+`,
+		"team-b/project-b.json": `{"name": "project-b", "team": "team-b", "stream": "stream-b"}`,
+		"README.md":             "# Project manifests",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	manifests, err := loadProjectManifests(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.ProjectManifest{
+		{
+			Name:           "project-a",
+			Team:           "team-a",
+			Stream:         "stream-a",
+			Administrators: []string{"admin@example.com"},
+			Labels:         models.Labels{{Key: "env", Value: "production"}},
+		},
+		{Name: "project-b", Team: "team-b", Stream: "stream-b"},
+	}, manifests)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yml"), []byte("name: project-c\nowner: x\n"), 0600))
+	_, err = loadProjectManifests(dir)
+	assert.ErrorContains(t, err, "invalid project manifest "+filepath.Join(dir, "invalid.yml"))
+}
+
+func TestPrintReconcileActions(t *testing.T) {
+	actions := []*service.ReconcileAction{
+		{
+			Type:    service.ReconcileUpdateAction,
+			Project: "project-a",
+			Changes: models.ProjectChanges{
+				"team":    {Before: "team-a", After: "team-b"},
+				"readers": {Before: nil, After: []string{"reader@example.com"}},
+			},
+		},
+		{Type: service.ReconcileArchiveAction, Project: "project-b"},
+	}
+
+	var out bytes.Buffer
+	printReconcileActions(&out, actions, true)
+	assert.Equal(t, `update project project-a
+  readers: null -> ["reader@example.com"]
+  team: "team-a" -> "team-b"
+archive project project-b
+2 changes planned, none applied (dry run)
+`, out.String())
+
+	out.Reset()
+	printReconcileActions(&out, nil, false)
+	assert.Equal(t, "projects are up to date\n", out.String())
+}
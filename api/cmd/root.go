@@ -21,6 +21,11 @@ var (
 func init() {
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(bootstrapCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(reconcileCmd)
+	rootCmd.AddCommand(syncGroupsCmd)
+	rootCmd.AddCommand(authzCmd)
 }
 
 func Execute() {
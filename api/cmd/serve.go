@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
@@ -21,6 +22,7 @@ import (
 	"github.com/caraml-dev/mlp/api/database"
 	"github.com/caraml-dev/mlp/api/log"
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	"github.com/caraml-dev/mlp/api/service"
 )
 
 var (
@@ -57,6 +59,30 @@ func startServer(cfg *config.Config) {
 		log.Panicf("unable to initialize application context: %v", err)
 	}
 
+	if cfg.MembershipSweeper.Enabled {
+		sweeper := service.NewMembershipSweeper(appCtx.ProjectsService, cfg.MembershipSweeper.Interval)
+		go sweeper.Run(context.Background())
+	}
+
+	if cfg.Authorization.Enabled && cfg.Authorization.Reconciliation.Enabled {
+		reconciler := service.NewScheduledAuthorizationReconciler(appCtx.AuthorizationReconciler,
+			cfg.Authorization.Reconciliation.Interval)
+		go reconciler.Run(context.Background())
+	}
+
+	if cfg.Authorization.Enabled && cfg.Authorization.SyncInterval > 0 {
+		worker := service.NewAuthorizationSyncWorker(appCtx.AuthorizationSyncService, cfg.Authorization.SyncInterval)
+		go worker.Run(context.Background())
+	}
+
+	decisionLog := cfg.Authorization.DecisionLog
+	if cfg.Authorization.Enabled && decisionLog.Enabled && decisionLog.Sink == config.DatabaseDecisionLogSink &&
+		decisionLog.Retention > 0 {
+		pruner := service.NewDecisionLogPruner(appCtx.AuthorizationDecisionsService, decisionLog.Retention,
+			decisionLog.PruneInterval)
+		go pruner.Run(context.Background())
+	}
+
 	router := mux.NewRouter()
 
 	mount(router, "/v1/internal", healthcheck.NewHandler())
@@ -66,6 +92,12 @@ func startServer(cfg *config.Config) {
 		&api.ProjectsController{AppContext: appCtx},
 		&api.SecretsController{AppContext: appCtx},
 		&api.SecretStoragesController{AppContext: appCtx},
+		&api.StreamsController{AppContext: appCtx},
+		&api.GroupsController{AppContext: appCtx},
+		&api.AccessRequestsController{AppContext: appCtx},
+		&api.ServiceAccountsController{AppContext: appCtx},
+		&api.RolesController{AppContext: appCtx},
+		&api.AuthorizationController{AppContext: appCtx},
 	}
 	mount(router, "/v1", api.NewRouter(appCtx, v1Controllers))
 
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-playground/validator"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/caraml-dev/mlp/api/api"
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/service"
+	"github.com/caraml-dev/mlp/api/validation"
+)
+
+// syncGroupsActor is recorded as the author of the changes made by the sync-groups command
+const syncGroupsActor = "mlp-sync-groups"
+
+// groupDirectory is the content of the file read by the sync-groups command, typically exported from a directory
+// service
+type groupDirectory struct {
+	Groups []*models.Group `json:"groups"`
+}
+
+var (
+	syncGroupsFile  string
+	syncGroupsPrune bool
+	syncGroupsCmd   = &cobra.Command{
+		Use:   "sync-groups",
+		Short: "Sync groups from a directory file",
+		Long: "Create and update the groups so that their description, members and owners match the groups of a " +
+			"YAML or JSON directory file, such as an export of the teams of a directory service.",
+		Run: func(cmd *cobra.Command, _ []string) {
+			groups, err := loadGroupDirectory(syncGroupsFile)
+			if err != nil {
+				log.Fatalf("unable to load groups: %v", err)
+			}
+
+			var result *service.GroupSyncResult
+			err = withAppContext(func(appCtx *api.AppContext) error {
+				ctx := requestctx.WithActor(context.Background(), syncGroupsActor)
+				result, err = appCtx.GroupsService.SyncGroups(ctx, groups, syncGroupsPrune)
+				return err
+			})
+			// the result is printed even on failure, to show what has been synced before the failure
+			printGroupSyncResult(cmd.OutOrStdout(), result)
+			if err != nil {
+				log.Fatalf("unable to sync groups: %v", err)
+			}
+		},
+	}
+)
+
+func init() {
+	syncGroupsCmd.Flags().StringSliceVarP(&configFiles, "config", "c", []string{},
+		"Comma separated list of config files to load. The last config file will take precedence over the "+
+			"previous ones.")
+	syncGroupsCmd.Flags().StringVarP(&syncGroupsFile, "file", "f", "", "Path to the directory file to sync")
+	syncGroupsCmd.Flags().BoolVar(&syncGroupsPrune, "prune", false,
+		"Delete the groups that are not in the directory file")
+	err := syncGroupsCmd.MarkFlagRequired("file")
+	if err != nil {
+		log.Panicf("unable to mark flag as required: %v", err)
+	}
+}
+
+func loadGroupDirectory(path string) ([]*models.Group, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	directory := &groupDirectory{}
+	if err := yaml.UnmarshalStrict(data, directory); err != nil {
+		return nil, fmt.Errorf("invalid directory file %s: %w", path, err)
+	}
+
+	validate := validation.NewValidator()
+	for _, group := range directory.Groups {
+		if err := validate.Struct(group); err != nil {
+			message := err.(validator.ValidationErrors)[0].Translate(validation.EN)
+			return nil, fmt.Errorf("invalid group %s: %s", group.Name, message)
+		}
+	}
+	return directory.Groups, nil
+}
+
+func printGroupSyncResult(w io.Writer, result *service.GroupSyncResult) {
+	if result == nil {
+		return
+	}
+	for _, name := range result.Created {
+		fmt.Fprintf(w, "created group %s\n", name)
+	}
+	for _, name := range result.Updated {
+		fmt.Fprintf(w, "updated group %s\n", name)
+	}
+	for _, name := range result.Deleted {
+		fmt.Fprintf(w, "deleted group %s\n", name)
+	}
+	if len(result.Created)+len(result.Updated)+len(result.Deleted) == 0 {
+		fmt.Fprintln(w, "groups are up to date")
+	}
+}
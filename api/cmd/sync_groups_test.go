@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/service"
+)
+
+func TestLoadGroupDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "groups.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+groups:
+  - name: credit-risk
+    description: Credit risk team
+    members:
+      - alice@example.com
+      - bob@example.com
+    owners:
+      - alice@example.com
+  - name: growth
+`), 0600))
+
+	groups, err := loadGroupDirectory(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Group{
+		{
+			Name:        "credit-risk",
+			Description: "Credit risk team",
+			Members:     []string{"alice@example.com", "bob@example.com"},
+			Owners:      []string{"alice@example.com"},
+		},
+		{Name: "growth"},
+	}, groups)
+
+	require.NoError(t, os.WriteFile(path, []byte("groups:\n  - name: -invalid\n"), 0600))
+	_, err = loadGroupDirectory(path)
+	assert.ErrorContains(t, err, "invalid group -invalid")
+
+	require.NoError(t, os.WriteFile(path, []byte("groups:\n  - name: growth\n    leader: bob@example.com\n"), 0600))
+	_, err = loadGroupDirectory(path)
+	assert.ErrorContains(t, err, "invalid directory file "+path)
+}
+
+func TestPrintGroupSyncResult(t *testing.T) {
+	var out bytes.Buffer
+	printGroupSyncResult(&out, &service.GroupSyncResult{
+		Created: []string{"credit-risk"},
+		Updated: []string{"growth"},
+		Deleted: []string{"legacy"},
+	})
+	assert.Equal(t, "created group credit-risk\nupdated group growth\ndeleted group legacy\n", out.String())
+
+	out.Reset()
+	printGroupSyncResult(&out, &service.GroupSyncResult{})
+	assert.Equal(t, "groups are up to date\n", out.String())
+}
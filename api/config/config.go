@@ -25,17 +25,21 @@ type Config struct {
 	SentryDSN     string
 	OauthClientID string
 
-	Streams Streams `validate:"dive,required"`
-	Docs    Documentations
+	Streams   Streams `validate:"dive,required"`
+	Catalogue CatalogueConfig
+	Docs      Documentations
 
 	Applications         []modelsv2.Application `validate:"dive"`
-	Authorization        *AuthorizationConfig   `validate:"required"`
-	Database             *DatabaseConfig        `validate:"required"`
-	Mlflow               *MlflowConfig          `validate:"required"`
-	DefaultSecretStorage *SecretStorage         `validate:"required"`
+	Authentication       AuthenticationConfig
+	Authorization        *AuthorizationConfig `validate:"required"`
+	Database             *DatabaseConfig      `validate:"required"`
+	Mlflow               *MlflowConfig        `validate:"required"`
+	DefaultSecretStorage *SecretStorage       `validate:"required"`
 	UI                   *UIConfig
 	Webhooks             *webhooks.Config
 	UpdateProjectConfig  *UpdateProjectConfig
+	ProjectNaming        ProjectNamingConfig
+	MembershipSweeper    MembershipSweeperConfig
 }
 
 // SecretStorage represents the configuration for a secret storage.
@@ -89,18 +93,107 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 }
 
+// AuthenticationConfig configures the verification of the users of the API. When it is disabled, the user is taken
+// from the User-Email header of any request.
+type AuthenticationConfig struct {
+	Enabled bool
+	// Issuer is the expected iss claim of the OIDC tokens
+	Issuer string `validate:"required_if=Enabled True"`
+	// Audience is the audience that the tokens must be issued for
+	Audience string `validate:"required_if=Enabled True"`
+	// JWKSURL is the URL of the JSON web key set of the issuer
+	JWKSURL string `validate:"omitempty,url"`
+	// JWKSFile is a local JSON web key set used instead of JWKSURL, e.g. in tests
+	JWKSFile string
+	// JWKSRefreshInterval is the maximum age of the cached key set. The key set is also refreshed when a token is
+	// signed by an unknown key.
+	JWKSRefreshInterval time.Duration
+	// UserClaim is the claim of the tokens that the user is taken from
+	UserClaim string `validate:"required_if=Enabled True"`
+	// TrustedProxies are the IP addresses or CIDR ranges of the proxies whose User-Email header is trusted
+	TrustedProxies []string `validate:"dive,ip|cidr"`
+}
+
 type AuthorizationConfig struct {
-	Enabled         bool
-	KetoRemoteRead  string               `validate:"required_if=Enabled True"`
-	KetoRemoteWrite string               `validate:"required_if=Enabled True"`
+	Enabled bool
+	// Backend stores the authorization relations, Keto if it is empty
+	Backend AuthorizationBackend `validate:"omitempty,oneof=keto database"`
+	// KetoRemoteRead and KetoRemoteWrite are required if the authorization is enabled with the Keto backend
+	KetoRemoteRead  string
+	KetoRemoteWrite string
 	Caching         *InMemoryCacheConfig `validate:"required_if=Enabled True"`
 	UseMiddleware   bool
+	// Reconciliation periodically repairs the authorization relations of the resources
+	Reconciliation AuthorizationReconciliationConfig
+	// DecisionLog records the decision of each permission check
+	DecisionLog DecisionLogConfig
+	// SyncInterval is the time between two retries of the authorization policies of the projects which failed to be
+	// applied. The failed policies are not retried if it is zero.
+	SyncInterval time.Duration
+}
+
+// AuthorizationBackend is the storage of the relations granting the permissions
+type AuthorizationBackend string
+
+const (
+	// KetoAuthorizationBackend stores the relations in Keto
+	KetoAuthorizationBackend AuthorizationBackend = "keto"
+	// DatabaseAuthorizationBackend stores the relations in the MLP database, for the installations without Keto
+	DatabaseAuthorizationBackend AuthorizationBackend = "database"
+)
+
+// validateAuthorizationConfig requires the Keto endpoints if the authorization is enabled with the Keto backend
+func validateAuthorizationConfig(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(AuthorizationConfig)
+	if !cfg.Enabled || cfg.Backend == DatabaseAuthorizationBackend {
+		return
+	}
+	if cfg.KetoRemoteRead == "" {
+		sl.ReportError(cfg.KetoRemoteRead, "KetoRemoteRead", "KetoRemoteRead", "required_if", "")
+	}
+	if cfg.KetoRemoteWrite == "" {
+		sl.ReportError(cfg.KetoRemoteWrite, "KetoRemoteWrite", "KetoRemoteWrite", "required_if", "")
+	}
+}
+
+// AuthorizationReconciliationConfig configures the background reconciliation of the authorization relations with the
+// resources stored in the database
+type AuthorizationReconciliationConfig struct {
+	Enabled bool
+	// Interval is the time between two reconciliations
+	Interval time.Duration `validate:"required_if=Enabled True"`
+}
+
+// DecisionLogConfig configures the log of the authorization decisions
+type DecisionLogConfig struct {
+	Enabled bool
+	// Sink is where the decisions are written: stdout and file write JSON lines, database writes them to the
+	// authorization_decisions table, which can be queried by the MLP administrators
+	Sink DecisionLogSink `validate:"required_if=Enabled True,omitempty,oneof=stdout file database"`
+	// FilePath is the file the decisions are appended to by the file sink
+	FilePath string `validate:"required_if=Sink file"`
+	// Retention is how long the database sink keeps the decisions, forever if it is zero
+	Retention time.Duration
+	// PruneInterval is the time between two deletions of the decisions older than the retention
+	PruneInterval time.Duration `validate:"required_with=Retention"`
 }
 
+// DecisionLogSink is the destination of the authorization decision log
+type DecisionLogSink string
+
+const (
+	StdoutDecisionLogSink   DecisionLogSink = "stdout"
+	FileDecisionLogSink     DecisionLogSink = "file"
+	DatabaseDecisionLogSink DecisionLogSink = "database"
+)
+
 type InMemoryCacheConfig struct {
 	Enabled                     bool
 	KeyExpirySeconds            int `validate:"required_if=Enabled True"`
 	CacheCleanUpIntervalSeconds int `validate:"required_if=Enabled True"`
+	// InvalidationChannel is the Postgres channel on which the replicas broadcast the invalidations of their cache
+	// after updating the authorization rules. The invalidations are not broadcast if it is empty.
+	InvalidationChannel string
 }
 
 type MlflowConfig struct {
@@ -139,6 +232,49 @@ type UpdateProjectConfig struct {
 	LabelsBlacklist []string
 }
 
+// ProjectNamingConfig is the policy that the names of new projects have to satisfy
+type ProjectNamingConfig struct {
+	// ReservedNames are names that cannot be used by projects in addition to the names of the system namespaces, which
+	// are always reserved
+	ReservedNames []string
+	// ReservedPrefixes are prefixes that project names cannot start with
+	ReservedPrefixes []string
+	// AllowPatterns are regular expressions of which a project name has to match at least one, if any is given.
+	// Use ^ and $ to match the whole name.
+	AllowPatterns []string
+	// DenyPatterns are regular expressions that a project name must not match
+	DenyPatterns []string
+	// StreamConventions are the additional naming conventions of projects in a stream, keyed by the stream name
+	StreamConventions map[string]StreamNamingConvention
+}
+
+// StreamNamingConvention is the naming convention of the projects in a stream
+type StreamNamingConvention struct {
+	// RequireTeamPrefix requires project names to start with the name of the project's team followed by a hyphen
+	RequireTeamPrefix bool
+	// Prefix is the prefix that project names have to start with
+	Prefix string
+	// Pattern is the regular expression that project names have to match
+	Pattern string
+}
+
+// CatalogueConfig configures the validation of the streams and teams of the projects against the catalogue of streams
+// and teams. The catalogue is enforced as soon as it contains a stream, unlike the UI flags which only change the
+// inputs of the project form.
+type CatalogueConfig struct {
+	// AllowCustomStreams accepts projects whose stream is not in the catalogue
+	AllowCustomStreams bool
+	// AllowCustomTeams accepts projects whose team is not in the catalogue of their stream
+	AllowCustomTeams bool
+}
+
+// MembershipSweeperConfig configures the background removal of the project members whose membership has expired
+type MembershipSweeperConfig struct {
+	Enabled bool
+	// Interval is the time between two removals of the expired members
+	Interval time.Duration `validate:"required_if=Enabled True"`
+}
+
 // Transform env variables to the format consumed by koanf.
 // The variable key is split by the double underscore ('__') sequence,
 // which separates nested config variables, and then each config key is
@@ -188,6 +324,7 @@ func Load(paths ...string) (*Config, error) {
 
 func Validate(config *Config) error {
 	validate := validator.New()
+	validate.RegisterStructValidation(validateAuthorizationConfig, AuthorizationConfig{})
 
 	err := validate.Struct(config)
 	if err != nil {
@@ -214,6 +351,12 @@ var defaultConfig = &Config{
 	Streams:      Streams{},
 	Docs:         Documentations{},
 	Applications: []modelsv2.Application{},
+	Authentication: AuthenticationConfig{
+		Enabled:             false,
+		JWKSRefreshInterval: time.Hour,
+		UserClaim:           "email",
+		TrustedProxies:      []string{},
+	},
 	Authorization: &AuthorizationConfig{
 		Enabled: false,
 		Caching: &InMemoryCacheConfig{
@@ -221,6 +364,7 @@ var defaultConfig = &Config{
 			CacheCleanUpIntervalSeconds: 900,
 		},
 		UseMiddleware: false,
+		SyncInterval:  10 * time.Second,
 	},
 	Database: &DatabaseConfig{
 		Host:          "localhost",
@@ -242,4 +386,8 @@ var defaultConfig = &Config{
 		Name: "internal",
 		Type: "internal",
 	},
+	MembershipSweeper: MembershipSweeperConfig{
+		Enabled:  true,
+		Interval: 5 * time.Minute,
+	},
 }
@@ -58,12 +58,18 @@ func TestLoad(t *testing.T) {
 				APIHost:     "http://localhost:8080",
 				Port:        8080,
 				Environment: "dev",
+				Authentication: config.AuthenticationConfig{
+					JWKSRefreshInterval: time.Hour,
+					UserClaim:           "email",
+					TrustedProxies:      []string{},
+				},
 				Authorization: &config.AuthorizationConfig{
 					Enabled: false,
 					Caching: &config.InMemoryCacheConfig{
 						KeyExpirySeconds:            600,
 						CacheCleanUpIntervalSeconds: 900,
 					},
+					SyncInterval: 10 * time.Second,
 				},
 				Database: &config.DatabaseConfig{
 					Host:            "localhost",
@@ -96,6 +102,10 @@ func TestLoad(t *testing.T) {
 					ResponseTemplate: "",
 					LabelsBlacklist:  nil,
 				},
+				MembershipSweeper: config.MembershipSweeperConfig{
+					Enabled:  true,
+					Interval: 5 * time.Minute,
+				},
 				DefaultSecretStorage: &config.SecretStorage{
 					Name: "default-secret-storage",
 					Type: "vault",
@@ -122,12 +132,18 @@ func TestLoad(t *testing.T) {
 				APIHost:     "http://localhost:8080",
 				Port:        8080,
 				Environment: "dev",
+				Authentication: config.AuthenticationConfig{
+					JWKSRefreshInterval: time.Hour,
+					UserClaim:           "email",
+					TrustedProxies:      []string{},
+				},
 				Authorization: &config.AuthorizationConfig{
 					Enabled: false,
 					Caching: &config.InMemoryCacheConfig{
 						KeyExpirySeconds:            600,
 						CacheCleanUpIntervalSeconds: 900,
 					},
+					SyncInterval: 10 * time.Second,
 				},
 				Database: &config.DatabaseConfig{
 					Host:            "localhost",
@@ -161,6 +177,10 @@ func TestLoad(t *testing.T) {
 					ResponseTemplate: "",
 					LabelsBlacklist:  nil,
 				},
+				MembershipSweeper: config.MembershipSweeperConfig{
+					Enabled:  true,
+					Interval: 5 * time.Minute,
+				},
 				DefaultSecretStorage: &config.SecretStorage{
 					Name: "default-secret-storage",
 					Type: "vault",
@@ -213,6 +233,15 @@ func TestLoad(t *testing.T) {
 						},
 					},
 				},
+				Authentication: config.AuthenticationConfig{
+					Enabled:             true,
+					Issuer:              "https://accounts.google.com",
+					Audience:            "mlp",
+					JWKSURL:             "https://www.googleapis.com/oauth2/v3/certs",
+					JWKSRefreshInterval: time.Hour,
+					UserClaim:           "email",
+					TrustedProxies:      []string{"10.0.0.0/8", "127.0.0.1"},
+				},
 				Authorization: &config.AuthorizationConfig{
 					Enabled:         true,
 					KetoRemoteRead:  "http://localhost:4466",
@@ -221,8 +250,10 @@ func TestLoad(t *testing.T) {
 						Enabled:                     true,
 						KeyExpirySeconds:            1000,
 						CacheCleanUpIntervalSeconds: 2000,
+						InvalidationChannel:         "mlp_authz_cache_invalidation",
 					},
 					UseMiddleware: true,
+					SyncInterval:  10 * time.Second,
 				},
 				Database: &config.DatabaseConfig{
 					Host:            "localhost",
@@ -271,6 +302,18 @@ func TestLoad(t *testing.T) {
 						"label2",
 					},
 				},
+				ProjectNaming: config.ProjectNamingConfig{
+					ReservedNames:    []string{"infrastructure", "monitoring"},
+					ReservedPrefixes: []string{"kube-"},
+					DenyPatterns:     []string{"--"},
+					StreamConventions: map[string]config.StreamNamingConvention{
+						"stream-1": {RequireTeamPrefix: true},
+					},
+				},
+				MembershipSweeper: config.MembershipSweeperConfig{
+					Enabled:  true,
+					Interval: 5 * time.Minute,
+				},
 				DefaultSecretStorage: &config.SecretStorage{
 					Name: "default-secret-storage",
 					Type: "vault",
@@ -366,6 +409,58 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"database authorization backend | success": {
+			config: &config.Config{
+				APIHost:     "/v1",
+				Port:        8080,
+				Environment: "dev",
+				Authorization: &config.AuthorizationConfig{
+					Enabled: true,
+					Backend: config.DatabaseAuthorizationBackend,
+					Caching: &config.InMemoryCacheConfig{
+						KeyExpirySeconds:            600,
+						CacheCleanUpIntervalSeconds: 900,
+					},
+				},
+				Database: &config.DatabaseConfig{
+					Host:          "localhost",
+					Port:          5432,
+					User:          "mlp",
+					Password:      "mlp",
+					Database:      "mlp",
+					MigrationPath: "file://db-migrations",
+				},
+				Mlflow: &config.MlflowConfig{
+					TrackingURL: "http://mlflow.tracking",
+				},
+				DefaultSecretStorage: &config.SecretStorage{
+					Name: "default-secret-storage",
+					Type: "vault",
+					Config: models.SecretStorageConfig{
+						VaultConfig: &models.VaultConfig{
+							URL:         "http://vault:8200",
+							Role:        "my-role",
+							MountPath:   "secret",
+							PathPrefix:  "caraml-secret/{{ .project }}/",
+							AuthMethod:  models.GCPAuthMethod,
+							GCPAuthType: models.GCEGCPAuthType,
+						},
+					},
+				},
+				UI: &config.UIConfig{
+					ProjectInfoUpdateEnabled: true,
+				},
+				UpdateProjectConfig: &config.UpdateProjectConfig{
+					Endpoint:         "http://example-update-project.dev",
+					PayloadTemplate:  "your-payload-template",
+					ResponseTemplate: "your-response-template",
+					LabelsBlacklist: []string{
+						"label1",
+						"label2",
+					},
+				},
+			},
+		},
 		"extended | success": {
 			config: &config.Config{
 				APIHost:     "/v1",
@@ -487,6 +582,70 @@ func TestValidate(t *testing.T) {
 					"Error:Field validation for 'KetoRemoteRead' failed on the 'required_if' tag",
 			),
 		},
+		"invalid authentication | failure": {
+			config: &config.Config{
+				APIHost:     "/v1",
+				Port:        8080,
+				Environment: "dev",
+				Authentication: config.AuthenticationConfig{
+					Enabled:        true,
+					Audience:       "mlp",
+					UserClaim:      "email",
+					TrustedProxies: []string{"10.0.0.0/33"},
+				},
+				Authorization: &config.AuthorizationConfig{
+					Enabled: false,
+					Caching: &config.InMemoryCacheConfig{
+						KeyExpirySeconds:            600,
+						CacheCleanUpIntervalSeconds: 900,
+					},
+				},
+				Database: &config.DatabaseConfig{
+					Host:          "localhost",
+					Port:          5432,
+					User:          "mlp",
+					Password:      "mlp",
+					Database:      "mlp",
+					MigrationPath: "file://db-migrations",
+				},
+				Mlflow: &config.MlflowConfig{
+					TrackingURL: "http://mlflow.tracking",
+				},
+				DefaultSecretStorage: &config.SecretStorage{
+					Name: "default-secret-storage",
+					Type: "vault",
+					Config: models.SecretStorageConfig{
+						VaultConfig: &models.VaultConfig{
+							URL:         "http://vault:8200",
+							Role:        "my-role",
+							MountPath:   "secret",
+							PathPrefix:  "caraml-secret/{{ .project }}/",
+							AuthMethod:  models.GCPAuthMethod,
+							GCPAuthType: models.GCEGCPAuthType,
+						},
+					},
+				},
+				UI: &config.UIConfig{
+					ProjectInfoUpdateEnabled: true,
+				},
+				UpdateProjectConfig: &config.UpdateProjectConfig{
+					Endpoint:         "http://example-update-project.dev",
+					PayloadTemplate:  "your-payload-template",
+					ResponseTemplate: "your-response-template",
+					LabelsBlacklist: []string{
+						"label1",
+						"label2",
+					},
+				},
+			},
+			error: errors.New(
+				"failed to validate configuration: " +
+					"Key: 'Config.Authentication.Issuer' " +
+					"Error:Field validation for 'Issuer' failed on the 'required_if' tag\n" +
+					"Key: 'Config.Authentication.TrustedProxies[0]' " +
+					"Error:Field validation for 'TrustedProxies[0]' failed on the 'ip|cidr' tag",
+			),
+		},
 		"missing authz cache key expiry | failure": {
 			config: &config.Config{
 				APIHost:     "/v1",
@@ -546,6 +705,70 @@ func TestValidate(t *testing.T) {
 					"Error:Field validation for 'CacheCleanUpIntervalSeconds' failed on the 'required_if' tag",
 			),
 		},
+		"invalid authz decision log | failure": {
+			config: &config.Config{
+				APIHost:     "/v1",
+				Port:        8080,
+				Environment: "dev",
+				Authorization: &config.AuthorizationConfig{
+					Enabled:         true,
+					KetoRemoteRead:  "http://abc",
+					KetoRemoteWrite: "http://abc",
+					Caching: &config.InMemoryCacheConfig{
+						Enabled: false,
+					},
+					DecisionLog: config.DecisionLogConfig{
+						Enabled:   true,
+						Sink:      config.FileDecisionLogSink,
+						Retention: 24 * time.Hour,
+					},
+				},
+				Database: &config.DatabaseConfig{
+					Host:          "localhost",
+					Port:          5432,
+					User:          "mlp",
+					Password:      "mlp",
+					Database:      "mlp",
+					MigrationPath: "file://db-migrations",
+				},
+				Mlflow: &config.MlflowConfig{
+					TrackingURL: "http://mlflow.tracking",
+				},
+				DefaultSecretStorage: &config.SecretStorage{
+					Name: "default-secret-storage",
+					Type: "vault",
+					Config: models.SecretStorageConfig{
+						VaultConfig: &models.VaultConfig{
+							URL:         "http://vault:8200",
+							Role:        "my-role",
+							MountPath:   "secret",
+							PathPrefix:  "caraml-secret/{{ .project }}/",
+							AuthMethod:  models.GCPAuthMethod,
+							GCPAuthType: models.GCEGCPAuthType,
+						},
+					},
+				},
+				UI: &config.UIConfig{
+					ProjectInfoUpdateEnabled: true,
+				},
+				UpdateProjectConfig: &config.UpdateProjectConfig{
+					Endpoint:         "http://example-update-project.dev",
+					PayloadTemplate:  "your-payload-template",
+					ResponseTemplate: "your-response-template",
+					LabelsBlacklist: []string{
+						"label1",
+						"label2",
+					},
+				},
+			},
+			error: errors.New(
+				"failed to validate configuration: " +
+					"Key: 'Config.Authorization.DecisionLog.FilePath' " +
+					"Error:Field validation for 'FilePath' failed on the 'required_if' tag\n" +
+					"Key: 'Config.Authorization.DecisionLog.PruneInterval' " +
+					"Error:Field validation for 'PruneInterval' failed on the 'required_with' tag",
+			),
+		},
 	}
 
 	for name, tt := range suite {
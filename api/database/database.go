@@ -20,14 +20,7 @@ import (
 // InitDB initialises a database connection as well as runs the migration scripts.
 // It is important to close the database after using it by calling defer db.Close()
 func InitDB(dbCfg *config.DatabaseConfig) (*gorm.DB, error) {
-	db, err := gorm.Open(
-		"postgres",
-		fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=disable",
-			dbCfg.Host,
-			dbCfg.Port,
-			dbCfg.User,
-			dbCfg.Database,
-			dbCfg.Password))
+	db, err := gorm.Open("postgres", ConnectionString(dbCfg))
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +44,16 @@ func InitDB(dbCfg *config.DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
+// ConnectionString returns the connection string of the database
+func ConnectionString(dbCfg *config.DatabaseConfig) string {
+	return fmt.Sprintf("host=%s port=%d user=%s dbname=%s password=%s sslmode=disable",
+		dbCfg.Host,
+		dbCfg.Port,
+		dbCfg.User,
+		dbCfg.Database,
+		dbCfg.Password)
+}
+
 func runDBMigration(db *gorm.DB, migrationPath string) error {
 	driver, err := postgres.WithInstance(db.DB(), &postgres.Config{})
 	if err != nil {
@@ -5,6 +5,8 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -40,6 +42,12 @@ func create(conn *sql.DB, dbName string) (*sql.DB, error) {
 	return testDb, nil
 }
 
+// migrationsURL locates the migrations from this file, so that the packages at any depth can use the test databases
+func migrationsURL() string {
+	_, file, _, _ := runtime.Caller(0)
+	return "file://" + filepath.Join(filepath.Dir(file), "..", "..", "..", "db-migrations")
+}
+
 func migrate(db *sql.DB, dbName string) (*sql.DB, error) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
@@ -47,7 +55,7 @@ func migrate(db *sql.DB, dbName string) (*sql.DB, error) {
 	}
 	defer driver.Close()
 
-	if migrations, err := gomigrate.NewWithDatabaseInstance("file://../../db-migrations", dbName, driver); err != nil {
+	if migrations, err := gomigrate.NewWithDatabaseInstance(migrationsURL(), dbName, driver); err != nil {
 		return nil, err
 	} else if err = migrations.Up(); err != nil {
 		return nil, err
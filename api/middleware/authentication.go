@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+// TokenVerifier verifies a bearer token and returns the user that it identifies
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (string, error)
+}
+
+// Authenticator sets the User-Email header of the requests to their authenticated user, so that the handlers and the
+// other middlewares can rely on it. The user is taken from the OIDC bearer token of the request, or from the
+// User-Email header if the request comes from a trusted proxy that has authenticated the user itself.
+type Authenticator struct {
+	verifier       TokenVerifier
+	trustedProxies []*net.IPNet
+}
+
+// NewAuthenticator creates an authenticator trusting the User-Email header of the requests from the given IP
+// addresses or CIDR ranges
+func NewAuthenticator(verifier TokenVerifier, trustedProxies []string) (*Authenticator, error) {
+	networks := make([]*net.IPNet, 0, len(trustedProxies))
+	for _, proxy := range trustedProxies {
+		if ip := net.ParseIP(proxy); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %w", proxy, err)
+		}
+		networks = append(networks, network)
+	}
+	return &Authenticator{verifier: verifier, trustedProxies: networks}, nil
+}
+
+// AuthenticationMiddleware rejects the requests whose user cannot be authenticated. The tokens of service accounts
+// are left to the ServiceAccountMiddleware.
+func (a *Authenticator) AuthenticationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, hasToken := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case hasToken && strings.HasPrefix(token, models.ServiceAccountTokenPrefix):
+			r.Header.Del("User-Email")
+		case r.Header.Get("User-Email") != "" && a.isTrustedProxy(r.RemoteAddr):
+			// the proxy has authenticated the user, its own credentials may be forwarded in the Authorization header
+		case hasToken:
+			user, err := a.verifier.Verify(r.Context(), token)
+			if errors.Is(err, &apperrors.UnauthenticatedError{}) {
+				jsonError(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				jsonError(w, fmt.Sprintf("Error while authenticating user: %s", err), http.StatusInternalServerError)
+				return
+			}
+			r.Header.Set("User-Email", user)
+		default:
+			jsonError(w, "a bearer token is required", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isTrustedProxy returns true if the request comes directly from a trusted proxy. The X-Forwarded-For header is not
+// used since it is set by the client.
+func (a *Authenticator) isTrustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range a.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type tokenVerifierFunc func(token string) (string, error)
+
+func (f tokenVerifierFunc) Verify(_ context.Context, token string) (string, error) {
+	return f(token)
+}
+
+func TestAuthenticationMiddleware(t *testing.T) {
+	verifier := tokenVerifierFunc(func(token string) (string, error) {
+		switch token {
+		case "valid":
+			return "user@example.com", nil
+		case "failing":
+			return "", errors.New("connection refused")
+		default:
+			return "", apperrors.NewUnauthenticatedErrorf("invalid token")
+		}
+	})
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		userEmail      string
+		authorization  string
+		expectedStatus int
+		expectedUser   string
+	}{
+		{
+			name:           "valid token",
+			remoteAddr:     "192.168.1.1:1234",
+			authorization:  "Bearer valid",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "user@example.com",
+		},
+		{
+			name:           "header from untrusted client is replaced by the user of the token",
+			remoteAddr:     "192.168.1.1:1234",
+			userEmail:      "admin@example.com",
+			authorization:  "Bearer valid",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "user@example.com",
+		},
+		{
+			name:           "header from untrusted client",
+			remoteAddr:     "192.168.1.1:1234",
+			userEmail:      "admin@example.com",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "header from trusted proxy",
+			remoteAddr:     "10.1.2.3:1234",
+			userEmail:      "admin@example.com",
+			authorization:  "Bearer proxy-credentials",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "admin@example.com",
+		},
+		{
+			name:           "header from trusted proxy address",
+			remoteAddr:     "[::1]:1234",
+			userEmail:      "admin@example.com",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "admin@example.com",
+		},
+		{
+			name:           "header from other address",
+			remoteAddr:     "172.16.0.2:1234",
+			userEmail:      "admin@example.com",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "header from trusted IPv4 proxy address",
+			remoteAddr:     "172.16.0.1:1234",
+			userEmail:      "admin@example.com",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "admin@example.com",
+		},
+		{
+			name:           "trusted proxy without user",
+			remoteAddr:     "10.1.2.3:1234",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			remoteAddr:     "192.168.1.1:1234",
+			authorization:  "Bearer invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "verification error",
+			remoteAddr:     "192.168.1.1:1234",
+			authorization:  "Bearer failing",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "service account token is left to the service account middleware",
+			remoteAddr:     "192.168.1.1:1234",
+			userEmail:      "admin@example.com",
+			authorization:  "Bearer mlp_token",
+			expectedStatus: http.StatusOK,
+			expectedUser:   "",
+		},
+	}
+
+	authenticator, err := NewAuthenticator(verifier, []string{"10.0.0.0/8", "172.16.0.1", "::1"})
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user string
+			handler := authenticator.AuthenticationMiddleware(http.HandlerFunc(func(w http.ResponseWriter,
+				r *http.Request) {
+				user = r.Header.Get("User-Email")
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/projects", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.userEmail != "" {
+				r.Header.Set("User-Email", tt.userEmail)
+			}
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedUser, user)
+		})
+	}
+}
+
+func TestNewAuthenticator(t *testing.T) {
+	_, err := NewAuthenticator(tokenVerifierFunc(nil), []string{"10.0.0.0/33"})
+	assert.EqualError(t, err, "invalid trusted proxy 10.0.0.0/33: invalid CIDR address: 10.0.0.0/33")
+	_, err = NewAuthenticator(tokenVerifierFunc(nil), []string{"proxy.internal"})
+	assert.EqualError(t, err, "invalid trusted proxy proxy.internal: invalid CIDR address: proxy.internal")
+}
@@ -4,9 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
-	"golang.org/x/exp/slices"
+	"github.com/gorilla/mux"
 
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
 )
@@ -19,74 +20,107 @@ func NewAuthorizer(enforcer enforcer.Enforcer) *Authorizer {
 	return &Authorizer{authEnforcer: enforcer}
 }
 
-type Operation struct {
-	RequestPath   string
-	RequestMethod []string
+// resourceVariable matches the path variables in the resource template of a route permission, e.g. {project_id}
+var resourceVariable = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// RoutePermission declares the permission required to call a route. The permission is mlp.<resource>.<action>, where
+// the path variables of the resource template are replaced by the values of the request, e.g. the resource
+// projects.{project_id}.secrets and the action get require mlp.projects.1.secrets.get for /projects/1/secrets.
+type RoutePermission struct {
+	// Public routes can be called by all users without any permission
+	Public bool
+	// Resource is the template of the resource of the route
+	Resource string
+	// Action is the action performed on the resource
+	Action string
 }
 
-var publicOperations = []Operation{
-	{"/projects", []string{http.MethodGet, http.MethodPost}},
-	{"/applications", []string{http.MethodGet}},
+// Public returns the permission of the routes that all users can call
+func Public() RoutePermission {
+	return RoutePermission{Public: true}
 }
 
-// AuthorizationMiddleware is a middleware that checks if the request is authorized.
-func (a *Authorizer) AuthorizationMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if !a.RequireAuthorization(r.URL.Path, r.Method) {
-			next.ServeHTTP(w, r)
-			return
-		}
-		permission := a.GetPermission(r.URL.Path, r.Method)
-		user := r.Header.Get("User-Email")
-
-		allowed, err := a.authEnforcer.IsUserGrantedPermission(r.Context(), user, permission)
-		if err != nil {
-			jsonError(
-				w,
-				fmt.Sprintf("Error while checking authorization: %s", err),
-				http.StatusInternalServerError)
-			return
-		}
-		if !allowed {
-			jsonError(
-				w,
-				fmt.Sprintf("%s does not have the permission:%s ", user, permission),
-				http.StatusUnauthorized)
-			return
-		}
-
-		next.ServeHTTP(w, r)
-	})
+// Requires returns the permission to perform the action on the resource
+func Requires(resource string, action string) RoutePermission {
+	return RoutePermission{Resource: resource, Action: action}
 }
 
-// RequireAuthorization returns true if the request requires authorization.
-func (a *Authorizer) RequireAuthorization(requestPath string, requestMethod string) bool {
-	if requestMethod == http.MethodOptions {
-		return false
+// Validate checks that the permission is declared and that the variables of its resource template are path variables
+// of the route
+func (p RoutePermission) Validate(path string) error {
+	if p.Public {
+		if p.Resource != "" || p.Action != "" {
+			return fmt.Errorf("public route cannot require a permission")
+		}
+		return nil
 	}
+	if p.Resource == "" || p.Action == "" {
+		return fmt.Errorf("route must be public or declare the resource and the action of its permission")
+	}
+	for _, match := range resourceVariable.FindAllStringSubmatch(p.Resource, -1) {
+		if !strings.Contains(path, "{"+match[1]+"}") && !strings.Contains(path, "{"+match[1]+":") {
+			return fmt.Errorf("resource %s refers to %s, which is not a variable of the path %s", p.Resource, match[1],
+				path)
+		}
+	}
+	return nil
+}
 
-	for _, operation := range publicOperations {
-		if operation.RequestPath == requestPath && slices.Contains(operation.RequestMethod, requestMethod) {
-			return false
+// Permission returns the permission required for the path variables of a request
+func (p RoutePermission) Permission(vars map[string]string) (string, error) {
+	var err error
+	resource := resourceVariable.ReplaceAllStringFunc(p.Resource, func(variable string) string {
+		name := strings.Trim(variable, "{}")
+		value, ok := vars[name]
+		if !ok || value == "" {
+			err = fmt.Errorf("missing path variable %s", name)
 		}
+		return value
+	})
+	if err != nil {
+		return "", err
 	}
-	return true
+	return fmt.Sprintf("mlp.%s.%s", resource, p.Action), nil
 }
 
-// GetPermission returns the permission required to authorized a request.
-// It's assumed that permission to request is a one to one mapping.
-func (a *Authorizer) GetPermission(requestPath string, requestMethod string) string {
-	parts := strings.Split(strings.TrimPrefix(requestPath, "/"), "/")
-	// Current paths registered in MLP are of the following format:
-	// - /projects
-	// - /applications
-	// - /projects/{project_id}/**
-	// Only project sub-resources endpoint require permission. If a user has READ/WRITE permissions
-	// on /projects/{project_id}, they would also have the same permissions on all its sub-resources.
-	if len(parts) > 1 {
-		parts = parts[:2]
+// RouteAuthorizationMiddleware returns a middleware that checks that the user of the request has the permission
+// declared by the route. It must wrap the handler of the route, so that the path variables are available.
+func (a *Authorizer) RouteAuthorizationMiddleware(routePermission RoutePermission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if routePermission.Public || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+			permission, err := routePermission.Permission(mux.Vars(r))
+			if err != nil {
+				jsonError(
+					w,
+					fmt.Sprintf("Error while checking authorization: %s", err),
+					http.StatusInternalServerError)
+				return
+			}
+			user := r.Header.Get("User-Email")
+
+			allowed, err := a.authEnforcer.IsUserGrantedPermission(r.Context(), user, permission)
+			if err != nil {
+				jsonError(
+					w,
+					fmt.Sprintf("Error while checking authorization: %s", err),
+					http.StatusInternalServerError)
+				return
+			}
+			if !allowed {
+				jsonError(
+					w,
+					fmt.Sprintf("%s does not have the permission:%s ", user, permission),
+					http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
 	}
-	return fmt.Sprintf("mlp.%s.%s", strings.Join(parts, "."), strings.ToLower(requestMethod))
 }
 
 func jsonError(w http.ResponseWriter, msg string, status int) {
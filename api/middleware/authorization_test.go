@@ -1,45 +1,154 @@
 package middleware
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
 )
 
-func TestAuthorizer_RequireAuthorization(t *testing.T) {
-	authorizer := NewAuthorizer(&enforcerMock.Enforcer{})
+func TestRoutePermission_Validate(t *testing.T) {
 	tests := []struct {
-		name     string
-		path     string
-		method   string
-		expected bool
+		name        string
+		permission  RoutePermission
+		path        string
+		expectedErr string
 	}{
-		{"All authenticated users can list projects", "/projects", "GET", false},
-		{"All authenticated users can create new project", "/projects", "POST", false},
-		{"All authenticated users can list applications", "/applications", "GET", false},
-		{"Only authorized users can update project", "/projects/100", "PATCH", true},
-		{"Options http request does not require authorization", "/projects/100", "OPTIONS", false},
-		{"Only authorized users can access project sub resources", "/projects/100/secrets", "GET", true},
+		{"public route", Public(), "/projects", ""},
+		{"project permission", Requires("projects.{project_id}", "get"), "/projects/{project_id:[0-9]+}", ""},
+		{"secrets permission", Requires("projects.{project_id}.secrets", "get"),
+			"/projects/{project_id:[0-9]+}/secrets/{secret_id}", ""},
+		{"undeclared permission", RoutePermission{}, "/projects", "route must be public or declare the resource " +
+			"and the action of its permission"},
+		{"missing action", RoutePermission{Resource: "projects"}, "/projects", "route must be public or declare " +
+			"the resource and the action of its permission"},
+		{"public route with permission", RoutePermission{Public: true, Resource: "projects", Action: "get"},
+			"/projects", "public route cannot require a permission"},
+		{"unknown path variable", Requires("projects.{id}", "get"), "/projects/{project_id:[0-9]+}",
+			"resource projects.{id} refers to id, which is not a variable of the path /projects/{project_id:[0-9]+}"},
 	}
 	for _, tt := range tests {
-		assert.Equal(t, tt.expected, authorizer.RequireAuthorization(tt.path, tt.method))
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.permission.Validate(tt.path)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
 	}
 }
 
-func TestAuthorizer_GetPermission(t *testing.T) {
-	authorizer := NewAuthorizer(&enforcerMock.Enforcer{})
+func TestRoutePermission_Permission(t *testing.T) {
 	tests := []struct {
-		name     string
-		path     string
-		method   string
-		expected string
+		name       string
+		permission RoutePermission
+		vars       map[string]string
+		expected   string
 	}{
-		{"project permission", "/projects/1003", "GET", "mlp.projects.1003.get"},
-		{"project sub-resource permission", "/projects/1003/secrets", "GET", "mlp.projects.1003.get"},
+		{"project permission", Requires("projects.{project_id}", "get"), map[string]string{"project_id": "1003"},
+			"mlp.projects.1003.get"},
+		{"project sub-resource permission", Requires("projects.{project_id}.secrets", "get"),
+			map[string]string{"project_id": "1003", "secret_id": "2"}, "mlp.projects.1003.secrets.get"},
+		{"stream permission", Requires("streams.{stream_id}", "put"), map[string]string{"stream_id": "4"},
+			"mlp.streams.4.put"},
+		{"permission without variables", Requires("streams", "post"), map[string]string{}, "mlp.streams.post"},
 	}
 	for _, tt := range tests {
-		assert.Equal(t, tt.expected, authorizer.GetPermission(tt.path, tt.method))
+		t.Run(tt.name, func(t *testing.T) {
+			permission, err := tt.permission.Permission(tt.vars)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, permission)
+		})
+	}
+
+	_, err := Requires("projects.{project_id}", "get").Permission(map[string]string{})
+	assert.EqualError(t, err, "missing path variable project_id")
+}
+
+func TestAuthorizer_RouteAuthorizationMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		permission     RoutePermission
+		method         string
+		path           string
+		enforcerResult func(e *enforcerMock.Enforcer)
+		expectedStatus int
+	}{
+		{
+			name:           "public route",
+			permission:     Public(),
+			method:         http.MethodGet,
+			path:           "/projects/1/secrets",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "options request",
+			permission:     Requires("projects.{project_id}.secrets", "get"),
+			method:         http.MethodOptions,
+			path:           "/projects/1/secrets",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "granted permission",
+			permission: Requires("projects.{project_id}.secrets", "get"),
+			method:     http.MethodGet,
+			path:       "/projects/1/secrets",
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@example.com", "mlp.projects.1.secrets.get").
+					Return(true, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "denied permission",
+			permission: Requires("projects.{project_id}.secrets", "get"),
+			method:     http.MethodGet,
+			path:       "/projects/1/secrets",
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@example.com", "mlp.projects.1.secrets.get").
+					Return(false, nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "enforcer error",
+			permission: Requires("projects.{project_id}", "get"),
+			method:     http.MethodGet,
+			path:       "/projects/1/secrets",
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@example.com", "mlp.projects.1.get").
+					Return(false, errors.New("keto is unavailable"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authEnforcer := &enforcerMock.Enforcer{}
+			if tt.enforcerResult != nil {
+				tt.enforcerResult(authEnforcer)
+			}
+			authorizer := NewAuthorizer(authEnforcer)
+			router := mux.NewRouter()
+			router.Methods(tt.method).
+				Path("/projects/{project_id:[0-9]+}/secrets").
+				Handler(authorizer.RouteAuthorizationMiddleware(tt.permission)(
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			r.Header.Set("User-Email", "user@example.com")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			authEnforcer.AssertExpectations(t)
+		})
 	}
 }
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+// RequestIDHeader is the header used to propagate the ID of a request. The ID is generated if the client does not
+// provide one, and is always returned in the response.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestContextMiddleware stores the user making the request and the request ID in the request context
+func RequestContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := requestctx.WithActor(r.Context(), r.Header.Get("User-Email"))
+		ctx = requestctx.WithRequestID(ctx, requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+func TestRequestContextMiddleware(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestID         string
+		expectedRequestID func(t *testing.T, requestID string)
+	}{
+		{
+			"request id is propagated",
+			"request-id",
+			func(t *testing.T, requestID string) {
+				assert.Equal(t, "request-id", requestID)
+			},
+		},
+		{
+			"request id is generated",
+			"",
+			func(t *testing.T, requestID string) {
+				assert.NotEmpty(t, requestID)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor, requestID string
+			handler := RequestContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = requestctx.Actor(r.Context())
+				requestID = requestctx.RequestID(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/projects", nil)
+			r.Header.Set("User-Email", "user@example.com")
+			if tt.requestID != "" {
+				r.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, "user@example.com", actor)
+			tt.expectedRequestID(t, requestID)
+			assert.Equal(t, requestID, w.Header().Get(RequestIDHeader))
+		})
+	}
+}
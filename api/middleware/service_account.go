@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+// TokenAuthenticator resolves the bearer token of a service account to the subject of the service account
+type TokenAuthenticator interface {
+	AuthenticateToken(token string) (string, error)
+}
+
+// ServiceAccountMiddleware authenticates the requests made with the token of a service account, whose subject then
+// replaces the User-Email header for the handlers and the authorization middleware. It runs after the
+// AuthenticationMiddleware, which has already verified the OIDC tokens of users and passes the tokens of service
+// accounts on, so other bearer tokens are left as is. The subject of a service account can only be obtained with its
+// token.
+func ServiceAccountMiddleware(authenticator TokenAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.Header.Get("User-Email"), models.ServiceAccountSubjectPrefix) {
+				jsonError(w, "service accounts must authenticate with a token", http.StatusUnauthorized)
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || !strings.HasPrefix(token, models.ServiceAccountTokenPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			subject, err := authenticator.AuthenticateToken(token)
+			if errors.Is(err, &apperrors.UnauthenticatedError{}) {
+				jsonError(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				jsonError(w, fmt.Sprintf("Error while authenticating service account: %s", err),
+					http.StatusInternalServerError)
+				return
+			}
+
+			r.Header.Set("User-Email", subject)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// UserAuthorization describes what a user is allowed to do
+type UserAuthorization struct {
+	User string `json:"user"`
+	// AuthorizationEnabled is false if the permissions are not enforced, in which case users are allowed to do
+	// everything and have no roles
+	AuthorizationEnabled bool `json:"authorization_enabled"`
+	// Groups are the names of the groups of which the user is a member
+	Groups []string `json:"groups"`
+	// Roles are the roles granted to the user, either directly or through its groups
+	Roles []string `json:"roles"`
+	// Permissions are the permissions granted by the roles of the user
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionCheck is the evaluation of a permission of a subject, e.g. mlp.projects.1.get for a user
+type PermissionCheck struct {
+	// Subject is the user or the service account whose permission is checked, the caller if it is empty
+	Subject    string `json:"subject"`
+	Permission string `json:"permission" validate:"required"`
+	Allowed    bool   `json:"allowed"`
+}
+
+// PermissionCheckRequest is a batch of permissions to check
+type PermissionCheckRequest struct {
+	Checks []PermissionCheck `json:"checks" validate:"required,min=1,max=100,dive"`
+}
+
+// AuthorizationDecision records the result of a permission check, e.g. to prove who read the secrets of a project
+type AuthorizationDecision struct {
+	ID         ID     `json:"id"`
+	Subject    string `json:"subject"`
+	Permission string `json:"permission"`
+	Allowed    bool   `json:"allowed"`
+	// CacheHit is true if the decision was served by the cache of the permission checks
+	CacheHit bool `json:"cache_hit"`
+	// LatencyMs is the duration of the check in milliseconds
+	LatencyMs float64 `json:"latency_ms"`
+	// Error is the error of the check that failed, in which case the access is denied
+	Error     string    `json:"error,omitempty"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// AuthorizationSyncAction is the change of the authorization policy of a project that a sync applies
+type AuthorizationSyncAction string
+
+const (
+	// AuthorizationSyncUpdate grants the permissions of the project to its roles and sets their members
+	AuthorizationSyncUpdate AuthorizationSyncAction = "update"
+	// AuthorizationSyncRemove revokes the permissions of the deleted project and removes the members of its roles
+	AuthorizationSyncRemove AuthorizationSyncAction = "remove"
+)
+
+// AuthorizationSyncStatus is the state of a sync which has not been applied yet
+type AuthorizationSyncStatus string
+
+const (
+	// AuthorizationSyncPending syncs have not been attempted yet
+	AuthorizationSyncPending AuthorizationSyncStatus = "pending"
+	// AuthorizationSyncFailed syncs failed to be applied and are retried with an exponential backoff
+	AuthorizationSyncFailed AuthorizationSyncStatus = "failed"
+)
+
+// AuthorizationSync is an entry of the outbox of the authorization policy changes. It is recorded in the same
+// transaction as the change of the project and deleted once the policy of the project is applied to the
+// authorization backend.
+type AuthorizationSync struct {
+	ID        ID                      `json:"id"`
+	ProjectID ID                      `json:"project_id"`
+	Action    AuthorizationSyncAction `json:"action"`
+	Status    AuthorizationSyncStatus `json:"status"`
+	Attempts  int                     `json:"attempts"`
+	// LastError is the error of the last failed attempt
+	LastError     string    `json:"last_error,omitempty"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	// ClaimedUntil is the end of the lease of the worker applying the sync, if it is being applied
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
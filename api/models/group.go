@@ -0,0 +1,33 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// GroupSubjectPrefix prefixes the name of a group granted access to a project as an administrator or a reader
+const GroupSubjectPrefix = "group:"
+
+// Group is a set of users that can be granted access to projects as a whole, e.g. the members of a team
+type Group struct {
+	ID          ID             `json:"id"`
+	Name        string         `json:"name" validate:"required,min=3,max=64,subdomain_rfc1123"`
+	Description string         `json:"description"`
+	Members     pq.StringArray `json:"members" gorm:"column:members;type:varchar(256)[]"`
+	Owners      pq.StringArray `json:"owners" gorm:"column:owners;type:varchar(256)[]"`
+	CreatedUpdated
+}
+
+// GroupSubject returns the subject that grants access to the members of the group, e.g. group:credit-risk
+func GroupSubject(name string) string {
+	return GroupSubjectPrefix + name
+}
+
+// ParseGroupSubject returns the name of the group if the subject refers to a group
+func ParseGroupSubject(subject string) (string, bool) {
+	if !strings.HasPrefix(subject, GroupSubjectPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(subject, GroupSubjectPrefix), true
+}
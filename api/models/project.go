@@ -4,8 +4,10 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
 )
 
 type Project struct {
@@ -15,12 +17,121 @@ type Project struct {
 	MLFlowTrackingURL string         `json:"mlflow_tracking_url" gorm:"column:mlflow_tracking_url" validate:"omitempty,url"`
 	Administrators    pq.StringArray `json:"administrators" gorm:"column:administrators;type:varchar(256)[]"`
 	Readers           pq.StringArray `json:"readers" gorm:"column:readers;type:varchar(256)[]"`
+	SecretReaders     pq.StringArray `json:"secret_readers" gorm:"column:secret_readers;type:varchar(256)[]"`
 	Team              string         `json:"team" validate:"required,min=1,max=64"`
 	Stream            string         `json:"stream" validate:"required,min=1,max=64"`
 	Labels            Labels         `json:"labels,omitempty" gorm:"column:labels"`
+	// MemberExpiries are the times at which the roles granted to some of the administrators and readers expire
+	// nolint:lll // Next line is 121 characters (lll)
+	MemberExpiries ProjectMemberExpiries `json:"member_expiries,omitempty" gorm:"column:member_expiries" validate:"dive"`
+	// ArchivedAt is the time the project was archived. Archived projects are hidden from project listing.
+	ArchivedAt *time.Time `json:"archived_at,omitempty" gorm:"column:archived_at"`
+	// Version is incremented on every update and is used to detect concurrent modifications of the project.
+	// It is exposed to API clients as the ETag of the project.
+	Version int `json:"-" gorm:"column:version"`
 	CreatedUpdated
 }
 
+// IsArchived returns true if the project has been archived
+func (p *Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// ProjectRole is a role that can be granted to a member of a project
+type ProjectRole string
+
+const (
+	ProjectReaderRole        ProjectRole = "reader"
+	ProjectAdministratorRole ProjectRole = "administrator"
+)
+
+// HasRole returns true if the member has been granted the role in the project. Administrators are considered to also
+// have the reader role.
+func (p *Project) HasRole(member string, role ProjectRole) bool {
+	if slices.Contains(p.Administrators, member) {
+		return true
+	}
+	return role == ProjectReaderRole && slices.Contains(p.Readers, member)
+}
+
+// RevokeMemberships removes the members of the given memberships from the administrators or the readers of the
+// project, together with the expiries of the memberships
+func (p *Project) RevokeMemberships(memberships ProjectMemberExpiries) {
+	for _, membership := range memberships {
+		switch membership.Role {
+		case ProjectAdministratorRole:
+			p.Administrators = removeMember(p.Administrators, membership.Member)
+		case ProjectReaderRole:
+			p.Readers = removeMember(p.Readers, membership.Member)
+		}
+	}
+	p.PruneMemberExpiries()
+}
+
+// PruneMemberExpiries removes the expiries of the members that are no longer granted the role of the expiry
+func (p *Project) PruneMemberExpiries() {
+	if p.MemberExpiries == nil {
+		return
+	}
+	expiries := ProjectMemberExpiries{}
+	for _, expiry := range p.MemberExpiries {
+		granted := p.Readers
+		if expiry.Role == ProjectAdministratorRole {
+			granted = p.Administrators
+		}
+		if slices.Contains(granted, expiry.Member) {
+			expiries = append(expiries, expiry)
+		}
+	}
+	p.MemberExpiries = expiries
+}
+
+func removeMember(members pq.StringArray, member string) pq.StringArray {
+	remaining := make(pq.StringArray, 0, len(members))
+	for _, m := range members {
+		if m != member {
+			remaining = append(remaining, m)
+		}
+	}
+	return remaining
+}
+
+// ProjectMemberExpiry is the time at which the role granted to a member of a project expires
+type ProjectMemberExpiry struct {
+	Member    string      `json:"member" validate:"required"`
+	Role      ProjectRole `json:"role" validate:"required,oneof=reader administrator"`
+	ExpiresAt time.Time   `json:"expires_at" validate:"required"`
+}
+
+type ProjectMemberExpiries []ProjectMemberExpiry
+
+// Expired returns the memberships that have expired at the given time
+func (expiries ProjectMemberExpiries) Expired(now time.Time) ProjectMemberExpiries {
+	expired := ProjectMemberExpiries{}
+	for _, expiry := range expiries {
+		if !expiry.ExpiresAt.After(now) {
+			expired = append(expired, expiry)
+		}
+	}
+	return expired
+}
+
+func (expiries ProjectMemberExpiries) Value() (driver.Value, error) {
+	if expiries == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(expiries)
+}
+
+func (expiries *ProjectMemberExpiries) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &expiries)
+}
+
 type Labels []Label
 
 type Label struct {
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// AccessRequestStatus is the state of a project access request
+type AccessRequestStatus string
+
+const (
+	AccessRequestPending  AccessRequestStatus = "pending"
+	AccessRequestApproved AccessRequestStatus = "approved"
+	AccessRequestDenied   AccessRequestStatus = "denied"
+)
+
+// ProjectAccessRequest is the request of a user to be granted a role in a project. It is reviewed by the
+// administrators of the project.
+type ProjectAccessRequest struct {
+	ID        ID                  `json:"id"`
+	ProjectID ID                  `json:"project_id"`
+	Requester string              `json:"requester"`
+	Role      ProjectRole         `json:"role" validate:"required,oneof=reader administrator"`
+	Reason    string              `json:"reason"`
+	Status    AccessRequestStatus `json:"status"`
+	// Reviewer is the email of the administrator who approved or denied the request
+	Reviewer      string     `json:"reviewer"`
+	ReviewComment string     `json:"review_comment"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedUpdated
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ProjectAuditAction is the kind of change made to a project
+type ProjectAuditAction string
+
+const (
+	ProjectCreatedAction    ProjectAuditAction = "created"
+	ProjectUpdatedAction    ProjectAuditAction = "updated"
+	ProjectArchivedAction   ProjectAuditAction = "archived"
+	ProjectUnarchivedAction ProjectAuditAction = "unarchived"
+	ProjectDeletedAction    ProjectAuditAction = "deleted"
+	ProjectRenamedAction    ProjectAuditAction = "renamed"
+	// ProjectMembersExpiredAction is recorded when the expired members of the project are removed
+	ProjectMembersExpiredAction ProjectAuditAction = "members_expired"
+)
+
+// ProjectAuditLog records a single change made to a project, who made it and as part of which request
+type ProjectAuditLog struct {
+	ID        ID                 `json:"id"`
+	ProjectID ID                 `json:"project_id"`
+	Action    ProjectAuditAction `json:"action"`
+	// Actor is the email of the user who made the change
+	Actor     string         `json:"actor"`
+	RequestID string         `json:"request_id"`
+	Changes   ProjectChanges `json:"changes" gorm:"column:changes"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// ProjectChanges are the changed fields of a project, keyed by the JSON name of the field
+type ProjectChanges map[string]ProjectChange
+
+// ProjectChange is the value of a project field before and after it was changed. A nil value means the field was
+// unset.
+type ProjectChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+func (changes ProjectChanges) Value() (driver.Value, error) {
+	return json.Marshal(changes)
+}
+
+func (changes *ProjectChanges) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &changes)
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// ProjectBundleVersion is the version of the project bundle format written by the export command
+const ProjectBundleVersion = "v1"
+
+// ProjectBundle is a serialisable snapshot of projects and their resources, used to promote projects between
+// environments and to restore them after a database loss. Bundles only reference resources by name, so that they can
+// be applied to an environment in which the IDs differ.
+type ProjectBundle struct {
+	// Version is the version of the bundle format
+	Version string `json:"version"`
+	// ExportedAt is the time the bundle was exported
+	ExportedAt time.Time `json:"exported_at"`
+	// Projects are the exported projects
+	Projects []*BundledProject `json:"projects"`
+}
+
+// BundledProject is a project together with its memberships, labels, project-scoped secret storages and secrets. The
+// MLflow tracking URL is specific to an environment and is therefore not part of the bundle.
+type BundledProject struct {
+	Name           string   `json:"name"`
+	Administrators []string `json:"administrators,omitempty"`
+	Readers        []string `json:"readers,omitempty"`
+	// SecretReaders are the members that can read the secrets of the project without being administrators
+	SecretReaders []string `json:"secret_readers,omitempty"`
+	// MemberExpiries are the times at which the memberships of some of the administrators and readers expire
+	MemberExpiries ProjectMemberExpiries `json:"member_expiries,omitempty"`
+	Team           string                `json:"team"`
+	Stream         string                `json:"stream"`
+	Labels         Labels                `json:"labels,omitempty"`
+	ArchivedAt     *time.Time            `json:"archived_at,omitempty"`
+	// SecretStorages are the secret storages owned by the project. Global secret storages are not exported.
+	SecretStorages []*BundledSecretStorage `json:"secret_storages,omitempty"`
+	// Secrets are only exported when requested
+	Secrets []*BundledSecret `json:"secrets,omitempty"`
+}
+
+// BundledSecretStorage is a project-scoped secret storage
+type BundledSecretStorage struct {
+	Name string            `json:"name"`
+	Type SecretStorageType `json:"type"`
+	// Config is the configuration of the secret storage without its credentials, such as the Vault token. The
+	// credentials of an existing secret storage are kept when the bundle is imported.
+	Config SecretStorageConfig `json:"config"`
+}
+
+// BundledSecret is a secret of a project
+type BundledSecret struct {
+	Name string `json:"name"`
+	// SecretStorage is the name of the secret storage of the secret, which is either a global secret storage or one
+	// of the secret storages of the project
+	SecretStorage string `json:"secret_storage"`
+	Data          string `json:"data"`
+	// Encrypted is true if the data has been encrypted with the key provided during the export
+	Encrypted bool `json:"encrypted,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// ProjectManifest is the declarative definition of a project, kept in version control and applied by the reconcile
+// command. Only the fields that describe the ownership of and the access to the project are managed by manifests.
+type ProjectManifest struct {
+	Name           string   `json:"name"`
+	Team           string   `json:"team"`
+	Stream         string   `json:"stream"`
+	Administrators []string `json:"administrators,omitempty"`
+	Readers        []string `json:"readers,omitempty"`
+	Labels         Labels   `json:"labels,omitempty"`
+}
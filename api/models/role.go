@@ -0,0 +1,34 @@
+package models
+
+import "github.com/lib/pq"
+
+// Role is a custom role defined by the MLP administrators, e.g. a deployer that sits between the readers and the
+// administrators of a project. It grants its permissions on a project to the members it is assigned to in the project.
+type Role struct {
+	ID          ID     `json:"id"`
+	Name        string `json:"name" validate:"required,min=3,max=64,subdomain_rfc1123"`
+	Description string `json:"description"`
+	// Permissions are the templates of the permissions granted by the role, which are expanded with the ID of the
+	// project in which the role is assigned, e.g. mlp.projects.{{ .ProjectId }}.get
+	Permissions pq.StringArray `json:"permissions" gorm:"column:permissions;type:varchar(256)[]" validate:"required,min=1"`
+	// ProjectID restricts the role to a single project. Roles without a project can be assigned in any project.
+	ProjectID *ID `json:"project_id,omitempty"`
+	CreatedUpdated
+}
+
+// IsAssignableIn returns true if the role can be assigned in the project
+func (r *Role) IsAssignableIn(projectID ID) bool {
+	return r.ProjectID == nil || *r.ProjectID == projectID
+}
+
+// RoleAssignment grants a custom role to users, groups and service accounts in a project
+type RoleAssignment struct {
+	ID        ID    `json:"id"`
+	RoleID    ID    `json:"role_id"`
+	Role      *Role `json:"role,omitempty" gorm:"foreignkey:RoleID"`
+	ProjectID ID    `json:"project_id"`
+	// Members are the users, the group subjects, e.g. group:credit-risk, and the service account subjects granted the
+	// role
+	Members pq.StringArray `json:"members" gorm:"column:members;type:varchar(256)[]" validate:"required,min=1"`
+	CreatedUpdated
+}
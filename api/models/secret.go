@@ -14,6 +14,8 @@ type Secret struct {
 	Name string `json:"name"`
 	// Data is secret value
 	Data string `json:"data"`
+	// Redacted is true if the value of the secret has been removed because the user is not allowed to read it
+	Redacted bool `json:"redacted,omitempty" gorm:"-"`
 	// SecretStorageID is the unique identifier of the secret storage for storing the secret
 	SecretStorageID *ID `json:"secret_storage_id,omitempty"`
 	// SecretStorage is the secret storage for storing the secret
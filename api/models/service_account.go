@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ServiceAccountSubjectPrefix is the prefix of the subjects of service accounts, e.g. serviceaccount:12. A service
+	// account is granted a role in a project by adding its subject to the administrators or the readers of the project.
+	ServiceAccountSubjectPrefix = "serviceaccount:"
+	// ServiceAccountTokenPrefix is the prefix of the tokens of service accounts, which distinguishes them from the
+	// other bearer tokens
+	ServiceAccountTokenPrefix = "mlp_"
+)
+
+// ServiceAccount is a machine identity owned by a project, e.g. used by CI pipelines, which authenticates with
+// bearer tokens
+type ServiceAccount struct {
+	ID          ID     `json:"id"`
+	ProjectID   ID     `json:"project_id"`
+	Name        string `json:"name" validate:"required,min=3,max=64,subdomain_rfc1123"`
+	Description string `json:"description"`
+	// Subject identifies the service account in requests and in the members of projects
+	Subject string `json:"subject" gorm:"-"`
+	// CreatedBy is the email of the user who created the service account
+	CreatedBy string `json:"created_by"`
+	CreatedUpdated
+}
+
+// AfterFind sets the subject of the service account when it is read from the database
+func (sa *ServiceAccount) AfterFind() error {
+	sa.Subject = ServiceAccountSubject(sa.ID)
+	return nil
+}
+
+// AfterSave sets the subject of the service account once it has an ID
+func (sa *ServiceAccount) AfterSave() error {
+	sa.Subject = ServiceAccountSubject(sa.ID)
+	return nil
+}
+
+// ServiceAccountSubject returns the subject of the service account with the given ID
+func ServiceAccountSubject(id ID) string {
+	return ServiceAccountSubjectPrefix + id.String()
+}
+
+// ParseServiceAccountSubject returns the ID of the service account if the subject is a service account subject
+func ParseServiceAccountSubject(subject string) (ID, bool) {
+	if !strings.HasPrefix(subject, ServiceAccountSubjectPrefix) {
+		return 0, false
+	}
+	id, err := ParseID(strings.TrimPrefix(subject, ServiceAccountSubjectPrefix))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// ServiceAccountToken is a bearer token of a service account. Only the hash of the token is stored.
+type ServiceAccountToken struct {
+	ID               ID     `json:"id"`
+	ServiceAccountID ID     `json:"service_account_id"`
+	Name             string `json:"name" validate:"max=64"`
+	TokenHash        string `json:"-"`
+	// ExpiresAt defaults to the default lifetime of tokens when a token is created without it
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	// Token is the bearer token, which is only returned when the token is created
+	Token string `json:"token,omitempty" gorm:"-"`
+}
+
+// IsActive returns true if the token has neither been revoked nor expired at the given time
+func (t *ServiceAccountToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && t.ExpiresAt != nil && t.ExpiresAt.After(now)
+}
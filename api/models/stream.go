@@ -0,0 +1,22 @@
+package models
+
+import "github.com/lib/pq"
+
+// Stream is a group of teams, e.g. a business unit. Projects belong to a stream and to one of its teams.
+type Stream struct {
+	ID          ID             `json:"id"`
+	Name        string         `json:"name" validate:"required,min=1,max=64"`
+	Description string         `json:"description"`
+	Owners      pq.StringArray `json:"owners" gorm:"column:owners;type:varchar(256)[]"`
+	CreatedUpdated
+}
+
+// Team is a team of a stream
+type Team struct {
+	ID          ID             `json:"id"`
+	StreamID    ID             `json:"stream_id" validate:"required"`
+	Name        string         `json:"name" validate:"required,min=1,max=64"`
+	Description string         `json:"description"`
+	Owners      pq.StringArray `json:"owners" gorm:"column:owners;type:varchar(256)[]"`
+	CreatedUpdated
+}
@@ -0,0 +1,124 @@
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/caraml-dev/mlp/api/log"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+// minRefreshInterval limits how often a token signed by an unknown key triggers a refresh of the key set
+const minRefreshInterval = time.Minute
+
+// keySet is the JSON web key set of the issuer, which is read from a URL or a local file and cached. The key set is
+// refreshed when it is older than the refresh interval, or when a token is signed by an unknown key, so that the keys
+// rotated by the issuer are picked up.
+type keySet struct {
+	url             string
+	file            string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+	now             func() time.Time
+
+	mu          sync.Mutex
+	keys        *jose.JSONWebKeySet
+	refreshedAt time.Time
+}
+
+func newKeySet(url string, file string, refreshInterval time.Duration) (*keySet, error) {
+	if url == "" && file == "" {
+		return nil, fmt.Errorf("either the URL or the file of the JSON web key set must be given")
+	}
+	return &keySet{
+		url:             url,
+		file:            file,
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+		now:             time.Now,
+	}, nil
+}
+
+// Key returns the public key with the given key ID, or an unauthenticated error if the issuer has no such key
+func (s *keySet) Key(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.keys == nil {
+		if err := s.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+	} else if s.refreshInterval > 0 && now.Sub(s.refreshedAt) >= s.refreshInterval {
+		// the cached keys are kept if the issuer is unavailable, and the refresh is retried after the interval
+		if err := s.refresh(ctx, now); err != nil {
+			log.Warnf("%s, using the cached keys", err)
+			s.refreshedAt = now
+		}
+	}
+	if key := s.find(kid); key != nil {
+		return key, nil
+	}
+
+	// the issuer may have rotated its keys since the last refresh
+	if now.Sub(s.refreshedAt) >= minRefreshInterval {
+		if err := s.refresh(ctx, now); err != nil {
+			return nil, err
+		}
+		if key := s.find(kid); key != nil {
+			return key, nil
+		}
+	}
+	return nil, apperrors.NewUnauthenticatedErrorf("token is signed by an unknown key %q", kid)
+}
+
+func (s *keySet) find(kid string) *jose.JSONWebKey {
+	for _, key := range s.keys.Key(kid) {
+		if key.IsPublic() {
+			return &key
+		}
+	}
+	return nil
+}
+
+func (s *keySet) refresh(ctx context.Context, now time.Time) error {
+	data, err := s.read(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading JSON web key set: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("error parsing JSON web key set: %w", err)
+	}
+	s.keys = &keys
+	s.refreshedAt = now
+	return nil
+}
+
+func (s *keySet) read(ctx context.Context) ([]byte, error) {
+	if s.file != "" {
+		return os.ReadFile(s.file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, s.url)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,98 @@
+// Package authn verifies the identity of the callers of the API
+package authn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+// clockSkew is the tolerated difference between the clocks of the issuer and the API when validating the times of a
+// token
+const clockSkew = time.Minute
+
+// VerifierConfig configures the verification of OIDC tokens
+type VerifierConfig struct {
+	// Issuer is the expected iss claim of the tokens
+	Issuer string
+	// Audience is the audience that the tokens must be issued for
+	Audience string
+	// JWKSURL is the URL of the JSON web key set of the issuer
+	JWKSURL string
+	// JWKSFile is a local JSON web key set used instead of JWKSURL
+	JWKSFile string
+	// JWKSRefreshInterval is the maximum age of the cached key set
+	JWKSRefreshInterval time.Duration
+	// UserClaim is the claim that identifies the user, e.g. email
+	UserClaim string
+}
+
+// Verifier verifies OIDC tokens signed by the keys of the issuer and returns the user that they identify
+type Verifier struct {
+	issuer    string
+	audience  string
+	userClaim string
+	keys      *keySet
+	now       func() time.Time
+}
+
+func NewVerifier(cfg VerifierConfig) (*Verifier, error) {
+	if cfg.Issuer == "" || cfg.Audience == "" || cfg.UserClaim == "" {
+		return nil, fmt.Errorf("the issuer, the audience and the user claim of the tokens must be given")
+	}
+	keys, err := newKeySet(cfg.JWKSURL, cfg.JWKSFile, cfg.JWKSRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{
+		issuer:    cfg.Issuer,
+		audience:  cfg.Audience,
+		userClaim: cfg.UserClaim,
+		keys:      keys,
+		now:       time.Now,
+	}, nil
+}
+
+// Verify checks the signature, the issuer, the audience and the validity period of the token, and returns the value
+// of its user claim. Invalid tokens return an unauthenticated error.
+func (v *Verifier) Verify(ctx context.Context, rawToken string) (string, error) {
+	token, err := jwt.ParseSigned(rawToken)
+	if err != nil {
+		return "", apperrors.NewUnauthenticatedErrorf("malformed token: %s", err)
+	}
+	if len(token.Headers) != 1 {
+		return "", apperrors.NewUnauthenticatedErrorf("token must have exactly one signature")
+	}
+
+	key, err := v.keys.Key(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return "", err
+	}
+
+	var claims jwt.Claims
+	var custom map[string]interface{}
+	if err := token.Claims(key, &claims, &custom); err != nil {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid token: %s", err)
+	}
+	expected := jwt.Expected{
+		Issuer:   v.issuer,
+		Audience: jwt.Audience{v.audience},
+		Time:     v.now(),
+	}
+	if err := claims.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid token: %s", err)
+	}
+	if claims.Expiry == nil {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid token: token has no expiry")
+	}
+
+	user, ok := custom[v.userClaim].(string)
+	if !ok || user == "" {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid token: missing %s claim", v.userClaim)
+	}
+	return user, nil
+}
@@ -0,0 +1,190 @@
+package authn
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "mlp"
+)
+
+type testKey struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+func newTestKey(t *testing.T, kid string) testKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testKey{kid: kid, key: key}
+}
+
+// writeJWKS writes the public keys to a JSON web key set file
+func writeJWKS(t *testing.T, path string, keys ...testKey) {
+	var keySet jose.JSONWebKeySet
+	for _, k := range keys {
+		keySet.Keys = append(keySet.Keys, jose.JSONWebKey{
+			Key:       &k.key.PublicKey,
+			KeyID:     k.kid,
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		})
+	}
+	data, err := json.Marshal(keySet)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func signToken(t *testing.T, k testKey, claims jwt.Claims, custom map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: k.key},
+		(&jose.SignerOptions{}).WithHeader("kid", k.kid))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).Claims(claims).Claims(custom).CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	key := newTestKey(t, "key-1")
+	otherKey := newTestKey(t, "key-1")
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, key)
+
+	validClaims := jwt.Claims{
+		Issuer:   testIssuer,
+		Audience: jwt.Audience{testAudience},
+		Subject:  "1234",
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	email := map[string]interface{}{"email": "user@example.com"}
+	withClaims := func(update func(c *jwt.Claims)) jwt.Claims {
+		c := validClaims
+		update(&c)
+		return c
+	}
+
+	tests := map[string]struct {
+		token        string
+		expectedUser string
+		expectedErr  string
+	}{
+		"valid token": {
+			token:        signToken(t, key, validClaims, email),
+			expectedUser: "user@example.com",
+		},
+		"other issuer": {
+			token:       signToken(t, key, withClaims(func(c *jwt.Claims) { c.Issuer = "https://other" }), email),
+			expectedErr: "invalid token: square/go-jose/jwt: validation failed, invalid issuer claim (iss)",
+		},
+		"other audience": {
+			token:       signToken(t, key, withClaims(func(c *jwt.Claims) { c.Audience = jwt.Audience{"other"} }), email),
+			expectedErr: "invalid token: square/go-jose/jwt: validation failed, invalid audience claim (aud)",
+		},
+		"expired token": {
+			token: signToken(t, key, withClaims(func(c *jwt.Claims) {
+				c.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+			}), email),
+			expectedErr: "invalid token: square/go-jose/jwt: validation failed, token is expired (exp)",
+		},
+		"token without expiry": {
+			token:       signToken(t, key, withClaims(func(c *jwt.Claims) { c.Expiry = nil }), email),
+			expectedErr: "invalid token: token has no expiry",
+		},
+		"missing user claim": {
+			token:       signToken(t, key, validClaims, map[string]interface{}{"name": "User"}),
+			expectedErr: "invalid token: missing email claim",
+		},
+		"forged signature": {
+			token:       signToken(t, otherKey, validClaims, email),
+			expectedErr: "invalid token: square/go-jose: error in cryptographic primitive",
+		},
+		"malformed token": {
+			token:       "not-a-token",
+			expectedErr: "malformed token: square/go-jose: compact JWS format must have three parts",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			verifier, err := NewVerifier(VerifierConfig{
+				Issuer:    testIssuer,
+				Audience:  testAudience,
+				JWKSFile:  jwksFile,
+				UserClaim: "email",
+			})
+			require.NoError(t, err)
+			verifier.now = func() time.Time { return now }
+
+			user, err := verifier.Verify(context.Background(), tt.token)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.True(t, errors.Is(err, &apperrors.UnauthenticatedError{}))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedUser, user)
+		})
+	}
+}
+
+func TestVerifier_KeyRotation(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	oldKey, newKey := newTestKey(t, "key-1"), newTestKey(t, "key-2")
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, oldKey)
+
+	verifier, err := NewVerifier(VerifierConfig{
+		Issuer:              testIssuer,
+		Audience:            testAudience,
+		JWKSFile:            jwksFile,
+		JWKSRefreshInterval: time.Hour,
+		UserClaim:           "email",
+	})
+	require.NoError(t, err)
+	verifier.now = func() time.Time { return now }
+	verifier.keys.now = func() time.Time { return now }
+	claims := jwt.Claims{
+		Issuer:   testIssuer,
+		Audience: jwt.Audience{testAudience},
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	email := map[string]interface{}{"email": "user@example.com"}
+
+	_, err = verifier.Verify(context.Background(), signToken(t, oldKey, claims, email))
+	require.NoError(t, err)
+
+	// the issuer rotates its keys, the unknown key is only looked up once the key set is old enough
+	writeJWKS(t, jwksFile, newKey)
+	newToken := signToken(t, newKey, claims, email)
+	_, err = verifier.Verify(context.Background(), newToken)
+	assert.EqualError(t, err, `token is signed by an unknown key "key-2"`)
+
+	verifier.keys.now = func() time.Time { return now.Add(minRefreshInterval) }
+	user, err := verifier.Verify(context.Background(), newToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", user)
+
+	// the cached keys are used if the key set cannot be refreshed
+	require.NoError(t, os.Remove(jwksFile))
+	verifier.keys.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = verifier.Verify(context.Background(), newToken)
+	require.NoError(t, err)
+}
@@ -1,10 +1,15 @@
 package enforcer
 
+import "database/sql"
+
 // Builder builder of enforcer.Enforcer
 type Builder struct {
+	db              *sql.DB
 	ketoRemoteRead  string
 	ketoRemoteWrite string
 	cacheConfig     *CacheConfig
+	broadcaster     CacheInvalidationBroadcaster
+	decisionSink    DecisionSink
 }
 
 const (
@@ -29,6 +34,13 @@ func (b *Builder) KetoEndpoints(ketoRemoteRead string, ketoRemoteWrite string) *
 	return b
 }
 
+// Database stores the authorization relations in the database instead of Keto, in which case the Keto endpoints
+// and the caching are ignored
+func (b *Builder) Database(db *sql.DB) *Builder {
+	b.db = db
+	return b
+}
+
 func (b *Builder) WithCaching(keyExpirySeconds int, cacheCleanUpIntervalSeconds int) *Builder {
 	b.cacheConfig = &CacheConfig{
 		KeyExpirySeconds:            keyExpirySeconds,
@@ -37,7 +49,39 @@ func (b *Builder) WithCaching(keyExpirySeconds int, cacheCleanUpIntervalSeconds
 	return b
 }
 
+// WithCacheInvalidationBroadcaster propagates the invalidations of the cache to the other replicas. It has no effect
+// if the caching is not enabled.
+func (b *Builder) WithCacheInvalidationBroadcaster(broadcaster CacheInvalidationBroadcaster) *Builder {
+	b.broadcaster = broadcaster
+	return b
+}
+
+// WithDecisionLog writes the decision of each permission check to the sink
+func (b *Builder) WithDecisionLog(sink DecisionSink) *Builder {
+	b.decisionSink = sink
+	return b
+}
+
 // Build build an enforcer.Enforcer instance
 func (b *Builder) Build() (Enforcer, error) {
-	return newEnforcer(b.ketoRemoteRead, b.ketoRemoteWrite, b.cacheConfig)
+	enforcer, err := b.build()
+	if err != nil || b.decisionSink == nil {
+		return enforcer, err
+	}
+	return newDecisionLoggingEnforcer(enforcer, b.decisionSink), nil
+}
+
+func (b *Builder) build() (Enforcer, error) {
+	if b.db != nil {
+		return newDatabaseEnforcer(b.db), nil
+	}
+	cacheConfig := b.cacheConfig
+	if cacheConfig != nil {
+		cacheConfig = &CacheConfig{
+			KeyExpirySeconds:            b.cacheConfig.KeyExpirySeconds,
+			CacheCleanUpIntervalSeconds: b.cacheConfig.CacheCleanUpIntervalSeconds,
+			Broadcaster:                 b.broadcaster,
+		}
+	}
+	return newEnforcer(b.ketoRemoteRead, b.ketoRemoteWrite, cacheConfig)
 }
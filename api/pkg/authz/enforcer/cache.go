@@ -11,6 +11,29 @@ type InMemoryCache struct {
 	store *cache.Cache
 }
 
+// cachedPermission is the permission check result of a user, stored with the user and the permission so that the
+// entries can be invalidated by user or by permission
+type cachedPermission struct {
+	user       string
+	permission string
+	allowed    bool
+}
+
+// CacheInvalidation identifies the cached permission check results which are stale after an authorization update
+type CacheInvalidation struct {
+	// All invalidates all the results
+	All bool `json:"all,omitempty"`
+	// Users invalidates the results of these users, whatever the permission
+	Users []string `json:"users,omitempty"`
+	// Permissions invalidates the results of these permissions, whatever the user
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// IsEmpty returns true if the invalidation does not invalidate any result
+func (i CacheInvalidation) IsEmpty() bool {
+	return !i.All && len(i.Users) == 0 && len(i.Permissions) == 0
+}
+
 func newInMemoryCache(keyExpirySeconds int, cacheCleanUpIntervalSeconds int) *InMemoryCache {
 	return &InMemoryCache{
 		store: cache.New(
@@ -24,8 +47,9 @@ func newInMemoryCache(keyExpirySeconds int, cacheCleanUpIntervalSeconds int) *In
 // The returned value indicates whether the result is cached.
 func (c *InMemoryCache) LookUpUserPermission(user string, permission string) (*bool, bool) {
 	if cachedValue, ok := c.store.Get(c.buildCacheKey(user, permission)); ok {
-		if allowed, ok := cachedValue.(*bool); ok {
-			return allowed, true
+		if cached, ok := cachedValue.(cachedPermission); ok {
+			allowed := cached.allowed
+			return &allowed, true
 		}
 	}
 	return nil, false
@@ -33,7 +57,33 @@ func (c *InMemoryCache) LookUpUserPermission(user string, permission string) (*b
 
 // StoreUserPermission stores the permission check result for a user / permission pair.
 func (c *InMemoryCache) StoreUserPermission(user string, permission string, result bool) {
-	c.store.Set(c.buildCacheKey(user, permission), &result, cache.DefaultExpiration)
+	c.store.Set(c.buildCacheKey(user, permission),
+		cachedPermission{user: user, permission: permission, allowed: result}, cache.DefaultExpiration)
+}
+
+// Invalidate deletes the cached permission check results matching the invalidation
+func (c *InMemoryCache) Invalidate(invalidation CacheInvalidation) {
+	if invalidation.All {
+		c.store.Flush()
+		return
+	}
+	if invalidation.IsEmpty() {
+		return
+	}
+
+	users := make(map[string]bool, len(invalidation.Users))
+	for _, user := range invalidation.Users {
+		users[user] = true
+	}
+	permissions := make(map[string]bool, len(invalidation.Permissions))
+	for _, permission := range invalidation.Permissions {
+		permissions[permission] = true
+	}
+	for key, item := range c.store.Items() {
+		if cached, ok := item.Object.(cachedPermission); ok && (users[cached.user] || permissions[cached.permission]) {
+			c.store.Delete(key)
+		}
+	}
 }
 
 func (c *InMemoryCache) buildCacheKey(user string, permission string) string {
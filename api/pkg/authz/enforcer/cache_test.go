@@ -51,3 +51,47 @@ func TestInMemoryCache_LookUpUserPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestInMemoryCache_Invalidate(t *testing.T) {
+	tests := map[string]struct {
+		invalidation     CacheInvalidation
+		expectedRemained []string
+	}{
+		"invalidate users": {
+			invalidation:     CacheInvalidation{Users: []string{"user1@email.com"}},
+			expectedRemained: []string{"user2@email.com-mlp.projects.1.get"},
+		},
+		"invalidate permissions": {
+			invalidation:     CacheInvalidation{Permissions: []string{"mlp.projects.1.get"}},
+			expectedRemained: []string{"user1@email.com-mlp.projects.1.post"},
+		},
+		"invalidate all": {
+			invalidation:     CacheInvalidation{All: true},
+			expectedRemained: []string{},
+		},
+		"invalidate nothing": {
+			invalidation: CacheInvalidation{},
+			expectedRemained: []string{
+				"user1@email.com-mlp.projects.1.get",
+				"user1@email.com-mlp.projects.1.post",
+				"user2@email.com-mlp.projects.1.get",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := newInMemoryCache(600, 600)
+			cache.StoreUserPermission("user1@email.com", "mlp.projects.1.get", true)
+			cache.StoreUserPermission("user1@email.com", "mlp.projects.1.post", false)
+			cache.StoreUserPermission("user2@email.com", "mlp.projects.1.get", true)
+
+			cache.Invalidate(tt.invalidation)
+			remained := make([]string, 0)
+			for key := range cache.store.Items() {
+				remained = append(remained, key)
+			}
+			assert.ElementsMatch(t, tt.expectedRemained, remained)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package enforcer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+// databaseEnforcer is the alternative to the Keto enforcer for the installations without Keto. It stores the
+// relations granting the permissions in the tables authorization_role_permissions, authorization_role_members and
+// authorization_group_members of the MLP database, with the same semantic as the Keto namespaces: the permissions are
+// granted to the members of the roles, which are users or groups of users. The permission checks are not cached,
+// since each one is a single query on indexed columns.
+type databaseEnforcer struct {
+	db *sql.DB
+}
+
+func newDatabaseEnforcer(db *sql.DB) *databaseEnforcer {
+	return &databaseEnforcer{db: db}
+}
+
+// userMemberCondition matches the role members granting the roles to the user $1, which are the user and the
+// groups the user is a member of, given the group subject prefix $2
+const userMemberCondition = `(m.member = $1 OR m.member IN (
+		SELECT $2::text || g.group_name FROM authorization_group_members g WHERE g.member = $1
+	))`
+
+func (e *databaseEnforcer) IsUserGrantedPermission(ctx context.Context, user string, permission string) (bool,
+	error) {
+	var allowed bool
+	err := e.db.QueryRowContext(ctx, `SELECT EXISTS (
+			SELECT 1
+			FROM authorization_role_permissions p
+			JOIN authorization_role_members m ON m.role = p.role
+			WHERE p.permission = $3 AND `+userMemberCondition+`
+		)`, user, models.GroupSubjectPrefix, permission).Scan(&allowed)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+	return allowed, nil
+}
+
+func (e *databaseEnforcer) GetUserRoles(ctx context.Context, user string) ([]string, error) {
+	roles, err := e.queryStrings(ctx, `SELECT DISTINCT m.role
+		FROM authorization_role_members m
+		WHERE `+userMemberCondition+`
+		ORDER BY m.role`, user, models.GroupSubjectPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (e *databaseEnforcer) GetGroupRoles(ctx context.Context, group string) ([]string, error) {
+	roles, err := e.queryStrings(ctx,
+		"SELECT role FROM authorization_role_members WHERE member = $1 ORDER BY role", models.GroupSubject(group))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (e *databaseEnforcer) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
+	return e.queryStrings(ctx,
+		"SELECT permission FROM authorization_role_permissions WHERE role = $1 ORDER BY permission", role)
+}
+
+func (e *databaseEnforcer) GetUserPermissions(ctx context.Context, user string) ([]string, error) {
+	return e.queryStrings(ctx, `SELECT DISTINCT p.permission
+		FROM authorization_role_permissions p
+		JOIN authorization_role_members m ON m.role = p.role
+		WHERE `+userMemberCondition+`
+		ORDER BY p.permission`, user, models.GroupSubjectPrefix)
+}
+
+func (e *databaseEnforcer) GetRoleMembers(ctx context.Context, role string) ([]string, error) {
+	return e.queryStrings(ctx,
+		"SELECT member FROM authorization_role_members WHERE role = $1 ORDER BY member", role)
+}
+
+func (e *databaseEnforcer) ListRoles(ctx context.Context, prefix string) ([]string, error) {
+	roles, err := e.queryStrings(ctx, `SELECT DISTINCT role FROM authorization_role_members
+		WHERE left(role, length($1)) = $1
+		ORDER BY role`, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (e *databaseEnforcer) GetGroupMembers(ctx context.Context, group string) ([]string, error) {
+	members, err := e.queryStrings(ctx,
+		"SELECT member FROM authorization_group_members WHERE group_name = $1 ORDER BY member", group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	return members, nil
+}
+
+func (e *databaseEnforcer) UpdateAuthorization(ctx context.Context, updateRequest AuthorizationUpdateRequest) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := updateAuthorizationRelations(ctx, tx, updateRequest); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to update authorization: %w", err)
+	}
+	return tx.Commit()
+}
+
+// updateAuthorizationRelations applies the update request in the transaction, in the same order as the patches of the
+// Keto enforcer: the permissions are removed from the roles before the new ones are added
+func updateAuthorizationRelations(ctx context.Context, tx *sql.Tx, updateRequest AuthorizationUpdateRequest) error {
+	for role, permissions := range updateRequest.RemovedRolePermissions {
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM authorization_role_permissions WHERE role = $1 AND permission = ANY($2)",
+			role, textArray(permissions)); err != nil {
+			return err
+		}
+	}
+	for role, permissions := range updateRequest.RolePermissions {
+		for _, permission := range permissions {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO authorization_role_permissions (role, permission)
+				VALUES ($1, $2) ON CONFLICT DO NOTHING`, role, permission); err != nil {
+				return err
+			}
+		}
+	}
+	for role, members := range updateRequest.RoleMembers {
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM authorization_role_members WHERE role = $1 AND member <> ALL($2)",
+			role, textArray(members)); err != nil {
+			return err
+		}
+		for _, member := range members {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO authorization_role_members (role, member)
+				VALUES ($1, $2) ON CONFLICT DO NOTHING`, role, member); err != nil {
+				return err
+			}
+		}
+	}
+	for group, members := range updateRequest.GroupMembers {
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM authorization_group_members WHERE group_name = $1 AND member <> ALL($2)",
+			group, textArray(members)); err != nil {
+			return err
+		}
+		for _, member := range members {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO authorization_group_members (group_name, member)
+				VALUES ($1, $2) ON CONFLICT DO NOTHING`, group, member); err != nil {
+				return err
+			}
+		}
+	}
+	for role, members := range updateRequest.RemovedRoleMembers {
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM authorization_role_members WHERE role = $1 AND member = ANY($2)",
+			role, textArray(members)); err != nil {
+			return err
+		}
+	}
+	for group, members := range updateRequest.RemovedGroupMembers {
+		if _, err := tx.ExecContext(ctx,
+			"DELETE FROM authorization_group_members WHERE group_name = $1 AND member = ANY($2)",
+			group, textArray(members)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// textArray converts the values to a text array parameter. A nil slice is converted to an empty array rather than
+// NULL, since comparing with ALL(NULL) never matches any row.
+func textArray(values []string) interface{} {
+	if values == nil {
+		values = []string{}
+	}
+	return pq.Array(values)
+}
+
+func (e *databaseEnforcer) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
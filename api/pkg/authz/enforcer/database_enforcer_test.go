@@ -0,0 +1,128 @@
+//go:build integration
+
+package enforcer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+)
+
+func TestDatabaseEnforcer(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		dbEnforcer, err := NewEnforcerBuilder().Database(db.DB()).Build()
+		require.NoError(t, err)
+
+		updateRequest := NewAuthorizationUpdateRequest()
+		updateRequest.AddRolePermissions("pages.1.admin", []string{"pages.1.get", "pages.1.put"})
+		updateRequest.SetRoleMembers("pages.1.admin", []string{"owner@example.com", "group:editors"})
+		updateRequest.SetGroupMembers("editors", []string{"user-1@example.com", "user-2@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+
+		tests := []struct {
+			user       string
+			permission string
+			allowed    bool
+		}{
+			{"owner@example.com", "pages.1.put", true},
+			{"user-2@example.com", "pages.1.put", true},
+			{"user-3@example.com", "pages.1.put", false},
+			{"owner@example.com", "pages.2.put", false},
+		}
+		for _, tt := range tests {
+			allowed, err := dbEnforcer.IsUserGrantedPermission(ctx, tt.user, tt.permission)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, allowed, "%s %s", tt.user, tt.permission)
+		}
+
+		members, err := dbEnforcer.GetRoleMembers(ctx, "pages.1.admin")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"group:editors", "owner@example.com"}, members)
+		roles, err := dbEnforcer.GetUserRoles(ctx, "owner@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.admin"}, roles)
+		roles, err = dbEnforcer.GetGroupRoles(ctx, "editors")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.admin"}, roles)
+		permissions, err := dbEnforcer.GetUserPermissions(ctx, "owner@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.get", "pages.1.put"}, permissions)
+		// the roles and permissions granted to the groups of the user are included
+		roles, err = dbEnforcer.GetUserRoles(ctx, "user-1@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.admin"}, roles)
+		permissions, err = dbEnforcer.GetUserPermissions(ctx, "user-1@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.get", "pages.1.put"}, permissions)
+
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.RemoveRolePermissions("pages.1.admin", []string{"pages.1.put"})
+		updateRequest.AddRolePermissions("pages.1.admin", []string{"pages.1.delete"})
+		updateRequest.SetRoleMembers("pages.1.admin", []string{"group:editors"})
+		updateRequest.SetGroupMembers("editors", []string{"user-1@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+
+		permissions, err = dbEnforcer.GetRolePermissions(ctx, "pages.1.admin")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.delete", "pages.1.get"}, permissions)
+		members, err = dbEnforcer.GetGroupMembers(ctx, "editors")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-1@example.com"}, members)
+		for _, user := range []string{"owner@example.com", "user-2@example.com"} {
+			allowed, err := dbEnforcer.IsUserGrantedPermission(ctx, user, "pages.1.get")
+			require.NoError(t, err)
+			assert.False(t, allowed, user)
+		}
+		allowed, err := dbEnforcer.IsUserGrantedPermission(ctx, "user-1@example.com", "pages.1.delete")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+
+		// only the removed members are removed
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.SetRoleMembers("pages.2.admin", []string{"owner@example.com", "other@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.RemoveRoleMembers("pages.2.admin", []string{"other@example.com", "unknown@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+
+		members, err = dbEnforcer.GetRoleMembers(ctx, "pages.2.admin")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owner@example.com"}, members)
+		roles, err = dbEnforcer.ListRoles(ctx, "pages.")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.1.admin", "pages.2.admin"}, roles)
+		roles, err = dbEnforcer.ListRoles(ctx, "pages.2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pages.2.admin"}, roles)
+
+		// setting no members removes all the members
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.SetRoleMembers("pages.1.admin", nil)
+		updateRequest.SetGroupMembers("editors", nil)
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+
+		members, err = dbEnforcer.GetRoleMembers(ctx, "pages.1.admin")
+		require.NoError(t, err)
+		assert.Empty(t, members)
+		members, err = dbEnforcer.GetGroupMembers(ctx, "editors")
+		require.NoError(t, err)
+		assert.Empty(t, members)
+
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.SetGroupMembers("editors", []string{"user-1@example.com", "user-2@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+		updateRequest = NewAuthorizationUpdateRequest()
+		updateRequest.RemoveGroupMembers("editors", []string{"user-2@example.com"})
+		require.NoError(t, dbEnforcer.UpdateAuthorization(ctx, updateRequest))
+
+		members, err = dbEnforcer.GetGroupMembers(ctx, "editors")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user-1@example.com"}, members)
+	})
+}
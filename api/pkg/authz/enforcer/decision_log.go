@@ -0,0 +1,189 @@
+package enforcer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+const (
+	// decisionBatchSize is the max number of decisions written at once by a batching sink
+	decisionBatchSize = 100
+	// decisionBufferSize is the max number of decisions waiting to be written by a batching sink
+	decisionBufferSize = 10000
+	// decisionFlushInterval is the max time a decision waits in a batching sink before being written
+	decisionFlushInterval = time.Second
+)
+
+// DecisionSink receives the decisions of the permission checks
+type DecisionSink interface {
+	// WriteDecision records the decision. It is called synchronously by each check, which is not failed by the
+	// errors of the sink.
+	WriteDecision(ctx context.Context, decision *models.AuthorizationDecision) error
+}
+
+// DecisionBatchWriter writes a batch of decisions, e.g. in a single database statement
+type DecisionBatchWriter func(decisions []*models.AuthorizationDecision) error
+
+// DecisionSinkFunc adapts a function to a DecisionSink
+type DecisionSinkFunc func(ctx context.Context, decision *models.AuthorizationDecision) error
+
+func (f DecisionSinkFunc) WriteDecision(ctx context.Context, decision *models.AuthorizationDecision) error {
+	return f(ctx, decision)
+}
+
+// jsonDecisionSink writes each decision as a line of JSON, e.g. to stdout or to a file
+type jsonDecisionSink struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONDecisionSink returns a sink writing the decisions to w as JSON lines
+func NewJSONDecisionSink(w io.Writer) DecisionSink {
+	return &jsonDecisionSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *jsonDecisionSink) WriteDecision(_ context.Context, decision *models.AuthorizationDecision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.encoder.Encode(decision)
+}
+
+// BatchingDecisionSink buffers the decisions and writes them in batches from a background goroutine, so that the
+// permission checks do not wait for the decisions to be written. The decisions received while the buffer is full are
+// dropped, and the batches which fail to be written are logged.
+type BatchingDecisionSink struct {
+	write         DecisionBatchWriter
+	decisions     chan *models.AuthorizationDecision
+	batchSize     int
+	flushInterval time.Duration
+	closeOnce     sync.Once
+	done          chan struct{}
+}
+
+// NewBatchingDecisionSink returns a sink writing the decisions with write, in batches of up to 100 decisions and at
+// least every second
+func NewBatchingDecisionSink(write DecisionBatchWriter) *BatchingDecisionSink {
+	return newBatchingDecisionSink(write, decisionBatchSize, decisionBufferSize, decisionFlushInterval)
+}
+
+func newBatchingDecisionSink(write DecisionBatchWriter, batchSize int, bufferSize int,
+	flushInterval time.Duration) *BatchingDecisionSink {
+	s := &BatchingDecisionSink{
+		write:         write,
+		decisions:     make(chan *models.AuthorizationDecision, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *BatchingDecisionSink) WriteDecision(_ context.Context, decision *models.AuthorizationDecision) error {
+	select {
+	case s.decisions <- decision:
+		return nil
+	default:
+		return errors.New("the decision log buffer is full, the decision is dropped")
+	}
+}
+
+// Close writes the buffered decisions and stops the sink. No decision can be written to the sink once it is closed.
+func (s *BatchingDecisionSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.decisions)
+	})
+	<-s.done
+	return nil
+}
+
+func (s *BatchingDecisionSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*models.AuthorizationDecision, 0, s.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.write(batch); err != nil {
+			log.Errorf("error writing %d authorization decisions: %s", len(batch), err)
+		}
+		batch = make([]*models.AuthorizationDecision, 0, s.batchSize)
+	}
+	for {
+		select {
+		case decision, ok := <-s.decisions:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, decision)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// cachingPermissionChecker is implemented by the enforcers which cache the permission checks
+type cachingPermissionChecker interface {
+	// checkPermission returns whether the user has the permission and whether the result was cached
+	checkPermission(ctx context.Context, user string, permission string) (bool, bool, error)
+}
+
+// decisionLoggingEnforcer writes the decision of each permission check to a sink
+type decisionLoggingEnforcer struct {
+	Enforcer
+	sink DecisionSink
+	now  func() time.Time
+}
+
+func newDecisionLoggingEnforcer(enforcer Enforcer, sink DecisionSink) *decisionLoggingEnforcer {
+	return &decisionLoggingEnforcer{
+		Enforcer: enforcer,
+		sink:     sink,
+		now:      time.Now,
+	}
+}
+
+func (e *decisionLoggingEnforcer) IsUserGrantedPermission(ctx context.Context, user string, permission string) (
+	bool, error) {
+	start := e.now()
+	var allowed, cacheHit bool
+	var err error
+	if checker, ok := e.Enforcer.(cachingPermissionChecker); ok {
+		allowed, cacheHit, err = checker.checkPermission(ctx, user, permission)
+	} else {
+		allowed, err = e.Enforcer.IsUserGrantedPermission(ctx, user, permission)
+	}
+	end := e.now()
+
+	decision := &models.AuthorizationDecision{
+		Subject:    user,
+		Permission: permission,
+		Allowed:    allowed && err == nil,
+		CacheHit:   cacheHit,
+		LatencyMs:  float64(end.Sub(start).Microseconds()) / 1000,
+		RequestID:  requestctx.RequestID(ctx),
+		CreatedAt:  end.UTC(),
+	}
+	if err != nil {
+		decision.Error = err.Error()
+	}
+	if sinkErr := e.sink.WriteDecision(ctx, decision); sinkErr != nil {
+		log.Errorf("error writing authorization decision of %s for %s: %s", user, permission, sinkErr)
+	}
+	return allowed, err
+}
@@ -0,0 +1,158 @@
+package enforcer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+// staticEnforcer grants the permissions of its map and fails the checks of the other permissions
+type staticEnforcer struct {
+	Enforcer
+	permissions map[string]bool
+}
+
+func (e *staticEnforcer) IsUserGrantedPermission(_ context.Context, _ string, permission string) (bool, error) {
+	allowed, ok := e.permissions[permission]
+	if !ok {
+		return false, errors.New("unknown permission")
+	}
+	return allowed, nil
+}
+
+func TestDecisionLoggingEnforcer_IsUserGrantedPermission(t *testing.T) {
+	var decisions []*models.AuthorizationDecision
+	sink := DecisionSinkFunc(func(_ context.Context, decision *models.AuthorizationDecision) error {
+		decisions = append(decisions, decision)
+		return nil
+	})
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	enforcer := newDecisionLoggingEnforcer(&staticEnforcer{permissions: map[string]bool{
+		"mlp.projects.1.secrets.get": true,
+		"mlp.projects.2.secrets.get": false,
+	}}, sink)
+	calls := 0
+	enforcer.now = func() time.Time {
+		calls++
+		return start.Add(time.Duration(calls) * 1500 * time.Microsecond)
+	}
+	ctx := requestctx.WithRequestID(context.Background(), "request-id")
+
+	allowed, err := enforcer.IsUserGrantedPermission(ctx, "alice@example.com", "mlp.projects.1.secrets.get")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = enforcer.IsUserGrantedPermission(ctx, "alice@example.com", "mlp.projects.2.secrets.get")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	_, err = enforcer.IsUserGrantedPermission(ctx, "alice@example.com", "mlp.projects.3.secrets.get")
+	assert.Error(t, err)
+
+	require.Len(t, decisions, 3)
+	assert.Equal(t, &models.AuthorizationDecision{
+		Subject:    "alice@example.com",
+		Permission: "mlp.projects.1.secrets.get",
+		Allowed:    true,
+		LatencyMs:  1.5,
+		RequestID:  "request-id",
+		CreatedAt:  start.Add(3 * time.Millisecond),
+	}, decisions[0])
+	assert.False(t, decisions[1].Allowed)
+	assert.False(t, decisions[2].Allowed)
+	assert.Equal(t, "unknown permission", decisions[2].Error)
+}
+
+func TestDecisionLoggingEnforcer_CacheHit(t *testing.T) {
+	ketoEnforcer, err := newEnforcer("http://localhost:0", "http://localhost:0",
+		&CacheConfig{KeyExpirySeconds: 60, CacheCleanUpIntervalSeconds: 60})
+	require.NoError(t, err)
+	ketoEnforcer.cache.StoreUserPermission("alice@example.com", "mlp.projects.1.get", true)
+
+	var out bytes.Buffer
+	enforcer := newDecisionLoggingEnforcer(ketoEnforcer, NewJSONDecisionSink(&out))
+	allowed, err := enforcer.IsUserGrantedPermission(context.Background(), "alice@example.com", "mlp.projects.1.get")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	var decision models.AuthorizationDecision
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decision))
+	assert.Equal(t, "alice@example.com", decision.Subject)
+	assert.True(t, decision.Allowed)
+	assert.True(t, decision.CacheHit)
+}
+
+func TestBatchingDecisionSink(t *testing.T) {
+	var batches [][]string
+	var mu sync.Mutex
+	sink := newBatchingDecisionSink(func(decisions []*models.AuthorizationDecision) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batch := make([]string, len(decisions))
+		for i, decision := range decisions {
+			batch[i] = decision.Permission
+		}
+		batches = append(batches, batch)
+		return nil
+	}, 2, 10, time.Hour)
+
+	for _, permission := range []string{"mlp.projects.1.get", "mlp.projects.2.get", "mlp.projects.3.get"} {
+		require.NoError(t, sink.WriteDecision(context.Background(), &models.AuthorizationDecision{
+			Permission: permission,
+		}))
+	}
+	// the full batch is written right away, and the remaining decision when the sink is closed
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(batches) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, sink.Close())
+	assert.Equal(t, [][]string{{"mlp.projects.1.get", "mlp.projects.2.get"}, {"mlp.projects.3.get"}}, batches)
+}
+
+func TestBatchingDecisionSink_FlushInterval(t *testing.T) {
+	written := make(chan int, 1)
+	sink := newBatchingDecisionSink(func(decisions []*models.AuthorizationDecision) error {
+		written <- len(decisions)
+		return nil
+	}, 100, 10, 10*time.Millisecond)
+	defer sink.Close()
+
+	require.NoError(t, sink.WriteDecision(context.Background(), &models.AuthorizationDecision{}))
+	select {
+	case n := <-written:
+		assert.Equal(t, 1, n)
+	case <-time.After(time.Second):
+		t.Fatal("the decision was not written after the flush interval")
+	}
+}
+
+func TestBatchingDecisionSink_BufferFull(t *testing.T) {
+	writing := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	sink := newBatchingDecisionSink(func(decisions []*models.AuthorizationDecision) error {
+		once.Do(func() { close(writing) })
+		<-release
+		return nil
+	}, 1, 1, time.Hour)
+
+	// the first decision is being written while the second one fills the buffer
+	require.NoError(t, sink.WriteDecision(context.Background(), &models.AuthorizationDecision{}))
+	<-writing
+	require.NoError(t, sink.WriteDecision(context.Background(), &models.AuthorizationDecision{}))
+	assert.EqualError(t, sink.WriteDecision(context.Background(), &models.AuthorizationDecision{}),
+		"the decision log buffer is full, the decision is dropped")
+
+	close(release)
+	require.NoError(t, sink.Close())
+}
@@ -3,11 +3,16 @@ package enforcer
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	ory "github.com/ory/keto-client-go"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
 )
 
 // Enforcer interface to enforce authorization
@@ -16,12 +21,18 @@ type Enforcer interface {
 	IsUserGrantedPermission(ctx context.Context, user string, permission string) (bool, error)
 	// GetUserRoles get all roles directly associated with a user
 	GetUserRoles(ctx context.Context, user string) ([]string, error)
+	// GetGroupRoles get all roles granted to the members of a group
+	GetGroupRoles(ctx context.Context, group string) ([]string, error)
 	// GetRolePermissions get all permissions directly associated with a role
 	GetRolePermissions(ctx context.Context, role string) ([]string, error)
 	// GetUserPermissions get all permissions associated with a user
 	GetUserPermissions(ctx context.Context, user string) ([]string, error)
 	// GetRoleMembers get all members for a role
 	GetRoleMembers(ctx context.Context, role string) ([]string, error)
+	// ListRoles get all roles with members whose name starts with the prefix
+	ListRoles(ctx context.Context, prefix string) ([]string, error)
+	// GetGroupMembers get all users that are direct members of a group
+	GetGroupMembers(ctx context.Context, group string) ([]string, error)
 	// UpdateAuthorization update authorization rules in batches
 	UpdateAuthorization(ctx context.Context, updateRequest AuthorizationUpdateRequest) error
 }
@@ -30,13 +41,28 @@ type Enforcer interface {
 type CacheConfig struct {
 	KeyExpirySeconds            int
 	CacheCleanUpIntervalSeconds int
+	// Broadcaster, if not nil, propagates the cache invalidations to the other replicas
+	Broadcaster CacheInvalidationBroadcaster
+}
+
+// CacheInvalidationBroadcaster propagates the invalidations of the in-memory cache between the replicas of the API,
+// so that the revoked permissions do not stay cached until they expire
+type CacheInvalidationBroadcaster interface {
+	// Broadcast sends the invalidation to all the subscribed replicas
+	Broadcast(ctx context.Context, invalidation CacheInvalidation) error
+	// Subscribe calls invalidate with each invalidation received from the replicas, including this one
+	Subscribe(invalidate func(CacheInvalidation)) error
 }
 
+// groupNamespace is the Keto namespace of the groups, whose members are granted the roles of the group
+const groupNamespace = "Group"
+
 // MaxKeyExpirySeconds is the max allowed value for the KeyExpirySeconds.
 const MaxKeyExpirySeconds = 600
 
 type enforcer struct {
 	cache           *InMemoryCache
+	broadcaster     CacheInvalidationBroadcaster
 	ketoReadClient  *ory.APIClient
 	ketoWriteClient *ory.APIClient
 }
@@ -69,14 +95,25 @@ func newEnforcer(
 				MaxKeyExpirySeconds)
 		}
 		enforcer.cache = newInMemoryCache(cacheConfig.KeyExpirySeconds, cacheConfig.CacheCleanUpIntervalSeconds)
+		if cacheConfig.Broadcaster != nil {
+			if err := cacheConfig.Broadcaster.Subscribe(enforcer.cache.Invalidate); err != nil {
+				return nil, fmt.Errorf("failed to subscribe to the cache invalidations: %w", err)
+			}
+			enforcer.broadcaster = cacheConfig.Broadcaster
+		}
 	}
 	return enforcer, nil
 }
 
 func (e *enforcer) IsUserGrantedPermission(ctx context.Context, user string, permission string) (bool, error) {
+	allowed, _, err := e.checkPermission(ctx, user, permission)
+	return allowed, err
+}
+
+func (e *enforcer) checkPermission(ctx context.Context, user string, permission string) (bool, bool, error) {
 	if e.isCacheEnabled() {
 		if isAllowed, found := e.cache.LookUpUserPermission(user, permission); found {
-			return *isAllowed, nil
+			return *isAllowed, true, nil
 		}
 	}
 	checkPermissionResult, _, err := e.ketoReadClient.PermissionApi.CheckPermission(ctx).
@@ -88,13 +125,13 @@ func (e *enforcer) IsUserGrantedPermission(ctx context.Context, user string, per
 		SubjectSetRelation("").
 		Execute()
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	userHasPermission := checkPermissionResult.Allowed
 	if e.isCacheEnabled() {
 		e.cache.StoreUserPermission(user, permission, userHasPermission)
 	}
-	return userHasPermission, nil
+	return userHasPermission, false, nil
 }
 
 func (e *enforcer) GetUserRoles(ctx context.Context, user string) ([]string, error) {
@@ -115,6 +152,24 @@ func (e *enforcer) GetUserRoles(ctx context.Context, user string) ([]string, err
 	return roles, nil
 }
 
+func (e *enforcer) GetGroupRoles(ctx context.Context, group string) ([]string, error) {
+	roleRelationships, _, err := e.ketoReadClient.RelationshipApi.GetRelationships(ctx).
+		Namespace("Role").
+		Relation("member").
+		SubjectSetNamespace(groupNamespace).
+		SubjectSetRelation("member").
+		SubjectSetObject(group).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group roles: %w", err)
+	}
+	roles := make([]string, 0)
+	for _, tuple := range roleRelationships.RelationTuples {
+		roles = append(roles, tuple.Object)
+	}
+
+	return roles, nil
+}
+
 func (e *enforcer) GetRolePermissions(ctx context.Context, role string) ([]string, error) {
 	permissionRelationships, _, err := e.ketoReadClient.RelationshipApi.GetRelationships(ctx).
 		Namespace("Permission").
@@ -179,12 +234,60 @@ func (e *enforcer) GetRoleMembers(ctx context.Context, role string) ([]string, e
 	}
 	members := make([]string, 0)
 	for _, child := range expandedRole.GetChildren() {
-		members = append(members, child.Tuple.SubjectSet.Object)
+		subjectSet := child.Tuple.SubjectSet
+		if subjectSet.Namespace == groupNamespace {
+			members = append(members, models.GroupSubject(subjectSet.Object))
+		} else {
+			members = append(members, subjectSet.Object)
+		}
 	}
 
 	return members, nil
 }
 
+func (e *enforcer) ListRoles(ctx context.Context, prefix string) ([]string, error) {
+	roleSet := make(map[string]bool)
+	pageToken := ""
+	for {
+		request := e.ketoReadClient.RelationshipApi.GetRelationships(ctx).
+			Namespace("Role").
+			Relation("member")
+		if pageToken != "" {
+			request = request.PageToken(pageToken)
+		}
+		roleRelationships, _, err := request.Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, tuple := range roleRelationships.RelationTuples {
+			if strings.HasPrefix(tuple.Object, prefix) {
+				roleSet[tuple.Object] = true
+			}
+		}
+		pageToken = roleRelationships.GetNextPageToken()
+		if pageToken == "" {
+			return sortedKeys(roleSet), nil
+		}
+	}
+}
+
+func (e *enforcer) GetGroupMembers(ctx context.Context, group string) ([]string, error) {
+	memberRelationships, _, err := e.ketoReadClient.RelationshipApi.GetRelationships(ctx).
+		Namespace(groupNamespace).
+		Object(group).
+		Relation("member").Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+	members := make([]string, 0)
+	for _, tuple := range memberRelationships.RelationTuples {
+		if tuple.SubjectSet != nil {
+			members = append(members, tuple.SubjectSet.Object)
+		}
+	}
+	return members, nil
+}
+
 func newRolePermissionPatch(action string, permission string, role string) ory.RelationshipPatch {
 	return ory.RelationshipPatch{
 		Action: &action,
@@ -197,13 +300,31 @@ func newRolePermissionPatch(action string, permission string, role string) ory.R
 	}
 }
 
+// newRoleMemberPatch adds or removes a member of a role. A group member is stored as the subject set of the members
+// of the group, so that Keto expands it to the users of the group.
 func newRoleMemberPatch(action string, role string, member string) ory.RelationshipPatch {
+	subjectSet := ory.NewSubjectSet("Subject", member, "")
+	if group, ok := models.ParseGroupSubject(member); ok {
+		subjectSet = ory.NewSubjectSet(groupNamespace, group, "member")
+	}
 	return ory.RelationshipPatch{
 		Action: &action,
 		RelationTuple: &ory.Relationship{
 			Namespace:  "Role",
 			Object:     role,
 			Relation:   "member",
+			SubjectSet: subjectSet,
+		},
+	}
+}
+
+func newGroupMemberPatch(action string, group string, member string) ory.RelationshipPatch {
+	return ory.RelationshipPatch{
+		Action: &action,
+		RelationTuple: &ory.Relationship{
+			Namespace:  groupNamespace,
+			Object:     group,
+			Relation:   "member",
 			SubjectSet: ory.NewSubjectSet("Subject", member, ""),
 		},
 	}
@@ -212,6 +333,10 @@ func newRoleMemberPatch(action string, role string, member string) ory.Relations
 func (e *enforcer) UpdateAuthorization(ctx context.Context, updateRequest AuthorizationUpdateRequest) error {
 	var existingRolePermissions sync.Map
 	var existingRoleMembers sync.Map
+	var removedRolePermissions sync.Map
+	var existingGroupMembers sync.Map
+	var removedRoleMembers sync.Map
+	var removedGroupMembers sync.Map
 	getRelationsWorkersGroup := new(errgroup.Group)
 	for role := range updateRequest.RolePermissions {
 		updatedRole := role
@@ -235,12 +360,66 @@ func (e *enforcer) UpdateAuthorization(ctx context.Context, updateRequest Author
 			return nil
 		})
 	}
+	for role := range updateRequest.RemovedRolePermissions {
+		updatedRole := role
+		getRelationsWorkersGroup.Go(func() error {
+			permissions, err := e.GetRolePermissions(ctx, updatedRole)
+			if err != nil {
+				return err
+			}
+			removedRolePermissions.Store(updatedRole, permissions)
+			return nil
+		})
+	}
+	for group := range updateRequest.GroupMembers {
+		updatedGroup := group
+		getRelationsWorkersGroup.Go(func() error {
+			members, err := e.GetGroupMembers(ctx, updatedGroup)
+			if err != nil {
+				return err
+			}
+			existingGroupMembers.Store(updatedGroup, members)
+			return nil
+		})
+	}
+	for role := range updateRequest.RemovedRoleMembers {
+		updatedRole := role
+		getRelationsWorkersGroup.Go(func() error {
+			members, err := e.GetRoleMembers(ctx, updatedRole)
+			if err != nil {
+				return err
+			}
+			removedRoleMembers.Store(updatedRole, members)
+			return nil
+		})
+	}
+	for group := range updateRequest.RemovedGroupMembers {
+		updatedGroup := group
+		getRelationsWorkersGroup.Go(func() error {
+			members, err := e.GetGroupMembers(ctx, updatedGroup)
+			if err != nil {
+				return err
+			}
+			removedGroupMembers.Store(updatedGroup, members)
+			return nil
+		})
+	}
 	err := getRelationsWorkersGroup.Wait()
 	if err != nil {
 		return err
 	}
 	patches := make([]ory.RelationshipPatch, 0)
 
+	for role, permissions := range updateRequest.RemovedRolePermissions {
+		result, _ := removedRolePermissions.Load(role)
+		existingPermissions := result.([]string)
+		for _, permission := range permissions {
+			if slices.Contains(existingPermissions, permission) {
+				patches = append(patches, newRolePermissionPatch("delete", permission, role))
+			}
+		}
+	}
+
 	for role, permissions := range updateRequest.RolePermissions {
 		for _, permission := range permissions {
 			result, found := existingRolePermissions.Load(role)
@@ -272,8 +451,103 @@ func (e *enforcer) UpdateAuthorization(ctx context.Context, updateRequest Author
 		}
 	}
 
+	for group, members := range updateRequest.GroupMembers {
+		result, _ := existingGroupMembers.Load(group)
+		existingMembers := result.([]string)
+		for _, member := range existingMembers {
+			if !slices.Contains(members, member) {
+				patches = append(patches, newGroupMemberPatch("delete", group, member))
+			}
+		}
+		for _, member := range members {
+			if !slices.Contains(existingMembers, member) {
+				patches = append(patches, newGroupMemberPatch("insert", group, member))
+			}
+		}
+	}
+
+	for role, members := range updateRequest.RemovedRoleMembers {
+		result, _ := removedRoleMembers.Load(role)
+		existingMembers := result.([]string)
+		for _, member := range members {
+			if slices.Contains(existingMembers, member) {
+				patches = append(patches, newRoleMemberPatch("delete", role, member))
+			}
+		}
+	}
+
+	for group, members := range updateRequest.RemovedGroupMembers {
+		result, _ := removedGroupMembers.Load(group)
+		existingMembers := result.([]string)
+		for _, member := range members {
+			if slices.Contains(existingMembers, member) {
+				patches = append(patches, newGroupMemberPatch("delete", group, member))
+			}
+		}
+	}
+
 	_, err = e.ketoWriteClient.RelationshipApi.PatchRelationships(ctx).RelationshipPatch(patches).Execute()
-	return err
+	if err != nil {
+		return err
+	}
+	if e.isCacheEnabled() {
+		e.invalidateCache(ctx, patches)
+	}
+	return nil
+}
+
+// invalidateCache deletes the cached permission check results affected by the patches, in this replica and, if a
+// broadcaster is configured, in the other ones. The results are invalidated by permission when the permissions of a
+// role change, and by user when the users are granted or revoked roles or groups.
+func (e *enforcer) invalidateCache(ctx context.Context, patches []ory.RelationshipPatch) {
+	invalidation := e.getCacheInvalidation(ctx, patches)
+	if invalidation.IsEmpty() {
+		return
+	}
+	e.cache.Invalidate(invalidation)
+	if e.broadcaster != nil {
+		if err := e.broadcaster.Broadcast(ctx, invalidation); err != nil {
+			log.Warnf("failed to broadcast the invalidation of the authorization cache: %s", err)
+		}
+	}
+}
+
+func (e *enforcer) getCacheInvalidation(ctx context.Context, patches []ory.RelationshipPatch) CacheInvalidation {
+	users := make(map[string]bool)
+	permissions := make(map[string]bool)
+	groups := make(map[string]bool)
+	for _, patch := range patches {
+		tuple := patch.RelationTuple
+		switch {
+		case tuple.Namespace == "Permission":
+			permissions[tuple.Object] = true
+		case tuple.SubjectSet.Namespace == groupNamespace:
+			groups[tuple.SubjectSet.Object] = true
+		default:
+			users[tuple.SubjectSet.Object] = true
+		}
+	}
+	// the roles granted to or revoked from a group affect all its members
+	for group := range groups {
+		members, err := e.GetGroupMembers(ctx, group)
+		if err != nil {
+			log.Warnf("failed to get the members of group %s, invalidating the whole cache: %s", group, err)
+			return CacheInvalidation{All: true}
+		}
+		for _, member := range members {
+			users[member] = true
+		}
+	}
+	return CacheInvalidation{Users: sortedKeys(users), Permissions: sortedKeys(permissions)}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func (e *enforcer) isCacheEnabled() bool {
@@ -285,14 +559,22 @@ func (e *enforcer) isCacheEnabled() bool {
 // object is passed to the Enforcer, in which all the previously chained operations will be executed in batch.
 func NewAuthorizationUpdateRequest() AuthorizationUpdateRequest {
 	return AuthorizationUpdateRequest{
-		RolePermissions: make(map[string][]string),
-		RoleMembers:     make(map[string][]string),
+		RolePermissions:        make(map[string][]string),
+		RoleMembers:            make(map[string][]string),
+		RemovedRolePermissions: make(map[string][]string),
+		GroupMembers:           make(map[string][]string),
+		RemovedRoleMembers:     make(map[string][]string),
+		RemovedGroupMembers:    make(map[string][]string),
 	}
 }
 
 type AuthorizationUpdateRequest struct {
-	RolePermissions map[string][]string
-	RoleMembers     map[string][]string
+	RolePermissions        map[string][]string
+	RoleMembers            map[string][]string
+	RemovedRolePermissions map[string][]string
+	GroupMembers           map[string][]string
+	RemovedRoleMembers     map[string][]string
+	RemovedGroupMembers    map[string][]string
 }
 
 // AddRolePermissions add permissions to a role, without duplication. Existing permissions will still be in place.
@@ -307,3 +589,30 @@ func (a AuthorizationUpdateRequest) SetRoleMembers(role string, members []string
 	a.RoleMembers[role] = members
 	return a
 }
+
+// SetGroupMembers set the members for a group. If the group already has members, they will be replaced.
+func (a AuthorizationUpdateRequest) SetGroupMembers(group string, members []string) AuthorizationUpdateRequest {
+	a.GroupMembers[group] = members
+	return a
+}
+
+// RemoveRolePermissions remove permissions from a role. Permissions that are not associated with the role are ignored.
+func (a AuthorizationUpdateRequest) RemoveRolePermissions(role string,
+	permissions []string) AuthorizationUpdateRequest {
+	a.RemovedRolePermissions[role] = permissions
+	return a
+}
+
+// RemoveRoleMembers remove members from a role, leaving its other members in place. Members that are not members of
+// the role are ignored.
+func (a AuthorizationUpdateRequest) RemoveRoleMembers(role string, members []string) AuthorizationUpdateRequest {
+	a.RemovedRoleMembers[role] = members
+	return a
+}
+
+// RemoveGroupMembers remove members from a group, leaving its other members in place. Members that are not members
+// of the group are ignored.
+func (a AuthorizationUpdateRequest) RemoveGroupMembers(group string, members []string) AuthorizationUpdateRequest {
+	a.RemovedGroupMembers[group] = members
+	return a
+}
@@ -315,6 +315,14 @@ func TestEnforcer_UpdateAuthorization(t *testing.T) {
 	require.NoError(t, err)
 	sort.Strings(res)
 	assert.Equal(t, []string{"pages.1.get", "pages.1.post", "pages.2.get", "pages.2.post"}, res)
+	updateRequest = NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRolePermissions("pages.readers", []string{"pages.2.get", "pages.2.post", "pages.3.get"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+	res, err = ketoEnforcer.GetRolePermissions(context.Background(), "pages.readers")
+	require.NoError(t, err)
+	sort.Strings(res)
+	assert.Equal(t, []string{"pages.1.get", "pages.1.post"}, res)
 }
 
 func TestEnforcer_GetRoleMembers(t *testing.T) {
@@ -357,6 +365,122 @@ func TestEnforcer_GetRoleMembers(t *testing.T) {
 	}
 }
 
+func TestEnforcer_GroupMembers(t *testing.T) {
+	ketoEnforcer, err := NewEnforcerBuilder().Build()
+	require.NoError(t, err)
+	readClient := newKetoClient(ketoRemoteRead)
+	writeClient := newKetoClient(ketoRemoteWrite)
+	clearRelations(readClient, writeClient)
+	updateRequest := NewAuthorizationUpdateRequest()
+	updateRequest.AddRolePermissions("pages.1.admin", []string{"pages.1.put"})
+	updateRequest.SetRoleMembers("pages.1.admin", []string{"owner@example.com", "group:editors"})
+	updateRequest.SetGroupMembers("editors", []string{"user-1@example.com", "user-2@example.com"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+
+	members, err := ketoEnforcer.GetRoleMembers(context.Background(), "pages.1.admin")
+	require.NoError(t, err)
+	sort.Strings(members)
+	assert.Equal(t, []string{"group:editors", "owner@example.com"}, members)
+	allowed, err := ketoEnforcer.IsUserGrantedPermission(context.Background(), "user-2@example.com", "pages.1.put")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	updateRequest = NewAuthorizationUpdateRequest()
+	updateRequest.SetGroupMembers("editors", []string{"user-1@example.com"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+	allowed, err = ketoEnforcer.IsUserGrantedPermission(context.Background(), "user-2@example.com", "pages.1.put")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = ketoEnforcer.IsUserGrantedPermission(context.Background(), "user-1@example.com", "pages.1.put")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	roles, err := ketoEnforcer.GetGroupRoles(context.Background(), "editors")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pages.1.admin"}, roles)
+	members, err = ketoEnforcer.GetGroupMembers(context.Background(), "editors")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1@example.com"}, members)
+
+	// only the removed members are removed
+	updateRequest = NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRoleMembers("pages.1.admin", []string{"owner@example.com", "unknown@example.com"})
+	updateRequest.RemoveGroupMembers("editors", []string{"unknown@example.com"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+	members, err = ketoEnforcer.GetRoleMembers(context.Background(), "pages.1.admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"group:editors"}, members)
+	members, err = ketoEnforcer.GetGroupMembers(context.Background(), "editors")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1@example.com"}, members)
+	roles, err = ketoEnforcer.ListRoles(context.Background(), "pages.1.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pages.1.admin"}, roles)
+}
+
+type recordingBroadcaster struct {
+	invalidations []CacheInvalidation
+}
+
+func (b *recordingBroadcaster) Broadcast(_ context.Context, invalidation CacheInvalidation) error {
+	b.invalidations = append(b.invalidations, invalidation)
+	return nil
+}
+
+func (b *recordingBroadcaster) Subscribe(_ func(CacheInvalidation)) error {
+	return nil
+}
+
+func TestEnforcer_CacheInvalidation(t *testing.T) {
+	broadcaster := &recordingBroadcaster{}
+	ketoEnforcer, err := NewEnforcerBuilder().
+		WithCaching(600, 600).
+		WithCacheInvalidationBroadcaster(broadcaster).
+		Build()
+	require.NoError(t, err)
+	readClient := newKetoClient(ketoRemoteRead)
+	writeClient := newKetoClient(ketoRemoteWrite)
+	clearRelations(readClient, writeClient)
+	updateRequest := NewAuthorizationUpdateRequest()
+	updateRequest.AddRolePermissions("pages.1.admin", []string{"pages.1.put"})
+	updateRequest.SetRoleMembers("pages.1.admin", []string{"admin@example.com", "group:editors"})
+	updateRequest.SetGroupMembers("editors", []string{"user-1@example.com"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+
+	for _, user := range []string{"admin@example.com", "user-1@example.com"} {
+		allowed, err := ketoEnforcer.IsUserGrantedPermission(context.Background(), user, "pages.1.put")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	// the revocation takes effect immediately, without waiting for the cached results to expire
+	updateRequest = NewAuthorizationUpdateRequest()
+	updateRequest.SetRoleMembers("pages.1.admin", []string{"group:editors"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+	allowed, err := ketoEnforcer.IsUserGrantedPermission(context.Background(), "admin@example.com", "pages.1.put")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	updateRequest = NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRolePermissions("pages.1.admin", []string{"pages.1.put"})
+	err = ketoEnforcer.UpdateAuthorization(context.Background(), updateRequest)
+	require.NoError(t, err)
+	allowed, err = ketoEnforcer.IsUserGrantedPermission(context.Background(), "user-1@example.com", "pages.1.put")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	assert.Equal(t, []CacheInvalidation{
+		{Users: []string{"admin@example.com", "user-1@example.com"}, Permissions: []string{"pages.1.put"}},
+		{Users: []string{"admin@example.com"}, Permissions: []string{}},
+		{Users: []string{}, Permissions: []string{"pages.1.put"}},
+	}, broadcaster.invalidations)
+}
+
 func newKetoClient(endpoint string) *ory.APIClient {
 	cfg := ory.NewConfiguration()
 	cfg.Servers = ory.ServerConfigurations{
@@ -15,6 +15,58 @@ type Enforcer struct {
 	mock.Mock
 }
 
+// GetGroupMembers provides a mock function with given fields: ctx, group
+func (_m *Enforcer) GetGroupMembers(ctx context.Context, group string) ([]string, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupRoles provides a mock function with given fields: ctx, group
+func (_m *Enforcer) GetGroupRoles(ctx context.Context, group string) ([]string, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRoleMembers provides a mock function with given fields: ctx, role
 func (_m *Enforcer) GetRoleMembers(ctx context.Context, role string) ([]string, error) {
 	ret := _m.Called(ctx, role)
@@ -143,6 +195,32 @@ func (_m *Enforcer) IsUserGrantedPermission(ctx context.Context, user string, pe
 	return r0, r1
 }
 
+// ListRoles provides a mock function with given fields: ctx, prefix
+func (_m *Enforcer) ListRoles(ctx context.Context, prefix string) ([]string, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateAuthorization provides a mock function with given fields: ctx, updateRequest
 func (_m *Enforcer) UpdateAuthorization(ctx context.Context, updateRequest enforcer.AuthorizationUpdateRequest) error {
 	ret := _m.Called(ctx, updateRequest)
@@ -0,0 +1,82 @@
+package enforcer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/caraml-dev/mlp/api/log"
+)
+
+// maxNotificationPayloadBytes is the max size of the payload of a Postgres notification
+const maxNotificationPayloadBytes = 8000
+
+// PostgresBroadcaster broadcasts the cache invalidations to the replicas connected to the same database, with the
+// Postgres LISTEN and NOTIFY commands
+type PostgresBroadcaster struct {
+	db       *sql.DB
+	listener *pq.Listener
+	channel  string
+}
+
+// NewPostgresBroadcaster creates a broadcaster notifying the invalidations through db, and listening to them on a
+// dedicated connection opened with connectionString
+func NewPostgresBroadcaster(db *sql.DB, connectionString string, channel string) *PostgresBroadcaster {
+	listener := pq.NewListener(connectionString, 10*time.Second, time.Minute,
+		func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Warnf("authorization cache invalidation listener error: %s", err)
+			}
+		})
+	return &PostgresBroadcaster{
+		db:       db,
+		listener: listener,
+		channel:  channel,
+	}
+}
+
+func (b *PostgresBroadcaster) Broadcast(ctx context.Context, invalidation CacheInvalidation) error {
+	payload, err := json.Marshal(invalidation)
+	if err != nil {
+		return err
+	}
+	// the invalidations which do not fit in a notification invalidate the whole cache of the other replicas
+	if len(payload) >= maxNotificationPayloadBytes {
+		payload, _ = json.Marshal(CacheInvalidation{All: true})
+	}
+	if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify channel %s: %w", b.channel, err)
+	}
+	return nil
+}
+
+func (b *PostgresBroadcaster) Subscribe(invalidate func(CacheInvalidation)) error {
+	if err := b.listener.Listen(b.channel); err != nil {
+		return fmt.Errorf("failed to listen to channel %s: %w", b.channel, err)
+	}
+	go func() {
+		for notification := range b.listener.Notify {
+			// the listener sends nil after reconnecting, the notifications sent in between are lost
+			if notification == nil {
+				invalidate(CacheInvalidation{All: true})
+				continue
+			}
+			var invalidation CacheInvalidation
+			if err := json.Unmarshal([]byte(notification.Extra), &invalidation); err != nil {
+				log.Warnf("invalid authorization cache invalidation %s: %s", notification.Extra, err)
+				invalidation = CacheInvalidation{All: true}
+			}
+			invalidate(invalidation)
+		}
+	}()
+	return nil
+}
+
+// Close stops listening to the invalidations
+func (b *PostgresBroadcaster) Close() error {
+	return b.listener.Close()
+}
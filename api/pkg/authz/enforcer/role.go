@@ -12,6 +12,13 @@ const (
 	MLPProjectsReaderRole = "mlp.projects.reader"
 	MLPProjectReaderRole  = "mlp.projects.{{ .ProjectId }}.reader"
 	MLPProjectAdminRole   = "mlp.projects.{{ .ProjectId }}.administrator"
+	// MLPProjectSecretReaderRole can read the values of all secrets of the project
+	MLPProjectSecretReaderRole = "mlp.projects.{{ .ProjectId }}.secret_reader"
+	// MLPProjectCustomRole is granted to the members of a custom role assigned in the project
+	MLPProjectCustomRole = "mlp.projects.{{ .ProjectId }}.roles.{{ .RoleName }}"
+	MLPStreamOwnerRole   = "mlp.streams.{{ .StreamId }}.owner"
+	MLPTeamOwnerRole     = "mlp.teams.{{ .TeamId }}.owner"
+	MLPGroupOwnerRole    = "mlp.groups.{{ .GroupId }}.owner"
 )
 
 func ParseRole(role string, templateContext map[string]string) (string, error) {
@@ -0,0 +1,72 @@
+// Package encryption provides symmetric encryption of sensitive values, such as the secrets of exported projects.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KeySize is the size in bytes of the keys used by AESEncryptor, which selects AES-256
+const KeySize = 32
+
+// AESEncryptor encrypts and decrypts values using AES-256 in GCM mode. Encrypted values are base64 encoded and
+// prefixed with the random nonce used to encrypt them.
+type AESEncryptor struct {
+	aead cipher.AEAD
+}
+
+// NewAESEncryptor creates an AESEncryptor from a base64 encoded key of KeySize bytes
+func NewAESEncryptor(encodedKey string) (*AESEncryptor, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key must be base64 encoded: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes long, got %d bytes", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESEncryptor{aead: aead}, nil
+}
+
+// Encrypt encrypts the plaintext and returns the base64 encoded ciphertext
+func (e *AESEncryptor) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("unable to generate nonce: %w", err)
+	}
+
+	ciphertext := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a base64 encoded ciphertext produced by Encrypt
+func (e *AESEncryptor) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("encrypted value must be base64 encoded: %w", err)
+	}
+
+	nonceSize := e.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+
+	plaintext, err := e.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
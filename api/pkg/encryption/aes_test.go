@@ -0,0 +1,70 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAESEncryptor(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		expectedErr string
+	}{
+		"valid key": {
+			key: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize))),
+		},
+		"valid key with trailing newline": {
+			key: base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize))) + "\n",
+		},
+		"key not base64 encoded": {
+			key:         "not a key",
+			expectedErr: "encryption key must be base64 encoded: illegal base64 data at input byte 3",
+		},
+		"key too short": {
+			key:         base64.StdEncoding.EncodeToString([]byte("short")),
+			expectedErr: "encryption key must be 32 bytes long, got 5 bytes",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			encryptor, err := NewAESEncryptor(tt.key)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, encryptor)
+		})
+	}
+}
+
+func TestAESEncryptor_EncryptDecrypt(t *testing.T) {
+	encryptor, err := NewAESEncryptor(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", KeySize))))
+	require.NoError(t, err)
+
+	encrypted, err := encryptor.Encrypt("secret-data")
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, "secret-data")
+
+	// every encryption uses a new nonce
+	encryptedAgain, err := encryptor.Encrypt("secret-data")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, encryptedAgain)
+
+	decrypted, err := encryptor.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-data", decrypted)
+
+	otherEncryptor, err := NewAESEncryptor(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", KeySize))))
+	require.NoError(t, err)
+	_, err = otherEncryptor.Decrypt(encrypted)
+	assert.EqualError(t, err, "unable to decrypt value: cipher: message authentication failed")
+
+	_, err = encryptor.Decrypt(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.EqualError(t, err, "encrypted value is too short")
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // NotFoundError is an error type that indicates that the resource is not found
 type NotFoundError struct {
@@ -70,3 +73,93 @@ func (e *InvalidArgumentError) Is(target error) bool {
 	_, ok := target.(*InvalidArgumentError)
 	return ok
 }
+
+// PreconditionFailedError is an error type that indicates that the resource has been modified since it was read
+type PreconditionFailedError struct {
+	message string
+}
+
+// NewPreconditionFailedErrorf creates a new PreconditionFailedError with a formatted message
+func NewPreconditionFailedErrorf(format string, a ...any) *PreconditionFailedError {
+	return &PreconditionFailedError{
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Error returns the error message
+func (e *PreconditionFailedError) Error() string {
+	return e.message
+}
+
+// Is check whether the error is PreconditionFailedError
+func (e *PreconditionFailedError) Is(target error) bool {
+	_, ok := target.(*PreconditionFailedError)
+	return ok
+}
+
+// FieldViolation describes why a field of a request is invalid
+type FieldViolation struct {
+	// Field is the name of the invalid field
+	Field string `json:"field"`
+	// Rule is the identifier of the rule that the field violates
+	Rule string `json:"rule"`
+	// Message is the human readable description of the violation
+	Message string `json:"message"`
+}
+
+// ValidationError is an error type that indicates that one or more fields of a request are invalid
+type ValidationError struct {
+	message    string
+	Violations []FieldViolation
+}
+
+// NewValidationError creates a new ValidationError with the given violations
+func NewValidationError(message string, violations ...FieldViolation) *ValidationError {
+	return &ValidationError{
+		message:    message,
+		Violations: violations,
+	}
+}
+
+// Message returns the error message without the violations
+func (e *ValidationError) Message() string {
+	return e.message
+}
+
+// Error returns the error message followed by the message of every violation
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.message, strings.Join(messages, "; "))
+}
+
+// Is check whether the error is ValidationError
+func (e *ValidationError) Is(target error) bool {
+	_, ok := target.(*ValidationError)
+	return ok
+}
+
+// UnauthenticatedError is an error type that indicates that the credentials of a request are missing or invalid
+type UnauthenticatedError struct {
+	message string
+}
+
+// NewUnauthenticatedErrorf creates a new UnauthenticatedError with a formatted message
+func NewUnauthenticatedErrorf(format string, a ...any) *UnauthenticatedError {
+	return &UnauthenticatedError{
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Error returns the error message
+func (e *UnauthenticatedError) Error() string {
+	return e.message
+}
+
+// Is check whether the error is UnauthenticatedError
+func (e *UnauthenticatedError) Is(target error) bool {
+	_, ok := target.(*UnauthenticatedError)
+	return ok
+}
@@ -0,0 +1,201 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// Operator is the relation between a label key and its values in a Requirement
+type Operator string
+
+const (
+	// Equals requires the label to have exactly the given value
+	Equals Operator = "="
+	// NotEquals requires the label to be absent or to have a value other than the given value
+	NotEquals Operator = "!="
+	// In requires the label to have one of the given values
+	In Operator = "in"
+	// NotIn requires the label to be absent or to have none of the given values
+	NotIn Operator = "notin"
+	// Exists requires the label to be present, regardless of its value
+	Exists Operator = "exists"
+	// DoesNotExist requires the label to be absent
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition that a set of labels has to satisfy
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector is a list of requirements that are all required to be satisfied
+type Selector []Requirement
+
+// Set is a set of labels, keyed by the label key
+type Set map[string]string
+
+var (
+	setRequirementRegex = regexp.MustCompile(`^([^\s=!(),]+)\s+(in|notin)\s*\((.*)\)$`)
+	existenceRegex      = regexp.MustCompile(`^(!?)\s*([^\s=!(),]+)$`)
+)
+
+// Parse parses a comma separated label selector. The following requirements are supported:
+//   - equality: "team=dsp", "team==dsp", "team!=dsp"
+//   - set based: "env in (staging,production)", "env notin (dev)"
+//   - existence: "env", "!env"
+//
+// An empty selector selects everything. Keys and values are validated against the Kubernetes label syntax.
+func Parse(selector string) (Selector, error) {
+	terms, err := splitTerms(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := make(Selector, 0, len(terms))
+	for _, term := range terms {
+		requirement, err := parseRequirement(term)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// Matches returns true if the labels satisfy all the requirements of the selector
+func (s Selector) Matches(labels Set) bool {
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches returns true if the labels satisfy the requirement
+func (r Requirement) Matches(labels Set) bool {
+	value, exists := labels[r.Key]
+	switch r.Operator {
+	case Equals, In:
+		return exists && slices.Contains(r.Values, value)
+	case NotEquals, NotIn:
+		return !exists || !slices.Contains(r.Values, value)
+	case Exists:
+		return exists
+	case DoesNotExist:
+		return !exists
+	default:
+		return false
+	}
+}
+
+// String returns the selector in the same format accepted by Parse
+func (s Selector) String() string {
+	terms := make([]string, 0, len(s))
+	for _, requirement := range s {
+		terms = append(terms, requirement.String())
+	}
+	return strings.Join(terms, ",")
+}
+
+// String returns the requirement in the same format accepted by Parse
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Equals, NotEquals:
+		return fmt.Sprintf("%s%s%s", r.Key, r.Operator, r.Values[0])
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return "!" + r.Key
+	default:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	}
+}
+
+// splitTerms splits the selector on commas that are not enclosed in parentheses
+func splitTerms(selector string) ([]string, error) {
+	terms := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid label selector %q: unbalanced parentheses", selector)
+	}
+	terms = append(terms, selector[start:])
+
+	if len(terms) == 1 && strings.TrimSpace(terms[0]) == "" {
+		return []string{}, nil
+	}
+	return terms, nil
+}
+
+func parseRequirement(term string) (Requirement, error) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return Requirement{}, fmt.Errorf("invalid label selector: empty requirement")
+	}
+
+	requirement, err := parseOperator(term)
+	if err != nil {
+		return Requirement{}, err
+	}
+
+	if err := ValidateKey(requirement.Key); err != nil {
+		return Requirement{}, fmt.Errorf("invalid label selector requirement %q: %w", term, err)
+	}
+	for _, value := range requirement.Values {
+		if err := ValidateValue(value); err != nil {
+			return Requirement{}, fmt.Errorf("invalid label selector requirement %q: %w", term, err)
+		}
+	}
+	return requirement, nil
+}
+
+func parseOperator(term string) (Requirement, error) {
+	if matches := setRequirementRegex.FindStringSubmatch(term); matches != nil {
+		values := make([]string, 0)
+		for _, value := range strings.Split(matches[3], ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+		return Requirement{Key: matches[1], Operator: Operator(matches[2]), Values: values}, nil
+	}
+
+	if matches := existenceRegex.FindStringSubmatch(term); matches != nil {
+		if matches[1] == "!" {
+			return Requirement{Key: matches[2], Operator: DoesNotExist}, nil
+		}
+		return Requirement{Key: matches[2], Operator: Exists}, nil
+	}
+
+	if key, value, found := strings.Cut(term, "!="); found {
+		return Requirement{Key: strings.TrimSpace(key), Operator: NotEquals, Values: []string{strings.TrimSpace(value)}},
+			nil
+	}
+
+	if key, value, found := strings.Cut(term, "="); found {
+		value = strings.TrimPrefix(value, "=")
+		return Requirement{Key: strings.TrimSpace(key), Operator: Equals, Values: []string{strings.TrimSpace(value)}},
+			nil
+	}
+
+	return Requirement{}, fmt.Errorf("invalid label selector requirement %q", term)
+}
@@ -0,0 +1,155 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		selector    string
+		expected    Selector
+		expectedErr string
+	}{
+		"empty": {
+			selector: "",
+			expected: Selector{},
+		},
+		"equality": {
+			selector: "team=dsp",
+			expected: Selector{
+				{Key: "team", Operator: Equals, Values: []string{"dsp"}},
+			},
+		},
+		"double equals": {
+			selector: "team==dsp",
+			expected: Selector{
+				{Key: "team", Operator: Equals, Values: []string{"dsp"}},
+			},
+		},
+		"empty value": {
+			selector: "team=",
+			expected: Selector{
+				{Key: "team", Operator: Equals, Values: []string{""}},
+			},
+		},
+		"set based": {
+			selector: "env in (staging, production)",
+			expected: Selector{
+				{Key: "env", Operator: In, Values: []string{"staging", "production"}},
+			},
+		},
+		"multiple requirements": {
+			selector: "team=dsp, env in (staging,production),app=merlin",
+			expected: Selector{
+				{Key: "team", Operator: Equals, Values: []string{"dsp"}},
+				{Key: "env", Operator: In, Values: []string{"staging", "production"}},
+				{Key: "app", Operator: Equals, Values: []string{"merlin"}},
+			},
+		},
+		"inequality": {
+			selector: "team!=dsp",
+			expected: Selector{
+				{Key: "team", Operator: NotEquals, Values: []string{"dsp"}},
+			},
+		},
+		"set based exclusion": {
+			selector: "env notin (dev)",
+			expected: Selector{
+				{Key: "env", Operator: NotIn, Values: []string{"dev"}},
+			},
+		},
+		"existence": {
+			selector: "caraml.dev/team,!env",
+			expected: Selector{
+				{Key: "caraml.dev/team", Operator: Exists},
+				{Key: "env", Operator: DoesNotExist},
+			},
+		},
+		"unbalanced parentheses": {
+			selector:    "env in (staging,production",
+			expectedErr: `invalid label selector "env in (staging,production": unbalanced parentheses`,
+		},
+		"empty requirement": {
+			selector:    "team=dsp,,app=merlin",
+			expectedErr: "invalid label selector: empty requirement",
+		},
+		"unknown operator": {
+			selector:    "env within (dev)",
+			expectedErr: `invalid label selector requirement "env within (dev)"`,
+		},
+		"missing key": {
+			selector: "=dsp",
+			expectedErr: `invalid label selector requirement "=dsp": label key "" must have a name of at most 63 ` +
+				`characters, consisting of alphanumeric characters, '-', '_' or '.', and starting and ending with an ` +
+				`alphanumeric character`,
+		},
+		"invalid value": {
+			selector: "env in (dev,-prod)",
+			expectedErr: `invalid label selector requirement "env in (dev,-prod)": label value "-prod" must be at ` +
+				`most 63 characters, consisting of alphanumeric characters, '-', '_' or '.', and starting and ending ` +
+				`with an alphanumeric character`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selector)
+		})
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	labels := Set{"team": "dsp", "env": "production"}
+
+	tests := map[string]struct {
+		selector string
+		expected bool
+	}{
+		"empty selector":              {"", true},
+		"equality":                    {"team=dsp", true},
+		"equality mismatch":           {"team=growth", false},
+		"inequality":                  {"team!=growth", true},
+		"inequality of missing label": {"app!=merlin", true},
+		"set based":                   {"env in (staging,production)", true},
+		"set based mismatch":          {"env in (staging,dev)", false},
+		"set based exclusion":         {"env notin (production)", false},
+		"existence":                   {"team", true},
+		"non existence":               {"!team", false},
+		"non existence of missing":    {"!app", true},
+		"all requirements satisfied":  {"team=dsp,env,!app", true},
+		"one requirement unsatisfied": {"team=dsp,app", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selector.Matches(labels))
+		})
+	}
+}
+
+func TestSelector_String(t *testing.T) {
+	selector := Selector{
+		{Key: "team", Operator: Equals, Values: []string{"dsp"}},
+		{Key: "app", Operator: NotEquals, Values: []string{"merlin"}},
+		{Key: "env", Operator: In, Values: []string{"staging", "production"}},
+		{Key: "stream", Operator: NotIn, Values: []string{"dev"}},
+		{Key: "owner", Operator: Exists},
+		{Key: "deprecated", Operator: DoesNotExist},
+	}
+	expected := "team=dsp,app!=merlin,env in (staging,production),stream notin (dev),owner,!deprecated"
+	assert.Equal(t, expected, selector.String())
+
+	parsed, err := Parse(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, selector, parsed)
+}
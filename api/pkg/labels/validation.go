@@ -0,0 +1,51 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxNameLength is the maximum length of a label value and of the name segment of a label key
+	maxNameLength = 63
+	// maxPrefixLength is the maximum length of the optional prefix segment of a label key
+	maxPrefixLength = 253
+)
+
+var (
+	nameRegex   = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	prefixRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateKey checks that the key is a valid Kubernetes label key, i.e. a name of at most 63 alphanumeric,
+// '-', '_' or '.' characters, optionally prefixed by a DNS subdomain and a '/'.
+func ValidateKey(key string) error {
+	name := key
+	if prefix, suffix, found := strings.Cut(key, "/"); found {
+		if len(prefix) == 0 || len(prefix) > maxPrefixLength || !prefixRegex.MatchString(prefix) {
+			return fmt.Errorf("label key %q must have a prefix that is a valid DNS subdomain of at most %d characters",
+				key, maxPrefixLength)
+		}
+		name = suffix
+	}
+
+	if len(name) == 0 || len(name) > maxNameLength || !nameRegex.MatchString(name) {
+		return fmt.Errorf("label key %q must have a name of at most %d characters, consisting of alphanumeric "+
+			"characters, '-', '_' or '.', and starting and ending with an alphanumeric character", key, maxNameLength)
+	}
+	return nil
+}
+
+// ValidateValue checks that the value is a valid Kubernetes label value, i.e. either empty or at most 63
+// alphanumeric, '-', '_' or '.' characters, starting and ending with an alphanumeric character.
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > maxNameLength || !nameRegex.MatchString(value) {
+		return fmt.Errorf("label value %q must be at most %d characters, consisting of alphanumeric "+
+			"characters, '-', '_' or '.', and starting and ending with an alphanumeric character", value, maxNameLength)
+	}
+	return nil
+}
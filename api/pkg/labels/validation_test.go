@@ -0,0 +1,65 @@
+package labels
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := map[string]struct {
+		key     string
+		isValid bool
+	}{
+		"simple name":             {"team", true},
+		"name with symbols":       {"app.kubernetes_io-name", true},
+		"prefixed name":           {"caraml.dev/team", true},
+		"empty":                   {"", false},
+		"empty name":              {"caraml.dev/", false},
+		"empty prefix":            {"/team", false},
+		"uppercase prefix":        {"CaraML.dev/team", false},
+		"name starting with dash": {"-team", false},
+		"name ending with dot":    {"team.", false},
+		"name with space":         {"my team", false},
+		"name too long":           {strings.Repeat("a", 64), false},
+		"longest name":            {strings.Repeat("a", 63), true},
+		"prefix too long":         {strings.Repeat("a", 254) + "/team", false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateKey(tt.key)
+			if tt.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateValue(t *testing.T) {
+	tests := map[string]struct {
+		value   string
+		isValid bool
+	}{
+		"empty":                    {"", true},
+		"simple value":             {"dsp", true},
+		"value with symbols":       {"v1.2_3-rc", true},
+		"value with slash":         {"dsp/team", false},
+		"value starting with dash": {"-dsp", false},
+		"value too long":           {strings.Repeat("a", 64), false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateValue(tt.value)
+			if tt.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
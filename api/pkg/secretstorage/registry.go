@@ -43,3 +43,9 @@ func (r *Registry) Get(secretStorageID models.ID) (Client, bool) {
 	sc, ok := r.registry[secretStorageID]
 	return sc, ok
 }
+
+func (r *Registry) Delete(secretStorageID models.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.registry, secretStorageID)
+}
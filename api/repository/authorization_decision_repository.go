@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+)
+
+// likeEscaper escapes the wildcards of the LIKE patterns, so that a prefix only matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// AuthorizationDecisionFilter selects the authorization decisions to list
+type AuthorizationDecisionFilter struct {
+	Subject string
+	// Permission matches the decisions whose permission starts with the given prefix, e.g. mlp.projects.1.secrets
+	Permission string
+	Allowed    *bool
+	RequestID  string
+	After      *time.Time
+	Before     *time.Time
+	pagination.Options
+}
+
+type AuthorizationDecisionRepository interface {
+	// List returns the decisions matching the filter, most recent first, together with the total number of matches
+	List(filter AuthorizationDecisionFilter) ([]*models.AuthorizationDecision, int, error)
+	// Save records a new decision
+	Save(decision *models.AuthorizationDecision) error
+	// SaveAll records the decisions in a single statement. The IDs of the decisions are not set.
+	SaveAll(decisions []*models.AuthorizationDecision) error
+	// DeleteBefore deletes the decisions made before the given time and returns how many were deleted
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+type authorizationDecisionRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorizationDecisionRepository(db *gorm.DB) AuthorizationDecisionRepository {
+	return &authorizationDecisionRepository{db: db}
+}
+
+func (r *authorizationDecisionRepository) List(filter AuthorizationDecisionFilter) (
+	[]*models.AuthorizationDecision, int, error) {
+	query := r.db.Model(&models.AuthorizationDecision{})
+	if filter.Subject != "" {
+		query = query.Where("subject = ?", filter.Subject)
+	}
+	if filter.Permission != "" {
+		query = query.Where("permission LIKE ?", likeEscaper.Replace(filter.Permission)+"%")
+	}
+	if filter.Allowed != nil {
+		query = query.Where("allowed = ?", *filter.Allowed)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.After != nil {
+		query = query.Where("created_at >= ?", *filter.After)
+	}
+	if filter.Before != nil {
+		query = query.Where("created_at < ?", *filter.Before)
+	}
+
+	var count int
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Page != nil && filter.PageSize != nil {
+		query = query.Offset((*filter.Page - 1) * *filter.PageSize).Limit(*filter.PageSize)
+	}
+
+	var decisions []*models.AuthorizationDecision
+	err := query.Order("created_at desc").Order("id desc").Find(&decisions).Error
+	return decisions, count, err
+}
+
+func (r *authorizationDecisionRepository) Save(decision *models.AuthorizationDecision) error {
+	return r.db.Create(decision).Error
+}
+
+func (r *authorizationDecisionRepository) SaveAll(decisions []*models.AuthorizationDecision) error {
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	rows := make([]string, len(decisions))
+	values := make([]interface{}, 0, len(decisions)*8)
+	for i, decision := range decisions {
+		createdAt := decision.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now().UTC()
+		}
+		rows[i] = "(?, ?, ?, ?, ?, ?, ?, ?)"
+		values = append(values, decision.Subject, decision.Permission, decision.Allowed, decision.CacheHit,
+			decision.LatencyMs, decision.Error, decision.RequestID, createdAt)
+	}
+	return r.db.Exec(`INSERT INTO authorization_decisions
+		(subject, permission, allowed, cache_hit, latency_ms, error, request_id, created_at)
+		VALUES `+strings.Join(rows, ", "), values...).Error
+}
+
+func (r *authorizationDecisionRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.AuthorizationDecision{})
+	return result.RowsAffected, result.Error
+}
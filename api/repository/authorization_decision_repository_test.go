@@ -0,0 +1,84 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+)
+
+func TestAuthorizationDecisionRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		decisionRepository := NewAuthorizationDecisionRepository(db)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		for i, decision := range []*models.AuthorizationDecision{
+			{Subject: "alice@example.com", Permission: "mlp.projects.1.secrets.get", Allowed: true},
+			{Subject: "bob@example.com", Permission: "mlp.projects.1.secrets.get", Allowed: false},
+			{Subject: "alice@example.com", Permission: "mlp.projects.1.get", Allowed: true, CacheHit: true},
+			{Subject: "alice@example.com", Permission: "mlp.projects.2.secrets.get", Allowed: true},
+		} {
+			decision.RequestID = "request-id"
+			decision.LatencyMs = 1.5
+			decision.CreatedAt = now.Add(time.Duration(i-3) * time.Hour)
+			require.NoError(t, decisionRepository.Save(decision))
+		}
+
+		page, pageSize := int32(1), int32(10)
+		options := pagination.Options{Page: &page, PageSize: &pageSize}
+		decisions, count, err := decisionRepository.List(AuthorizationDecisionFilter{
+			Subject:    "alice@example.com",
+			Permission: "mlp.projects.1.",
+			Options:    options,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, "mlp.projects.1.get", decisions[0].Permission)
+		assert.True(t, decisions[0].CacheHit)
+		assert.Equal(t, "mlp.projects.1.secrets.get", decisions[1].Permission)
+		assert.Equal(t, "request-id", decisions[1].RequestID)
+		assert.Equal(t, 1.5, decisions[1].LatencyMs)
+
+		denied := false
+		decisions, count, err = decisionRepository.List(AuthorizationDecisionFilter{Allowed: &denied, Options: options})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, "bob@example.com", decisions[0].Subject)
+
+		after := now.Add(-90 * time.Minute)
+		_, count, err = decisionRepository.List(AuthorizationDecisionFilter{After: &after, Options: options})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		// the wildcards of the permission prefix match literally
+		require.NoError(t, decisionRepository.SaveAll([]*models.AuthorizationDecision{
+			{Subject: "carol@example.com", Permission: "mlp.projects.10.get", CreatedAt: now},
+			{Subject: "carol@example.com", Permission: "mlp.projects.1_0.get", CreatedAt: now},
+		}))
+		decisions, count, err = decisionRepository.List(AuthorizationDecisionFilter{
+			Permission: "mlp.projects.1_",
+			Options:    options,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, "mlp.projects.1_0.get", decisions[0].Permission)
+		_, count, err = decisionRepository.List(AuthorizationDecisionFilter{Permission: "%", Options: options})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		deleted, err := decisionRepository.DeleteBefore(now.Add(-90 * time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+		_, count, err = decisionRepository.List(AuthorizationDecisionFilter{Options: options})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, count)
+	})
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+// authorizationSyncClaimDuration is how long the syncs claimed by a worker are not applied by the other workers. It
+// must exceed the time taken to apply the policy of a project, after which another worker can apply the syncs again.
+const authorizationSyncClaimDuration = 5 * time.Minute
+
+type AuthorizationSyncRepository interface {
+	// ListByProject returns the syncs of the project which have not been applied yet, oldest first
+	ListByProject(projectID models.ID) ([]*models.AuthorizationSync, error)
+	// ListDueProjects returns the IDs of the projects with a sync whose next attempt is due at the given time, up to
+	// limit projects, those waiting the longest first
+	ListDueProjects(now time.Time, limit int) ([]models.ID, error)
+	// Apply claims the syncs of the project, so that they are not applied concurrently, and calls apply with them,
+	// oldest first, outside of a transaction. The syncs are deleted if apply succeeds, and the syncs recorded in the
+	// meantime are then applied the same way. Otherwise, the changes that apply made to them, e.g. the number of
+	// attempts, are saved and the error of apply is returned. The syncs of a project already claimed by another
+	// worker are left to that worker.
+	Apply(projectID models.ID, apply func(syncs []*models.AuthorizationSync) error) error
+}
+
+type authorizationSyncRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorizationSyncRepository(db *gorm.DB) AuthorizationSyncRepository {
+	return &authorizationSyncRepository{db: db}
+}
+
+func (r *authorizationSyncRepository) ListByProject(projectID models.ID) ([]*models.AuthorizationSync, error) {
+	var syncs []*models.AuthorizationSync
+	err := r.db.Where("project_id = ?", projectID).Order("id").Find(&syncs).Error
+	return syncs, err
+}
+
+func (r *authorizationSyncRepository) ListDueProjects(now time.Time, limit int) ([]models.ID, error) {
+	var projectIDs []models.ID
+	err := r.db.Model(&models.AuthorizationSync{}).
+		Where("next_attempt_at <= ?", now).
+		Group("project_id").
+		Order("min(next_attempt_at)").
+		Limit(limit).
+		Pluck("project_id", &projectIDs).Error
+	return projectIDs, err
+}
+
+func (r *authorizationSyncRepository) Apply(projectID models.ID,
+	apply func(syncs []*models.AuthorizationSync) error) error {
+	for {
+		syncs, err := r.claim(projectID)
+		if err != nil {
+			return err
+		}
+		if len(syncs) == 0 {
+			return nil
+		}
+		if err := r.complete(syncs, apply(syncs)); err != nil {
+			return err
+		}
+	}
+}
+
+// claim leases the syncs of the project to the caller, unless they are already leased to another worker, in which
+// case no sync is returned. The lease of a worker which stopped before completing the syncs expires after
+// authorizationSyncClaimDuration.
+func (r *authorizationSyncRepository) claim(projectID models.ID) ([]*models.AuthorizationSync, error) {
+	tx := r.db.Begin()
+	defer tx.RollbackUnlessCommitted()
+
+	var syncs []*models.AuthorizationSync
+	err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Where("project_id = ?", projectID).
+		Order("id").
+		Find(&syncs).Error
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, sync := range syncs {
+		if sync.ClaimedUntil != nil && sync.ClaimedUntil.After(now) {
+			return nil, tx.Commit().Error
+		}
+	}
+	if len(syncs) == 0 {
+		return nil, tx.Commit().Error
+	}
+
+	claimedUntil := now.Add(authorizationSyncClaimDuration)
+	ids := make([]models.ID, len(syncs))
+	for i, sync := range syncs {
+		ids[i] = sync.ID
+		sync.ClaimedUntil = &claimedUntil
+	}
+	err = tx.Model(&models.AuthorizationSync{}).
+		Where("id IN (?)", ids).
+		Update("claimed_until", claimedUntil).Error
+	if err != nil {
+		return nil, err
+	}
+	return syncs, tx.Commit().Error
+}
+
+// complete deletes the claimed syncs if they have been applied, otherwise it saves them and releases their claim
+// before returning the error of their application
+func (r *authorizationSyncRepository) complete(syncs []*models.AuthorizationSync, applyErr error) error {
+	tx := r.db.Begin()
+	defer tx.RollbackUnlessCommitted()
+
+	if applyErr == nil {
+		ids := make([]models.ID, len(syncs))
+		for i, sync := range syncs {
+			ids[i] = sync.ID
+		}
+		if err := tx.Where("id IN (?)", ids).Delete(&models.AuthorizationSync{}).Error; err != nil {
+			return err
+		}
+		return tx.Commit().Error
+	}
+
+	for _, sync := range syncs {
+		sync.ClaimedUntil = nil
+		if err := tx.Save(sync).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// createAuthorizationSync records a sync of the authorization policy of the project in the transaction
+func createAuthorizationSync(tx *gorm.DB, projectID models.ID, action models.AuthorizationSyncAction) error {
+	now := time.Now().UTC()
+	return tx.Create(&models.AuthorizationSync{
+		ProjectID:     projectID,
+		Action:        action,
+		Status:        models.AuthorizationSyncPending,
+		NextAttemptAt: now,
+	}).Error
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+)
+
+func TestAuthorizationSyncRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		projectRepository := NewProjectRepository(db)
+		syncRepository := NewAuthorizationSyncRepository(db)
+
+		project, err := projectRepository.SaveWith(&models.Project{
+			Name:           "project",
+			Administrators: []string{"user@example.com"},
+		}, ProjectWriteOptions{SyncAuthorization: true})
+		require.NoError(t, err)
+		project.Readers = []string{"reader@example.com"}
+		project, err = projectRepository.SaveWith(project, ProjectWriteOptions{SyncAuthorization: true})
+		require.NoError(t, err)
+		// the projects saved without a sync are not recorded in the outbox
+		other, err := projectRepository.Save(&models.Project{Name: "other"})
+		require.NoError(t, err)
+
+		syncs, err := syncRepository.ListByProject(project.ID)
+		require.NoError(t, err)
+		require.Len(t, syncs, 2)
+		assert.Equal(t, models.AuthorizationSyncUpdate, syncs[0].Action)
+		assert.Equal(t, models.AuthorizationSyncPending, syncs[0].Status)
+		syncs, err = syncRepository.ListByProject(other.ID)
+		require.NoError(t, err)
+		assert.Empty(t, syncs)
+
+		projectIDs, err := syncRepository.ListDueProjects(time.Now().UTC().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Equal(t, []models.ID{project.ID}, projectIDs)
+
+		// the changes made by a failed apply are saved
+		nextAttemptAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		err = syncRepository.Apply(project.ID, func(syncs []*models.AuthorizationSync) error {
+			for _, sync := range syncs {
+				sync.Attempts++
+				sync.Status = models.AuthorizationSyncFailed
+				sync.LastError = "keto is unavailable"
+				sync.NextAttemptAt = nextAttemptAt
+			}
+			return errors.New("keto is unavailable")
+		})
+		assert.EqualError(t, err, "keto is unavailable")
+		syncs, err = syncRepository.ListByProject(project.ID)
+		require.NoError(t, err)
+		require.Len(t, syncs, 2)
+		assert.Nil(t, syncs[1].ClaimedUntil)
+		assert.Equal(t, 1, syncs[1].Attempts)
+		assert.Equal(t, models.AuthorizationSyncFailed, syncs[1].Status)
+		assert.Equal(t, "keto is unavailable", syncs[1].LastError)
+		projectIDs, err = syncRepository.ListDueProjects(time.Now().UTC().Add(time.Minute), 10)
+		require.NoError(t, err)
+		assert.Empty(t, projectIDs)
+
+		// the syncs claimed by a worker are not applied by another one, and the syncs recorded while they are applied
+		// are applied once they are completed
+		calls := 0
+		err = syncRepository.Apply(project.ID, func(syncs []*models.AuthorizationSync) error {
+			calls++
+			if calls == 1 {
+				require.Len(t, syncs, 2)
+				assert.NotNil(t, syncs[0].ClaimedUntil)
+				err := syncRepository.Apply(project.ID, func(_ []*models.AuthorizationSync) error {
+					t.Error("the claimed syncs are applied concurrently")
+					return nil
+				})
+				assert.NoError(t, err)
+				_, err = projectRepository.SaveWith(project, ProjectWriteOptions{SyncAuthorization: true})
+				require.NoError(t, err)
+			} else {
+				require.Len(t, syncs, 1)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		syncs, err = syncRepository.ListByProject(project.ID)
+		require.NoError(t, err)
+		assert.Empty(t, syncs)
+
+		// the syncs are kept after the project is deleted, so that its policy is removed
+		require.NoError(t, projectRepository.DeleteWith(project.ID, ProjectWriteOptions{SyncAuthorization: true}))
+		err = syncRepository.Apply(project.ID, func(syncs []*models.AuthorizationSync) error {
+			require.Len(t, syncs, 1)
+			assert.Equal(t, models.AuthorizationSyncRemove, syncs[0].Action)
+			return nil
+		})
+		assert.NoError(t, err)
+		syncs, err = syncRepository.ListByProject(project.ID)
+		require.NoError(t, err)
+		assert.Empty(t, syncs)
+	})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type GroupRepository interface {
+	// List returns all groups ordered by name
+	List() ([]*models.Group, error)
+	// ListByMember returns the groups of which the user is a member, ordered by name
+	ListByMember(member string) ([]*models.Group, error)
+	Get(id models.ID) (*models.Group, error)
+	GetByName(name string) (*models.Group, error)
+	// Save creates a new group or updates an existing one
+	Save(group *models.Group) (*models.Group, error)
+	Delete(id models.ID) error
+}
+
+type groupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+func (r *groupRepository) List() ([]*models.Group, error) {
+	var groups []*models.Group
+	err := r.db.Order("name").Find(&groups).Error
+	return groups, err
+}
+
+func (r *groupRepository) ListByMember(member string) ([]*models.Group, error) {
+	var groups []*models.Group
+	err := r.db.Where("? = ANY(members)", member).Order("name").Find(&groups).Error
+	return groups, err
+}
+
+func (r *groupRepository) Get(id models.ID) (*models.Group, error) {
+	var group models.Group
+	if err := r.db.Where("id = ?", id).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("group with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) GetByName(name string) (*models.Group, error) {
+	var group models.Group
+	if err := r.db.Where("name = ?", name).First(&group).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("group with name %s not found", name)
+		}
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) Save(group *models.Group) (*models.Group, error) {
+	if err := r.db.Save(group).Error; err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (r *groupRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.Group{}).Error
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func TestGroupRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		groupRepository := NewGroupRepository(db)
+
+		growth, err := groupRepository.Save(&models.Group{
+			Name:    "growth",
+			Members: []string{"alice@example.com", "bob@example.com"},
+		})
+		require.NoError(t, err)
+		_, err = groupRepository.Save(&models.Group{
+			Name:    "credit-risk",
+			Members: []string{"alice@example.com"},
+			Owners:  []string{"owner@example.com"},
+		})
+		require.NoError(t, err)
+
+		groups, err := groupRepository.List()
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "credit-risk", groups[0].Name)
+		assert.Equal(t, []string{"owner@example.com"}, []string(groups[0].Owners))
+
+		groups, err = groupRepository.ListByMember("bob@example.com")
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, "growth", groups[0].Name)
+		groups, err = groupRepository.ListByMember("alice@example.com")
+		require.NoError(t, err)
+		assert.Len(t, groups, 2)
+
+		group, err := groupRepository.GetByName("growth")
+		require.NoError(t, err)
+		assert.Equal(t, growth.ID, group.ID)
+		_, err = groupRepository.GetByName("unknown")
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+
+		require.NoError(t, groupRepository.Delete(growth.ID))
+		_, err = groupRepository.Get(growth.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+	})
+}
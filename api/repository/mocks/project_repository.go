@@ -6,6 +6,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/caraml-dev/mlp/api/models"
+
+	repository "github.com/caraml-dev/mlp/api/repository"
 )
 
 // ProjectRepository is an autogenerated mock type for the ProjectRepository type
@@ -13,6 +15,34 @@ type ProjectRepository struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: projectID
+func (_m *ProjectRepository) Delete(projectID models.ID) error {
+	ret := _m.Called(projectID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ID) error); ok {
+		r0 = rf(projectID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWith provides a mock function with given fields: projectID, options
+func (_m *ProjectRepository) DeleteWith(projectID models.ID, options repository.ProjectWriteOptions) error {
+	ret := _m.Called(projectID, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ID, repository.ProjectWriteOptions) error); ok {
+		r0 = rf(projectID, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: projectID
 func (_m *ProjectRepository) Get(projectID models.ID) (*models.Project, error) {
 	ret := _m.Called(projectID)
@@ -91,30 +121,37 @@ func (_m *ProjectRepository) ListAll() ([]*models.Project, error) {
 	return r0, r1
 }
 
-// ListProjects provides a mock function with given fields: name
-func (_m *ProjectRepository) ListProjects(name string) ([]*models.Project, error) {
-	ret := _m.Called(name)
+// ListProjects provides a mock function with given fields: filter
+func (_m *ProjectRepository) ListProjects(filter repository.ProjectFilter) ([]*models.Project, int, error) {
+	ret := _m.Called(filter)
 
 	var r0 []*models.Project
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]*models.Project, error)); ok {
-		return rf(name)
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(repository.ProjectFilter) ([]*models.Project, int, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(string) []*models.Project); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(repository.ProjectFilter) []*models.Project); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Project)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(repository.ProjectFilter) int); ok {
+		r1 = rf(filter)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(repository.ProjectFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Save provides a mock function with given fields: project
@@ -143,6 +180,32 @@ func (_m *ProjectRepository) Save(project *models.Project) (*models.Project, err
 	return r0, r1
 }
 
+// SaveWith provides a mock function with given fields: project, options
+func (_m *ProjectRepository) SaveWith(project *models.Project, options repository.ProjectWriteOptions) (*models.Project, error) {
+	ret := _m.Called(project, options)
+
+	var r0 *models.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.Project, repository.ProjectWriteOptions) (*models.Project, error)); ok {
+		return rf(project, options)
+	}
+	if rf, ok := ret.Get(0).(func(*models.Project, repository.ProjectWriteOptions) *models.Project); ok {
+		r0 = rf(project, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.Project, repository.ProjectWriteOptions) error); ok {
+		r1 = rf(project, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewProjectRepository interface {
 	mock.TestingT
 	Cleanup(func())
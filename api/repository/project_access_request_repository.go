@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type ProjectAccessRequestRepository interface {
+	// List returns the access requests of a project, most recent first. Only the requests with the given status are
+	// returned if it is not empty.
+	List(projectID models.ID, status models.AccessRequestStatus) ([]*models.ProjectAccessRequest, error)
+	Get(projectID models.ID, id models.ID) (*models.ProjectAccessRequest, error)
+	// GetPending returns the pending access request of the requester in a project
+	GetPending(projectID models.ID, requester string) (*models.ProjectAccessRequest, error)
+	// Save creates a new access request or updates an existing one
+	Save(accessRequest *models.ProjectAccessRequest) (*models.ProjectAccessRequest, error)
+	// UpdateStatus saves the status and the review of the access request if its stored status is still the given
+	// one, so that concurrent reviews of a request cannot both succeed. It fails with an InvalidArgumentError
+	// otherwise.
+	UpdateStatus(accessRequest *models.ProjectAccessRequest, from models.AccessRequestStatus) error
+}
+
+type projectAccessRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectAccessRequestRepository(db *gorm.DB) ProjectAccessRequestRepository {
+	return &projectAccessRequestRepository{db: db}
+}
+
+func (r *projectAccessRequestRepository) List(projectID models.ID,
+	status models.AccessRequestStatus) ([]*models.ProjectAccessRequest, error) {
+	query := r.db.Where("project_id = ?", projectID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var accessRequests []*models.ProjectAccessRequest
+	err := query.Order("created_at desc").Order("id desc").Find(&accessRequests).Error
+	return accessRequests, err
+}
+
+func (r *projectAccessRequestRepository) Get(projectID models.ID, id models.ID) (*models.ProjectAccessRequest,
+	error) {
+	var accessRequest models.ProjectAccessRequest
+	if err := r.db.Where("project_id = ? AND id = ?", projectID, id).First(&accessRequest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("access request with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &accessRequest, nil
+}
+
+func (r *projectAccessRequestRepository) GetPending(projectID models.ID,
+	requester string) (*models.ProjectAccessRequest, error) {
+	var accessRequest models.ProjectAccessRequest
+	err := r.db.Where("project_id = ? AND requester = ? AND status = ?", projectID, requester,
+		models.AccessRequestPending).First(&accessRequest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("pending access request of %s not found", requester)
+		}
+		return nil, err
+	}
+	return &accessRequest, nil
+}
+
+func (r *projectAccessRequestRepository) Save(
+	accessRequest *models.ProjectAccessRequest) (*models.ProjectAccessRequest, error) {
+	if err := r.db.Save(accessRequest).Error; err != nil {
+		return nil, err
+	}
+	return accessRequest, nil
+}
+
+func (r *projectAccessRequestRepository) UpdateStatus(accessRequest *models.ProjectAccessRequest,
+	from models.AccessRequestStatus) error {
+	result := r.db.Model(&models.ProjectAccessRequest{}).
+		Where("id = ? AND status = ?", accessRequest.ID, from).
+		Updates(map[string]interface{}{
+			"status":         accessRequest.Status,
+			"reviewer":       accessRequest.Reviewer,
+			"review_comment": accessRequest.ReviewComment,
+			"reviewed_at":    accessRequest.ReviewedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewInvalidArgumentErrorf("access request %d is no longer %s", accessRequest.ID, from)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func TestProjectAccessRequestRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		project, err := NewProjectRepository(db).Save(&models.Project{Name: "project"})
+		require.NoError(t, err)
+		accessRequestRepository := NewProjectAccessRequestRepository(db)
+
+		denied, err := accessRequestRepository.Save(&models.ProjectAccessRequest{
+			ProjectID: project.ID,
+			Requester: "user@example.com",
+			Role:      models.ProjectAdministratorRole,
+			Status:    models.AccessRequestDenied,
+		})
+		require.NoError(t, err)
+		pending, err := accessRequestRepository.Save(&models.ProjectAccessRequest{
+			ProjectID: project.ID,
+			Requester: "user@example.com",
+			Role:      models.ProjectReaderRole,
+			Status:    models.AccessRequestPending,
+		})
+		require.NoError(t, err)
+
+		// a user can only have one pending request per project
+		_, err = accessRequestRepository.Save(&models.ProjectAccessRequest{
+			ProjectID: project.ID,
+			Requester: "user@example.com",
+			Role:      models.ProjectAdministratorRole,
+			Status:    models.AccessRequestPending,
+		})
+		assert.Error(t, err)
+
+		accessRequests, err := accessRequestRepository.List(project.ID, "")
+		require.NoError(t, err)
+		require.Len(t, accessRequests, 2)
+		assert.Equal(t, pending.ID, accessRequests[0].ID)
+		assert.Equal(t, denied.ID, accessRequests[1].ID)
+		accessRequests, err = accessRequestRepository.List(project.ID, models.AccessRequestDenied)
+		require.NoError(t, err)
+		require.Len(t, accessRequests, 1)
+
+		accessRequest, err := accessRequestRepository.GetPending(project.ID, "user@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, pending.ID, accessRequest.ID)
+		_, err = accessRequestRepository.GetPending(project.ID, "other@example.com")
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+		_, err = accessRequestRepository.Get(project.ID+1, pending.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+
+		// only the first of two concurrent reviews succeeds
+		reviewed := *pending
+		reviewed.Status = models.AccessRequestApproved
+		reviewed.Reviewer = "admin@example.com"
+		require.NoError(t, accessRequestRepository.UpdateStatus(&reviewed, models.AccessRequestPending))
+		reviewed.Status = models.AccessRequestDenied
+		err = accessRequestRepository.UpdateStatus(&reviewed, models.AccessRequestPending)
+		assert.True(t, errors.Is(err, &apperrors.InvalidArgumentError{}))
+		accessRequest, err = accessRequestRepository.Get(project.ID, pending.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.AccessRequestApproved, accessRequest.Status)
+		assert.Equal(t, "admin@example.com", accessRequest.Reviewer)
+	})
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+)
+
+type ProjectAuditLogRepository interface {
+	// List returns the audit logs of a project, most recent first, together with the total number of audit logs
+	List(projectID models.ID, options pagination.Options) ([]*models.ProjectAuditLog, int, error)
+	// Save records a new audit log
+	Save(auditLog *models.ProjectAuditLog) (*models.ProjectAuditLog, error)
+}
+
+type projectAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectAuditLogRepository(db *gorm.DB) ProjectAuditLogRepository {
+	return &projectAuditLogRepository{
+		db: db,
+	}
+}
+
+// List returns the audit logs of a project, most recent first, together with the total number of audit logs
+func (r *projectAuditLogRepository) List(projectID models.ID, options pagination.Options) ([]*models.ProjectAuditLog,
+	int, error) {
+	query := r.db.Model(&models.ProjectAuditLog{}).Where("project_id = ?", projectID)
+
+	var count int
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if options.Page != nil && options.PageSize != nil {
+		query = query.Offset((*options.Page - 1) * *options.PageSize).Limit(*options.PageSize)
+	}
+
+	var auditLogs []*models.ProjectAuditLog
+	err := query.Order("created_at desc").Order("id desc").Find(&auditLogs).Error
+	return auditLogs, count, err
+}
+
+// Save records a new audit log
+func (r *projectAuditLogRepository) Save(auditLog *models.ProjectAuditLog) (*models.ProjectAuditLog, error) {
+	if err := r.db.Create(auditLog).Error; err != nil {
+		return nil, err
+	}
+	return auditLog, nil
+}
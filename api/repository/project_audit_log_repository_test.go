@@ -0,0 +1,91 @@
+//go:build integration
+
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+)
+
+func TestProjectAuditLogRepository_SaveAndList(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		auditLogRepository := NewProjectAuditLogRepository(db)
+
+		for _, action := range []models.ProjectAuditAction{
+			models.ProjectCreatedAction,
+			models.ProjectUpdatedAction,
+			models.ProjectArchivedAction,
+		} {
+			_, err := auditLogRepository.Save(&models.ProjectAuditLog{
+				ProjectID: 1,
+				Action:    action,
+				Actor:     "user@example.com",
+				RequestID: "request-id",
+				Changes: models.ProjectChanges{
+					"team": {Before: "dsp", After: "growth"},
+				},
+			})
+			assert.NoError(t, err)
+		}
+		_, err := auditLogRepository.Save(&models.ProjectAuditLog{ProjectID: 2, Action: models.ProjectCreatedAction})
+		assert.NoError(t, err)
+
+		page, pageSize := int32(1), int32(2)
+		auditLogs, count, err := auditLogRepository.List(1, pagination.Options{Page: &page, PageSize: &pageSize})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Len(t, auditLogs, 2)
+		assert.Equal(t, models.ProjectArchivedAction, auditLogs[0].Action)
+		assert.Equal(t, models.ProjectUpdatedAction, auditLogs[1].Action)
+		assert.Equal(t, "user@example.com", auditLogs[0].Actor)
+		assert.Equal(t, "request-id", auditLogs[0].RequestID)
+		assert.Equal(t, models.ProjectChanges{"team": {Before: "dsp", After: "growth"}}, auditLogs[0].Changes)
+
+		page = 2
+		auditLogs, _, err = auditLogRepository.List(1, pagination.Options{Page: &page, PageSize: &pageSize})
+		assert.NoError(t, err)
+		assert.Len(t, auditLogs, 1)
+		assert.Equal(t, models.ProjectCreatedAction, auditLogs[0].Action)
+	})
+}
+
+func TestProjectAuditLogRepository_WrittenWithProject(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		projectRepository := NewProjectRepository(db)
+		auditLogRepository := NewProjectAuditLogRepository(db)
+
+		project, err := projectRepository.SaveWith(&models.Project{Name: "project"}, ProjectWriteOptions{
+			AuditLog: &models.ProjectAuditLog{Action: models.ProjectCreatedAction, Actor: "user@example.com"},
+		})
+		assert.NoError(t, err)
+
+		// the project is not updated if its audit log cannot be recorded
+		project.Team = "dsp"
+		_, err = projectRepository.SaveWith(project, ProjectWriteOptions{
+			AuditLog: &models.ProjectAuditLog{Action: models.ProjectUpdatedAction, Actor: strings.Repeat("a", 1024)},
+		})
+		assert.Error(t, err)
+		saved, err := projectRepository.Get(project.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, saved.Team)
+
+		err = projectRepository.DeleteWith(project.ID, ProjectWriteOptions{
+			AuditLog: &models.ProjectAuditLog{Action: models.ProjectDeletedAction, Actor: "user@example.com"},
+		})
+		assert.NoError(t, err)
+
+		auditLogs, count, err := auditLogRepository.List(project.ID, pagination.Options{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, models.ProjectDeletedAction, auditLogs[0].Action)
+		assert.Equal(t, models.ProjectCreatedAction, auditLogs[1].Action)
+		assert.Equal(t, project.ID, auditLogs[1].ProjectID)
+	})
+}
@@ -1,20 +1,126 @@
 package repository
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
 
 	"github.com/caraml-dev/mlp/api/models"
 	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/labels"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
 )
 
+// projectSortFields are the columns that projects can be sorted by
+var projectSortFields = map[string]bool{
+	"id":         true,
+	"name":       true,
+	"team":       true,
+	"stream":     true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// ProjectFilter contains the criteria used to filter, sort and paginate projects.
+// Zero-valued fields are ignored.
+type ProjectFilter struct {
+	// Name matches projects whose name starts with the given prefix
+	Name          string
+	Team          string
+	Stream        string
+	LabelSelector labels.Selector
+	Administrator string
+	Reader        string
+	// Member matches projects in which the user is either an administrator or a reader
+	Member string
+	// MemberGroups extends Member to the projects granted to any of the given group subjects, e.g. group:credit-risk
+	MemberGroups []string
+	// Subject matches projects in which the subject is an administrator, a reader or a secret reader
+	Subject string
+	// IncludeArchived also matches the archived projects, which are excluded otherwise
+	IncludeArchived bool
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	// MembershipExpiredBefore only matches the projects in which a membership expired at or before the given time
+	MembershipExpiredBefore *time.Time
+	// Sort is a comma separated list of fields to sort by. Prefix a field with "-" to sort in descending order.
+	Sort string
+	pagination.Options
+}
+
+// orderBy translates the sort expression of the filter into an ORDER BY clause
+func (filter ProjectFilter) orderBy() (string, error) {
+	if strings.TrimSpace(filter.Sort) == "" {
+		return "name asc", nil
+	}
+
+	clauses := make([]string, 0)
+	for _, field := range strings.Split(filter.Sort, ",") {
+		field = strings.TrimSpace(field)
+		direction := "asc"
+		if strings.HasPrefix(field, "-") {
+			field = strings.TrimPrefix(field, "-")
+			direction = "desc"
+		}
+		if !projectSortFields[field] {
+			return "", apperrors.NewInvalidArgumentErrorf("unable to sort projects by %s", field)
+		}
+		clauses = append(clauses, fmt.Sprintf("%s %s", field, direction))
+	}
+	return strings.Join(clauses, ", "), nil
+}
+
 type ProjectRepository interface {
 	ListAll() ([]*models.Project, error)
-	ListProjects(name string) ([]*models.Project, error)
+	// ListProjects returns the projects matching the filter along with the total number of matching projects,
+	// ignoring pagination. Archived projects are excluded unless the filter includes them.
+	ListProjects(filter ProjectFilter) ([]*models.Project, int, error)
 	Get(projectID models.ID) (*models.Project, error)
 	GetByName(projectName string) (*models.Project, error)
+	// Save creates a new project or updates an existing one. An update fails with a PreconditionFailedError if the
+	// project has been modified since it was read, i.e. its version differs from the stored version.
 	Save(project *models.Project) (*models.Project, error)
+	// Delete deletes a project together with its secrets and project-scoped secret storages
+	Delete(projectID models.ID) error
+	// SaveWith saves the project like Save and writes the records of the options in the same transaction
+	SaveWith(project *models.Project, options ProjectWriteOptions) (*models.Project, error)
+	// DeleteWith deletes the project like Delete and writes the records of the options in the same transaction
+	DeleteWith(projectID models.ID, options ProjectWriteOptions) error
+}
+
+// ProjectWriteOptions are the records written in the same transaction as a project, so that they are only written if
+// the project is, and vice versa
+type ProjectWriteOptions struct {
+	// AuditLog is recorded if it is set. Its project ID is set to the ID of the project once it is saved.
+	AuditLog *models.ProjectAuditLog
+	// SyncAuthorization records an authorization sync updating the authorization policy of the saved project, or
+	// removing the policy of the deleted project
+	SyncAuthorization bool
+}
+
+// write writes the records of the options in the transaction of the project
+func (options ProjectWriteOptions) write(
+	tx *gorm.DB,
+	projectID models.ID,
+	action models.AuthorizationSyncAction,
+) error {
+	if options.AuditLog != nil {
+		options.AuditLog.ProjectID = projectID
+		if err := tx.Create(options.AuditLog).Error; err != nil {
+			return err
+		}
+	}
+	if options.SyncAuthorization {
+		return createAuthorizationSync(tx, projectID, action)
+	}
+	return nil
 }
 
 type projectRepository struct {
@@ -33,9 +139,112 @@ func (storage *projectRepository) ListAll() ([]*models.Project, error) {
 	return projects, nil
 }
 
-func (storage *projectRepository) ListProjects(name string) (projects []*models.Project, err error) {
-	err = storage.db.Where("name LIKE ?", name+"%").Find(&projects).Error
-	return
+func (storage *projectRepository) ListProjects(filter ProjectFilter) ([]*models.Project, int, error) {
+	orderBy, err := filter.orderBy()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := storage.db.Model(&models.Project{}).Where("name LIKE ?", filter.Name+"%")
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	if filter.Team != "" {
+		query = query.Where("team = ?", filter.Team)
+	}
+	if filter.Stream != "" {
+		query = query.Where("stream = ?", filter.Stream)
+	}
+	if filter.Administrator != "" {
+		query = query.Where("? = ANY(administrators)", filter.Administrator)
+	}
+	if filter.Reader != "" {
+		query = query.Where("? = ANY(readers)", filter.Reader)
+	}
+	if filter.Member != "" && len(filter.MemberGroups) > 0 {
+		subjects := pq.StringArray(append([]string{filter.Member}, filter.MemberGroups...))
+		query = query.Where("(administrators && ?::varchar[] OR readers && ?::varchar[])", subjects, subjects)
+	} else if filter.Member != "" {
+		query = query.Where("(? = ANY(administrators) OR ? = ANY(readers))", filter.Member, filter.Member)
+	}
+	if filter.Subject != "" {
+		query = query.Where("(? = ANY(administrators) OR ? = ANY(readers) OR ? = ANY(secret_readers))",
+			filter.Subject, filter.Subject, filter.Subject)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		query = query.Where("updated_at >= ?", *filter.UpdatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedBefore)
+	}
+	if filter.MembershipExpiredBefore != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM jsonb_array_elements(member_expiries) AS expiry "+
+			"WHERE (expiry->>'expires_at')::timestamptz <= ?)", *filter.MembershipExpiredBefore)
+	}
+	for _, requirement := range filter.LabelSelector {
+		query, err = whereLabelRequirement(query, requirement)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var count int
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order(orderBy)
+	if filter.Page != nil && filter.PageSize != nil {
+		query = query.Offset((*filter.Page - 1) * *filter.PageSize).Limit(*filter.PageSize)
+	}
+
+	var projects []*models.Project
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+	return projects, count, nil
+}
+
+// whereLabelRequirement adds a condition on the labels column matching the label requirement. The conditions are
+// expressed with the jsonb containment operator so that they can make use of the GIN index on the labels column.
+func whereLabelRequirement(query *gorm.DB, requirement labels.Requirement) (*gorm.DB, error) {
+	var containedLabels []interface{}
+	switch requirement.Operator {
+	case labels.Exists, labels.DoesNotExist:
+		containedLabels = append(containedLabels, []map[string]string{{"key": requirement.Key}})
+	default:
+		for _, value := range requirement.Values {
+			containedLabels = append(containedLabels, models.Labels{{Key: requirement.Key, Value: value}})
+		}
+	}
+
+	conditions := make([]string, 0)
+	values := make([]interface{}, 0)
+	for _, containedLabel := range containedLabels {
+		label, err := json.Marshal(containedLabel)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "labels @> ?::jsonb")
+		values = append(values, string(label))
+	}
+	condition := "(" + strings.Join(conditions, " OR ") + ")"
+
+	switch requirement.Operator {
+	case labels.Equals, labels.In, labels.Exists:
+		return query.Where(condition, values...), nil
+	case labels.NotEquals, labels.NotIn, labels.DoesNotExist:
+		// projects without any labels have NULL labels, which satisfy the negated requirements
+		return query.Where("NOT COALESCE("+condition+", false)", values...), nil
+	default:
+		return nil, apperrors.NewInvalidArgumentErrorf("unsupported label selector operator %s", requirement.Operator)
+	}
 }
 
 func (storage *projectRepository) Get(projectID models.ID) (*models.Project, error) {
@@ -63,8 +272,65 @@ func (storage *projectRepository) GetByName(projectName string) (*models.Project
 }
 
 func (storage *projectRepository) Save(project *models.Project) (*models.Project, error) {
-	if err := storage.db.Save(project).Error; err != nil {
+	return storage.SaveWith(project, ProjectWriteOptions{})
+}
+
+func (storage *projectRepository) SaveWith(project *models.Project, options ProjectWriteOptions) (*models.Project,
+	error) {
+	tx := storage.db.Begin()
+	defer tx.RollbackUnlessCommitted()
+
+	if project.ID != 0 {
+		var existing models.Project
+		err := tx.Set("gorm:query_option", "FOR UPDATE").
+			Select("version").
+			Where("id = ?", project.ID).
+			First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil && existing.Version != project.Version {
+			return nil, apperrors.NewPreconditionFailedErrorf(
+				"project %s has been modified by another request, expected version %d but found %d",
+				project.Name, project.Version, existing.Version)
+		}
+	}
+
+	project.Version++
+	if err := tx.Save(project).Error; err != nil {
+		project.Version--
+		return nil, err
+	}
+	if err := options.write(tx, project.ID, models.AuthorizationSyncUpdate); err != nil {
+		project.Version--
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		project.Version--
 		return nil, err
 	}
 	return project, nil
 }
+
+func (storage *projectRepository) Delete(projectID models.ID) error {
+	return storage.DeleteWith(projectID, ProjectWriteOptions{})
+}
+
+func (storage *projectRepository) DeleteWith(projectID models.ID, options ProjectWriteOptions) error {
+	tx := storage.db.Begin()
+	defer tx.RollbackUnlessCommitted()
+
+	if err := tx.Where("project_id = ?", projectID).Delete(models.Secret{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("project_id = ?", projectID).Delete(models.SecretStorage{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id = ?", projectID).Delete(models.Project{}).Error; err != nil {
+		return err
+	}
+	if err := options.write(tx, projectID, models.AuthorizationSyncRemove); err != nil {
+		return err
+	}
+	return tx.Commit().Error
+}
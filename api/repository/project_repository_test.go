@@ -4,12 +4,16 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/caraml-dev/mlp/api/it/database"
 	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/labels"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
 )
 
 func TestProjectsRepository_SaveAndGet(t *testing.T) {
@@ -112,17 +116,257 @@ func TestProjectsRepository_List(t *testing.T) {
 			_, _ = projectStorage.Save(&p)
 		}
 
-		res, err := projectStorage.ListProjects("")
+		res, _, err := projectStorage.ListProjects(ProjectFilter{})
 		assert.NoError(t, err)
 		assert.Len(t, res, len(projects))
 
-		res, err = projectStorage.ListProjects("my-project")
+		res, _, err = projectStorage.ListProjects(ProjectFilter{Name: "my-project"})
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
 		assert.Equal(t, "my-project", res[0].Name)
 
-		res, err = projectStorage.ListProjects("unknown-project")
+		res, _, err = projectStorage.ListProjects(ProjectFilter{Name: "unknown-project"})
 		assert.NoError(t, err)
 		assert.Len(t, res, 0)
+
+		archived, err := projectStorage.GetByName("my-project")
+		assert.NoError(t, err)
+		archivedAt := time.Now()
+		archived.ArchivedAt = &archivedAt
+		_, err = projectStorage.Save(archived)
+		assert.NoError(t, err)
+
+		res, _, err = projectStorage.ListProjects(ProjectFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, res, len(projects)-1)
+
+		res, _, err = projectStorage.ListProjects(ProjectFilter{Name: "my-project"})
+		assert.NoError(t, err)
+		assert.Len(t, res, 0)
+
+		res1, err := projectStorage.Get(archived.ID)
+		assert.NoError(t, err)
+		assert.True(t, res1.IsArchived())
+	})
+}
+
+func TestProjectsRepository_ListWithFilter(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		projectStorage := NewProjectRepository(db)
+
+		archivedAt := time.Now()
+		projects := []models.Project{
+			{
+				Name:           "project-a",
+				Administrators: []string{"admin-a@example.com"},
+				Readers:        []string{"reader@example.com"},
+				MemberExpiries: models.ProjectMemberExpiries{
+					{Member: "reader@example.com", Role: models.ProjectReaderRole, ExpiresAt: time.Now().Add(time.Minute)},
+				},
+				Team:   "dsp",
+				Stream: "dsp",
+				Labels: models.Labels{{Key: "env", Value: "production"}, {Key: "app", Value: "merlin"}},
+			},
+			{
+				Name:           "project-b",
+				Administrators: []string{"admin-b@example.com"},
+				Team:           "dsp",
+				Stream:         "pricing",
+				Labels:         models.Labels{{Key: "env", Value: "staging"}},
+			},
+			{
+				Name:           "project-c",
+				Administrators: []string{"admin-a@example.com"},
+				Readers:        []string{"group:growth"},
+				Team:           "growth",
+				Stream:         "pricing",
+				Labels:         models.Labels{{Key: "env", Value: "dev"}},
+			},
+			{
+				Name:          "project-d",
+				SecretReaders: []string{"serviceaccount:1"},
+				Team:          "growth",
+				Stream:        "pricing",
+				ArchivedAt:    &archivedAt,
+			},
+		}
+		for _, p := range projects {
+			_, err := projectStorage.Save(&p)
+			assert.NoError(t, err)
+		}
+
+		envSelector, err := labels.Parse("env in (production,staging)")
+		assert.NoError(t, err)
+		appSelector, err := labels.Parse("env=production,app=merlin")
+		assert.NoError(t, err)
+		notInSelector, err := labels.Parse("env notin (production,staging)")
+		assert.NoError(t, err)
+		notEqualsSelector, err := labels.Parse("env!=dev")
+		assert.NoError(t, err)
+		existsSelector, err := labels.Parse("app")
+		assert.NoError(t, err)
+		doesNotExistSelector, err := labels.Parse("!app")
+		assert.NoError(t, err)
+		one, two := int32(1), int32(2)
+		now, future := time.Now(), time.Now().Add(time.Hour)
+
+		tests := []struct {
+			name        string
+			filter      ProjectFilter
+			expProjects []string
+			expCount    int
+			expErr      string
+		}{
+			{"by team", ProjectFilter{Team: "dsp"}, []string{"project-a", "project-b"}, 2, ""},
+			{"by stream", ProjectFilter{Stream: "pricing"}, []string{"project-b", "project-c"}, 2, ""},
+			{"by set based label selector", ProjectFilter{LabelSelector: envSelector},
+				[]string{"project-a", "project-b"}, 2, ""},
+			{"by multiple label requirements", ProjectFilter{LabelSelector: appSelector}, []string{"project-a"}, 1, ""},
+			{"by set based exclusion", ProjectFilter{LabelSelector: notInSelector}, []string{"project-c"}, 1, ""},
+			{"by inequality", ProjectFilter{LabelSelector: notEqualsSelector}, []string{"project-a", "project-b"}, 2, ""},
+			{"by label existence", ProjectFilter{LabelSelector: existsSelector}, []string{"project-a"}, 1, ""},
+			{"by label absence", ProjectFilter{LabelSelector: doesNotExistSelector},
+				[]string{"project-b", "project-c"}, 2, ""},
+			{"by administrator", ProjectFilter{Administrator: "admin-a@example.com"},
+				[]string{"project-a", "project-c"}, 2, ""},
+			{"by reader", ProjectFilter{Reader: "reader@example.com"}, []string{"project-a"}, 1, ""},
+			{"by member", ProjectFilter{Member: "reader@example.com"}, []string{"project-a"}, 1, ""},
+			{"by member or group", ProjectFilter{Member: "reader@example.com", MemberGroups: []string{"group:growth"}},
+				[]string{"project-a", "project-c"}, 2, ""},
+			{"by subject", ProjectFilter{Subject: "serviceaccount:1"}, []string{}, 0, ""},
+			{"by subject including archived projects", ProjectFilter{Subject: "serviceaccount:1", IncludeArchived: true},
+				[]string{"project-d"}, 1, ""},
+			{"by created range", ProjectFilter{CreatedAfter: &future}, []string{}, 0, ""},
+			{"by expired membership", ProjectFilter{MembershipExpiredBefore: &future}, []string{"project-a"}, 1, ""},
+			{"by membership not yet expired", ProjectFilter{MembershipExpiredBefore: &now}, []string{}, 0, ""},
+			{"by updated range", ProjectFilter{UpdatedBefore: &future}, []string{"project-a", "project-b", "project-c"},
+				3, ""},
+			{"sorted descending", ProjectFilter{Sort: "-name"}, []string{"project-c", "project-b", "project-a"}, 3, ""},
+			{"sorted by multiple fields", ProjectFilter{Sort: "team,-name"},
+				[]string{"project-b", "project-a", "project-c"}, 3, ""},
+			{"paginated", ProjectFilter{Options: pagination.Options{Page: &two, PageSize: &two}},
+				[]string{"project-c"}, 3, ""},
+			{"first page", ProjectFilter{Stream: "pricing", Options: pagination.Options{Page: &one, PageSize: &one}},
+				[]string{"project-b"}, 2, ""},
+			{"invalid sort field", ProjectFilter{Sort: "administrators"}, nil, 0,
+				"unable to sort projects by administrators"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res, count, err := projectStorage.ListProjects(tt.filter)
+				if tt.expErr != "" {
+					assert.EqualError(t, err, tt.expErr)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expCount, count)
+				names := make([]string, 0)
+				for _, p := range res {
+					names = append(names, p.Name)
+				}
+				assert.Equal(t, tt.expProjects, names)
+			})
+		}
+	})
+}
+
+func TestProjectsRepository_SaveStaleVersion(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		projectStorage := NewProjectRepository(db)
+
+		project, err := projectStorage.Save(&models.Project{
+			Name:           "project_1",
+			Administrators: []string{"user@example.com"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, project.Version)
+
+		first, err := projectStorage.Get(project.ID)
+		assert.NoError(t, err)
+		second, err := projectStorage.Get(project.ID)
+		assert.NoError(t, err)
+
+		first.Team = "dsp"
+		first, err = projectStorage.Save(first)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, first.Version)
+
+		second.Team = "growth"
+		_, err = projectStorage.Save(second)
+		assert.ErrorIs(t, err, &apperrors.PreconditionFailedError{})
+		assert.Equal(t, 1, second.Version)
+
+		stored, err := projectStorage.Get(project.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "dsp", stored.Team)
+		assert.Equal(t, 2, stored.Version)
+	})
+}
+
+func TestProjectsRepository_Delete(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		projectStorage := NewProjectRepository(db)
+		secretStorageRepository := NewSecretStorageRepository(db)
+		secretRepository := NewSecretRepository(db)
+
+		project, err := projectStorage.Save(&models.Project{
+			Name:           "project_1",
+			Administrators: []string{"user@example.com"},
+		})
+		assert.NoError(t, err)
+		otherProject, err := projectStorage.Save(&models.Project{
+			Name:           "project_2",
+			Administrators: []string{"user@example.com"},
+		})
+		assert.NoError(t, err)
+
+		secretStorage, err := secretStorageRepository.Save(&models.SecretStorage{
+			Name:      "project-secret-storage",
+			Type:      models.VaultSecretStorageType,
+			Scope:     models.ProjectSecretStorageScope,
+			ProjectID: &project.ID,
+			Config: models.SecretStorageConfig{
+				VaultConfig: &models.VaultConfig{
+					URL:        "http://localhost:8200",
+					MountPath:  "secret",
+					PathPrefix: "secret-storage-test",
+					AuthMethod: models.TokenAuthMethod,
+					Token:      "root",
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		// internal secret storage always have ID 1
+		internalSecretStorageID := models.ID(1)
+		_, err = secretRepository.Save(&models.Secret{
+			ProjectID:       project.ID,
+			SecretStorageID: &secretStorage.ID,
+			Name:            "secret",
+		})
+		assert.NoError(t, err)
+		_, err = secretRepository.Save(&models.Secret{
+			ProjectID:       otherProject.ID,
+			SecretStorageID: &internalSecretStorageID,
+			Name:            "secret",
+		})
+		assert.NoError(t, err)
+
+		err = projectStorage.Delete(project.ID)
+		assert.NoError(t, err)
+
+		_, err = projectStorage.Get(project.ID)
+		assert.ErrorIs(t, err, &apperrors.NotFoundError{})
+
+		_, err = secretStorageRepository.Get(secretStorage.ID)
+		assert.Error(t, err)
+
+		secrets, err := secretRepository.List(project.ID)
+		assert.NoError(t, err)
+		assert.Len(t, secrets, 0)
+
+		secrets, err = secretRepository.List(otherProject.ID)
+		assert.NoError(t, err)
+		assert.Len(t, secrets, 1)
 	})
 }
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type RoleRepository interface {
+	// List returns all custom roles ordered by name
+	List() ([]*models.Role, error)
+	Get(id models.ID) (*models.Role, error)
+	GetByName(name string) (*models.Role, error)
+	// Save creates a new role or updates an existing one
+	Save(role *models.Role) (*models.Role, error)
+	// Delete deletes the role together with its assignments
+	Delete(id models.ID) error
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) List() ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.db.Order("name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) Get(id models.ID) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Where("id = ?", id).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("role with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) GetByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Where("name = ?", name).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("role with name %s not found", name)
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Save(role *models.Role) (*models.Role, error) {
+	if err := r.db.Save(role).Error; err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *roleRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.Role{}).Error
+}
+
+type RoleAssignmentRepository interface {
+	// ListByProject returns the assignments of custom roles in a project, together with their roles
+	ListByProject(projectID models.ID) ([]*models.RoleAssignment, error)
+	// ListByRole returns the assignments of a custom role in all projects
+	ListByRole(roleID models.ID) ([]*models.RoleAssignment, error)
+	// ListByMember returns the assignments of custom roles to the member in all projects
+	ListByMember(member string) ([]*models.RoleAssignment, error)
+	// Get returns the assignment of the role in the project
+	Get(roleID models.ID, projectID models.ID) (*models.RoleAssignment, error)
+	// Save creates a new assignment or updates an existing one
+	Save(assignment *models.RoleAssignment) (*models.RoleAssignment, error)
+	Delete(id models.ID) error
+}
+
+type roleAssignmentRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleAssignmentRepository(db *gorm.DB) RoleAssignmentRepository {
+	return &roleAssignmentRepository{db: db}
+}
+
+func (r *roleAssignmentRepository) ListByProject(projectID models.ID) ([]*models.RoleAssignment, error) {
+	var assignments []*models.RoleAssignment
+	err := r.db.Preload("Role").Where("project_id = ?", projectID).Order("id").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *roleAssignmentRepository) ListByRole(roleID models.ID) ([]*models.RoleAssignment, error) {
+	var assignments []*models.RoleAssignment
+	err := r.db.Where("role_id = ?", roleID).Order("project_id").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *roleAssignmentRepository) ListByMember(member string) ([]*models.RoleAssignment, error) {
+	var assignments []*models.RoleAssignment
+	err := r.db.Where("? = ANY(members)", member).Order("project_id").Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *roleAssignmentRepository) Get(roleID models.ID, projectID models.ID) (*models.RoleAssignment, error) {
+	var assignment models.RoleAssignment
+	err := r.db.Preload("Role").Where("role_id = ? AND project_id = ?", roleID, projectID).First(&assignment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("role with ID %d is not assigned in project with ID %d", roleID,
+				projectID)
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *roleAssignmentRepository) Save(assignment *models.RoleAssignment) (*models.RoleAssignment, error) {
+	if err := r.db.Omit("Role").Save(assignment).Error; err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (r *roleAssignmentRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.RoleAssignment{}).Error
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func TestRoleRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		project, err := NewProjectRepository(db).Save(&models.Project{Name: "project"})
+		require.NoError(t, err)
+		roleRepository := NewRoleRepository(db)
+		assignmentRepository := NewRoleAssignmentRepository(db)
+
+		role, err := roleRepository.Save(&models.Role{
+			Name:        "deployer",
+			Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"},
+		})
+		require.NoError(t, err)
+		_, err = roleRepository.Save(&models.Role{Name: "deployer", Permissions: []string{}})
+		assert.Error(t, err)
+
+		found, err := roleRepository.GetByName("deployer")
+		require.NoError(t, err)
+		assert.Equal(t, role.ID, found.ID)
+		assert.Equal(t, []string{"mlp.projects.{{ .ProjectId }}.get"}, []string(found.Permissions))
+
+		assignment, err := assignmentRepository.Save(&models.RoleAssignment{
+			RoleID:    role.ID,
+			ProjectID: project.ID,
+			Members:   []string{"deployer@example.com"},
+		})
+		require.NoError(t, err)
+		_, err = assignmentRepository.Save(&models.RoleAssignment{
+			RoleID:    role.ID,
+			ProjectID: project.ID,
+			Members:   []string{"other@example.com"},
+		})
+		assert.Error(t, err)
+
+		foundAssignment, err := assignmentRepository.Get(role.ID, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, assignment.ID, foundAssignment.ID)
+		assert.Equal(t, "deployer", foundAssignment.Role.Name)
+		assignments, err := assignmentRepository.ListByProject(project.ID)
+		require.NoError(t, err)
+		require.Len(t, assignments, 1)
+		assert.Equal(t, "deployer", assignments[0].Role.Name)
+		assignments, err = assignmentRepository.ListByRole(role.ID)
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1)
+		assignments, err = assignmentRepository.ListByMember("deployer@example.com")
+		require.NoError(t, err)
+		assert.Len(t, assignments, 1)
+		assignments, err = assignmentRepository.ListByMember("other@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+
+		// the assignments are deleted with the role
+		require.NoError(t, roleRepository.Delete(role.ID))
+		_, err = roleRepository.Get(role.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+		_, err = assignmentRepository.Get(role.ID, project.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type ServiceAccountRepository interface {
+	// List returns the service accounts of a project ordered by name
+	List(projectID models.ID) ([]*models.ServiceAccount, error)
+	Get(id models.ID) (*models.ServiceAccount, error)
+	GetByName(projectID models.ID, name string) (*models.ServiceAccount, error)
+	// Save creates a new service account or updates an existing one
+	Save(serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error)
+	// Delete deletes the service account together with its tokens
+	Delete(id models.ID) error
+}
+
+type serviceAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAccountRepository(db *gorm.DB) ServiceAccountRepository {
+	return &serviceAccountRepository{db: db}
+}
+
+func (r *serviceAccountRepository) List(projectID models.ID) ([]*models.ServiceAccount, error) {
+	var serviceAccounts []*models.ServiceAccount
+	err := r.db.Where("project_id = ?", projectID).Order("name").Find(&serviceAccounts).Error
+	return serviceAccounts, err
+}
+
+func (r *serviceAccountRepository) Get(id models.ID) (*models.ServiceAccount, error) {
+	var serviceAccount models.ServiceAccount
+	if err := r.db.Where("id = ?", id).First(&serviceAccount).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("service account with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &serviceAccount, nil
+}
+
+func (r *serviceAccountRepository) GetByName(projectID models.ID, name string) (*models.ServiceAccount, error) {
+	var serviceAccount models.ServiceAccount
+	if err := r.db.Where("project_id = ? AND name = ?", projectID, name).First(&serviceAccount).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("service account with name %s not found", name)
+		}
+		return nil, err
+	}
+	return &serviceAccount, nil
+}
+
+func (r *serviceAccountRepository) Save(serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error) {
+	if err := r.db.Save(serviceAccount).Error; err != nil {
+		return nil, err
+	}
+	return serviceAccount, nil
+}
+
+func (r *serviceAccountRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.ServiceAccount{}).Error
+}
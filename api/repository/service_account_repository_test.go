@@ -0,0 +1,63 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func TestServiceAccountRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		project, err := NewProjectRepository(db).Save(&models.Project{Name: "project"})
+		require.NoError(t, err)
+		serviceAccountRepository := NewServiceAccountRepository(db)
+		tokenRepository := NewServiceAccountTokenRepository(db)
+
+		serviceAccount, err := serviceAccountRepository.Save(&models.ServiceAccount{
+			ProjectID: project.ID,
+			Name:      "ci-pipeline",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.ServiceAccountSubject(serviceAccount.ID), serviceAccount.Subject)
+		_, err = serviceAccountRepository.Save(&models.ServiceAccount{ProjectID: project.ID, Name: "ci-pipeline"})
+		assert.Error(t, err)
+
+		found, err := serviceAccountRepository.GetByName(project.ID, "ci-pipeline")
+		require.NoError(t, err)
+		assert.Equal(t, serviceAccount.Subject, found.Subject)
+
+		expiresAt := time.Now().Add(time.Hour)
+		token, err := tokenRepository.Save(&models.ServiceAccountToken{
+			ServiceAccountID: serviceAccount.ID,
+			TokenHash:        "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			ExpiresAt:        &expiresAt,
+		})
+		require.NoError(t, err)
+		found, err = serviceAccountRepository.Get(serviceAccount.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ci-pipeline", found.Name)
+		foundToken, err := tokenRepository.GetByHash(token.TokenHash)
+		require.NoError(t, err)
+		assert.Equal(t, token.ID, foundToken.ID)
+		tokens, err := tokenRepository.List(serviceAccount.ID)
+		require.NoError(t, err)
+		assert.Len(t, tokens, 1)
+
+		// the tokens are deleted with the service account
+		require.NoError(t, serviceAccountRepository.Delete(serviceAccount.ID))
+		_, err = serviceAccountRepository.Get(serviceAccount.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+		_, err = tokenRepository.GetByHash(token.TokenHash)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type ServiceAccountTokenRepository interface {
+	// List returns the tokens of a service account, most recent first
+	List(serviceAccountID models.ID) ([]*models.ServiceAccountToken, error)
+	Get(serviceAccountID models.ID, id models.ID) (*models.ServiceAccountToken, error)
+	// GetByHash returns the token with the given hash, whether it is active or not
+	GetByHash(tokenHash string) (*models.ServiceAccountToken, error)
+	// Save creates a new token or updates an existing one
+	Save(token *models.ServiceAccountToken) (*models.ServiceAccountToken, error)
+}
+
+type serviceAccountTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewServiceAccountTokenRepository(db *gorm.DB) ServiceAccountTokenRepository {
+	return &serviceAccountTokenRepository{db: db}
+}
+
+func (r *serviceAccountTokenRepository) List(serviceAccountID models.ID) ([]*models.ServiceAccountToken, error) {
+	var tokens []*models.ServiceAccountToken
+	err := r.db.Where("service_account_id = ?", serviceAccountID).
+		Order("created_at desc").Order("id desc").Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *serviceAccountTokenRepository) Get(serviceAccountID models.ID,
+	id models.ID) (*models.ServiceAccountToken, error) {
+	var token models.ServiceAccountToken
+	err := r.db.Where("service_account_id = ? AND id = ?", serviceAccountID, id).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("token with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *serviceAccountTokenRepository) GetByHash(tokenHash string) (*models.ServiceAccountToken, error) {
+	var token models.ServiceAccountToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("token not found")
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *serviceAccountTokenRepository) Save(token *models.ServiceAccountToken) (*models.ServiceAccountToken, error) {
+	if err := r.db.Save(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
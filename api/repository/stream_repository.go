@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type StreamRepository interface {
+	// List returns all streams ordered by name
+	List() ([]*models.Stream, error)
+	Get(id models.ID) (*models.Stream, error)
+	GetByName(name string) (*models.Stream, error)
+	// Save creates a new stream or updates an existing one
+	Save(stream *models.Stream) (*models.Stream, error)
+	Delete(id models.ID) error
+}
+
+type streamRepository struct {
+	db *gorm.DB
+}
+
+func NewStreamRepository(db *gorm.DB) StreamRepository {
+	return &streamRepository{db: db}
+}
+
+func (r *streamRepository) List() ([]*models.Stream, error) {
+	var streams []*models.Stream
+	err := r.db.Order("name").Find(&streams).Error
+	return streams, err
+}
+
+func (r *streamRepository) Get(id models.ID) (*models.Stream, error) {
+	var stream models.Stream
+	if err := r.db.Where("id = ?", id).First(&stream).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("stream with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &stream, nil
+}
+
+func (r *streamRepository) GetByName(name string) (*models.Stream, error) {
+	var stream models.Stream
+	if err := r.db.Where("name = ?", name).First(&stream).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("stream with name %s not found", name)
+		}
+		return nil, err
+	}
+	return &stream, nil
+}
+
+func (r *streamRepository) Save(stream *models.Stream) (*models.Stream, error) {
+	if err := r.db.Save(stream).Error; err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (r *streamRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.Stream{}).Error
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/it/database"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func TestStreamAndTeamRepository(t *testing.T) {
+	database.WithTestDatabase(t, func(t *testing.T, db *gorm.DB) {
+		streamRepository := NewStreamRepository(db)
+		teamRepository := NewTeamRepository(db)
+
+		marketing, err := streamRepository.Save(&models.Stream{Name: "marketing"})
+		require.NoError(t, err)
+		credit, err := streamRepository.Save(&models.Stream{
+			Name:   "credit",
+			Owners: []string{"owner@example.com"},
+		})
+		require.NoError(t, err)
+
+		streams, err := streamRepository.List()
+		require.NoError(t, err)
+		require.Len(t, streams, 2)
+		assert.Equal(t, "credit", streams[0].Name)
+		assert.Equal(t, []string{"owner@example.com"}, []string(streams[0].Owners))
+
+		stream, err := streamRepository.GetByName("marketing")
+		require.NoError(t, err)
+		assert.Equal(t, marketing.ID, stream.ID)
+		_, err = streamRepository.GetByName("unknown")
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+
+		_, err = teamRepository.Save(&models.Team{StreamID: credit.ID, Name: "risk"})
+		require.NoError(t, err)
+		campaigns, err := teamRepository.Save(&models.Team{StreamID: marketing.ID, Name: "campaigns"})
+		require.NoError(t, err)
+
+		teams, err := teamRepository.List(nil)
+		require.NoError(t, err)
+		assert.Len(t, teams, 2)
+		teams, err = teamRepository.List(&marketing.ID)
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "campaigns", teams[0].Name)
+
+		team, err := teamRepository.GetByName(marketing.ID, "campaigns")
+		require.NoError(t, err)
+		assert.Equal(t, campaigns.ID, team.ID)
+		_, err = teamRepository.GetByName(credit.ID, "campaigns")
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+
+		// a team cannot reference a stream that does not exist
+		_, err = teamRepository.Save(&models.Team{StreamID: 1000, Name: "orphan"})
+		assert.Error(t, err)
+
+		require.NoError(t, teamRepository.Delete(campaigns.ID))
+		_, err = teamRepository.Get(campaigns.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+		require.NoError(t, streamRepository.Delete(marketing.ID))
+		_, err = streamRepository.Get(marketing.ID)
+		assert.True(t, errors.Is(err, &apperrors.NotFoundError{}))
+	})
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+type TeamRepository interface {
+	// List returns the teams ordered by name. Only the teams of the given stream are returned if streamID is set.
+	List(streamID *models.ID) ([]*models.Team, error)
+	Get(id models.ID) (*models.Team, error)
+	GetByName(streamID models.ID, name string) (*models.Team, error)
+	// Save creates a new team or updates an existing one
+	Save(team *models.Team) (*models.Team, error)
+	Delete(id models.ID) error
+}
+
+type teamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) TeamRepository {
+	return &teamRepository{db: db}
+}
+
+func (r *teamRepository) List(streamID *models.ID) ([]*models.Team, error) {
+	query := r.db
+	if streamID != nil {
+		query = query.Where("stream_id = ?", *streamID)
+	}
+
+	var teams []*models.Team
+	err := query.Order("name").Find(&teams).Error
+	return teams, err
+}
+
+func (r *teamRepository) Get(id models.ID) (*models.Team, error) {
+	var team models.Team
+	if err := r.db.Where("id = ?", id).First(&team).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("team with ID %d not found", id)
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) GetByName(streamID models.ID, name string) (*models.Team, error) {
+	var team models.Team
+	if err := r.db.Where("stream_id = ? AND name = ?", streamID, name).First(&team).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundErrorf("team with name %s not found", name)
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) Save(team *models.Team) (*models.Team, error) {
+	if err := r.db.Save(team).Error; err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (r *teamRepository) Delete(id models.ID) error {
+	return r.db.Where("id = ?", id).Delete(models.Team{}).Error
+}
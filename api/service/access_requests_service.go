@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/pkg/webhooks"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// AccessRequestsService manages the requests of users to be granted a role in a project, which are approved or
+// denied by the administrators of the project
+type AccessRequestsService interface {
+	// ListAccessRequests returns the access requests of the project, most recent first. Only the requests with the
+	// given status are returned if it is not empty.
+	ListAccessRequests(projectID models.ID, status models.AccessRequestStatus) ([]*models.ProjectAccessRequest, error)
+	FindAccessRequestByID(projectID models.ID, id models.ID) (*models.ProjectAccessRequest, error)
+	// CreateAccessRequest records a pending request of the requester for a role in the project
+	CreateAccessRequest(ctx context.Context, project *models.Project,
+		accessRequest *models.ProjectAccessRequest) (*models.ProjectAccessRequest, error)
+	// ApproveAccessRequest grants the requested role to the requester and marks the request as approved by the user
+	// of the context
+	ApproveAccessRequest(ctx context.Context, project *models.Project, accessRequest *models.ProjectAccessRequest,
+		comment string) (*models.ProjectAccessRequest, error)
+	// DenyAccessRequest marks the request as denied by the user of the context
+	DenyAccessRequest(ctx context.Context, project *models.Project, accessRequest *models.ProjectAccessRequest,
+		comment string) (*models.ProjectAccessRequest, error)
+}
+
+func NewAccessRequestsService(
+	accessRequestRepository repository.ProjectAccessRequestRepository,
+	projectsService ProjectsService,
+	webhookManager webhooks.WebhookManager) AccessRequestsService {
+	return &accessRequestsService{
+		accessRequestRepository: accessRequestRepository,
+		projectsService:         projectsService,
+		webhookManager:          webhookManager,
+	}
+}
+
+type accessRequestsService struct {
+	accessRequestRepository repository.ProjectAccessRequestRepository
+	projectsService         ProjectsService
+	webhookManager          webhooks.WebhookManager
+}
+
+func (s *accessRequestsService) ListAccessRequests(projectID models.ID,
+	status models.AccessRequestStatus) ([]*models.ProjectAccessRequest, error) {
+	return s.accessRequestRepository.List(projectID, status)
+}
+
+func (s *accessRequestsService) FindAccessRequestByID(projectID models.ID,
+	id models.ID) (*models.ProjectAccessRequest, error) {
+	return s.accessRequestRepository.Get(projectID, id)
+}
+
+func (s *accessRequestsService) CreateAccessRequest(ctx context.Context, project *models.Project,
+	accessRequest *models.ProjectAccessRequest) (*models.ProjectAccessRequest, error) {
+	if project.HasRole(accessRequest.Requester, accessRequest.Role) {
+		return nil, apperrors.NewInvalidArgumentErrorf("%s already has the %s role in project %s",
+			accessRequest.Requester, accessRequest.Role, project.Name)
+	}
+	_, err := s.accessRequestRepository.GetPending(project.ID, accessRequest.Requester)
+	if err == nil {
+		return nil, apperrors.NewAlreadyExistsErrorf("%s already has a pending access request for project %s",
+			accessRequest.Requester, project.Name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return nil, err
+	}
+
+	accessRequest.ProjectID = project.ID
+	accessRequest.Status = models.AccessRequestPending
+	accessRequest, err = s.accessRequestRepository.Save(accessRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error creating access request for project %s: %w", project.Name, err)
+	}
+	s.notify(ctx, ProjectAccessRequestedEvent, project, accessRequest)
+	return accessRequest, nil
+}
+
+func (s *accessRequestsService) ApproveAccessRequest(ctx context.Context, project *models.Project,
+	accessRequest *models.ProjectAccessRequest, comment string) (*models.ProjectAccessRequest, error) {
+	if err := checkPending(accessRequest); err != nil {
+		return nil, err
+	}
+
+	// the request is approved before the access is granted, so that it cannot be approved twice
+	if err := s.review(ctx, accessRequest, models.AccessRequestApproved, comment); err != nil {
+		return nil, err
+	}
+	updatedProject, err := s.projectsService.GrantProjectAccess(ctx, project, accessRequest.Requester,
+		accessRequest.Role)
+	if err != nil {
+		// the request is reopened so that it can be approved again
+		s.reopen(accessRequest)
+		return nil, fmt.Errorf("error granting the %s role in project %s to %s: %w", accessRequest.Role,
+			project.Name, accessRequest.Requester, err)
+	}
+	s.notify(ctx, ProjectAccessApprovedEvent, updatedProject, accessRequest)
+	return accessRequest, nil
+}
+
+func (s *accessRequestsService) DenyAccessRequest(ctx context.Context, project *models.Project,
+	accessRequest *models.ProjectAccessRequest, comment string) (*models.ProjectAccessRequest, error) {
+	if err := checkPending(accessRequest); err != nil {
+		return nil, err
+	}
+	if err := s.review(ctx, accessRequest, models.AccessRequestDenied, comment); err != nil {
+		return nil, err
+	}
+	s.notify(ctx, ProjectAccessDeniedEvent, project, accessRequest)
+	return accessRequest, nil
+}
+
+// review records the decision of the user of the context on the pending access request. It fails if the request has
+// been reviewed concurrently.
+func (s *accessRequestsService) review(ctx context.Context, accessRequest *models.ProjectAccessRequest,
+	status models.AccessRequestStatus, comment string) error {
+	reviewedAt := time.Now()
+	reviewed := *accessRequest
+	reviewed.Status = status
+	reviewed.Reviewer = requestctx.Actor(ctx)
+	reviewed.ReviewComment = comment
+	reviewed.ReviewedAt = &reviewedAt
+
+	if err := s.accessRequestRepository.UpdateStatus(&reviewed, models.AccessRequestPending); err != nil {
+		return fmt.Errorf("error updating access request %d: %w", accessRequest.ID, err)
+	}
+	*accessRequest = reviewed
+	return nil
+}
+
+// reopen marks the approved access request as pending again after the access could not be granted
+func (s *accessRequestsService) reopen(accessRequest *models.ProjectAccessRequest) {
+	reopened := *accessRequest
+	reopened.Status = models.AccessRequestPending
+	reopened.Reviewer = ""
+	reopened.ReviewComment = ""
+	reopened.ReviewedAt = nil
+	if err := s.accessRequestRepository.UpdateStatus(&reopened, models.AccessRequestApproved); err != nil {
+		log.Errorf("error reopening access request %d: %s", accessRequest.ID, err)
+		return
+	}
+	*accessRequest = reopened
+}
+
+// notify invokes the webhooks configured for the event. The change of the access request has already been saved at
+// this point, so failing to call the webhooks is logged rather than failing the request.
+func (s *accessRequestsService) notify(ctx context.Context, event webhooks.EventType, project *models.Project,
+	accessRequest *models.ProjectAccessRequest) {
+	if s.webhookManager == nil || !s.webhookManager.IsEventConfigured(event) {
+		return
+	}
+
+	_ = s.webhookManager.InvokeWebhooks(ctx, event,
+		ProjectAccessRequestPayload{AccessRequest: accessRequest, Project: project},
+		func(p []byte) error {
+			return nil
+		}, func(err error) error {
+			log.Errorf("error calling webhook - %s, err: %s", event, err.Error())
+			return err
+		},
+	)
+}
+
+// checkPending returns an invalid argument error if the access request has already been reviewed
+func checkPending(accessRequest *models.ProjectAccessRequest) error {
+	if accessRequest.Status != models.AccessRequestPending {
+		return apperrors.NewInvalidArgumentErrorf("access request %d has already been %s", accessRequest.ID,
+			accessRequest.Status)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/pkg/webhooks"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestAccessRequestsService_CreateAccessRequest(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com"},
+		Readers:        []string{"reader@example.com"},
+	}
+	tests := map[string]struct {
+		requester   string
+		role        models.ProjectRole
+		pending     bool
+		expectedErr error
+	}{
+		"request reader role": {
+			requester: "user@example.com",
+			role:      models.ProjectReaderRole,
+		},
+		"reader requests administrator role": {
+			requester: "reader@example.com",
+			role:      models.ProjectAdministratorRole,
+		},
+		"administrator requests reader role": {
+			requester:   "admin@example.com",
+			role:        models.ProjectReaderRole,
+			expectedErr: &apperrors.InvalidArgumentError{},
+		},
+		"request already pending": {
+			requester:   "user@example.com",
+			role:        models.ProjectReaderRole,
+			pending:     true,
+			expectedErr: &apperrors.AlreadyExistsError{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			accessRequestRepository := &mocks.ProjectAccessRequestRepository{}
+			if tt.pending {
+				accessRequestRepository.On("GetPending", models.ID(1), tt.requester).Return(
+					&models.ProjectAccessRequest{ID: 1}, nil)
+			} else {
+				accessRequestRepository.On("GetPending", models.ID(1), tt.requester).Return(nil,
+					apperrors.NewNotFoundErrorf("not found"))
+			}
+			accessRequestRepository.On("Save", mock.Anything).Return(
+				func(accessRequest *models.ProjectAccessRequest) *models.ProjectAccessRequest {
+					accessRequest.ID = 2
+					return accessRequest
+				}, nil)
+			webhookManager := &webhooks.MockWebhookManager{}
+			webhookManager.On("IsEventConfigured", ProjectAccessRequestedEvent).Return(true)
+			webhookManager.On("InvokeWebhooks", mock.Anything, ProjectAccessRequestedEvent,
+				mock.AnythingOfType("service.ProjectAccessRequestPayload"), mock.Anything, mock.Anything).Return(nil)
+
+			s := NewAccessRequestsService(accessRequestRepository, nil, webhookManager)
+			accessRequest, err := s.CreateAccessRequest(context.Background(), project, &models.ProjectAccessRequest{
+				Requester: tt.requester,
+				Role:      tt.role,
+			})
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				webhookManager.AssertNotCalled(t, "InvokeWebhooks")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.AccessRequestPending, accessRequest.Status)
+			assert.Equal(t, models.ID(1), accessRequest.ProjectID)
+			webhookManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccessRequestsService_ReviewAccessRequest(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com"},
+		Readers:        []string{"reader@example.com"},
+	}
+	ctx := requestctx.WithActor(context.Background(), "admin@example.com")
+
+	t.Run("approve", func(t *testing.T) {
+		projectRepository := &mocks.ProjectRepository{}
+		projectRepository.On("Get", models.ID(1)).Return(project, nil)
+		projectRepository.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+			return assert.ObjectsAreEqual([]string{"reader@example.com", "user@example.com"}, []string(p.Readers))
+		}), repository.ProjectWriteOptions{}).Return(project, nil)
+		projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+			nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+		require.NoError(t, err)
+		accessRequestRepository := &mocks.ProjectAccessRequestRepository{}
+		accessRequestRepository.On("UpdateStatus", mock.MatchedBy(func(accessRequest *models.ProjectAccessRequest) bool {
+			return accessRequest.Status == models.AccessRequestApproved
+		}), models.AccessRequestPending).Return(nil)
+
+		s := NewAccessRequestsService(accessRequestRepository, projectsService, nil)
+		accessRequest, err := s.ApproveAccessRequest(ctx, project, &models.ProjectAccessRequest{
+			ID:        2,
+			ProjectID: 1,
+			Requester: "user@example.com",
+			Role:      models.ProjectReaderRole,
+			Status:    models.AccessRequestPending,
+		}, "welcome")
+		require.NoError(t, err)
+		assert.Equal(t, models.AccessRequestApproved, accessRequest.Status)
+		assert.Equal(t, "admin@example.com", accessRequest.Reviewer)
+		assert.Equal(t, "welcome", accessRequest.ReviewComment)
+		assert.NotNil(t, accessRequest.ReviewedAt)
+		projectRepository.AssertExpectations(t)
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		accessRequestRepository := &mocks.ProjectAccessRequestRepository{}
+		accessRequestRepository.On("UpdateStatus", mock.Anything, models.AccessRequestPending).Return(nil)
+		webhookManager := &webhooks.MockWebhookManager{}
+		webhookManager.On("IsEventConfigured", ProjectAccessDeniedEvent).Return(true)
+		webhookManager.On("InvokeWebhooks", mock.Anything, ProjectAccessDeniedEvent, mock.Anything, mock.Anything,
+			mock.Anything).Return(errors.New("webhook failed"))
+
+		s := NewAccessRequestsService(accessRequestRepository, nil, webhookManager)
+		accessRequest, err := s.DenyAccessRequest(ctx, project, &models.ProjectAccessRequest{
+			ID:     2,
+			Status: models.AccessRequestPending,
+		}, "")
+		require.NoError(t, err)
+		assert.Equal(t, models.AccessRequestDenied, accessRequest.Status)
+		webhookManager.AssertExpectations(t)
+	})
+
+	t.Run("reviewed concurrently", func(t *testing.T) {
+		accessRequestRepository := &mocks.ProjectAccessRequestRepository{}
+		accessRequestRepository.On("UpdateStatus", mock.Anything, models.AccessRequestPending).Return(
+			apperrors.NewInvalidArgumentErrorf("access request 2 is no longer pending"))
+
+		// the access is not granted, as the request has been reviewed by another administrator
+		s := NewAccessRequestsService(accessRequestRepository, nil, nil)
+		accessRequest := &models.ProjectAccessRequest{ID: 2, Status: models.AccessRequestPending}
+		_, err := s.ApproveAccessRequest(ctx, project, accessRequest, "")
+		assert.EqualError(t, err, "error updating access request 2: access request 2 is no longer pending")
+		assert.Equal(t, models.AccessRequestPending, accessRequest.Status)
+	})
+
+	t.Run("reopened if the access cannot be granted", func(t *testing.T) {
+		accessRequestRepository := &mocks.ProjectAccessRequestRepository{}
+		accessRequestRepository.On("UpdateStatus", mock.Anything, models.AccessRequestPending).Return(nil)
+		accessRequestRepository.On("UpdateStatus", mock.MatchedBy(func(accessRequest *models.ProjectAccessRequest) bool {
+			return accessRequest.Status == models.AccessRequestPending && accessRequest.ReviewedAt == nil
+		}), models.AccessRequestApproved).Return(nil)
+		projectRepository := &mocks.ProjectRepository{}
+		projectRepository.On("Get", models.ID(1)).Return(project, nil)
+		projectRepository.On("SaveWith", mock.Anything, repository.ProjectWriteOptions{}).
+			Return(nil, errors.New("database is unavailable"))
+		projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+			nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+		require.NoError(t, err)
+
+		s := NewAccessRequestsService(accessRequestRepository, projectsService, nil)
+		accessRequest := &models.ProjectAccessRequest{
+			ID:        2,
+			Requester: "user@example.com",
+			Role:      models.ProjectReaderRole,
+			Status:    models.AccessRequestPending,
+		}
+		_, err = s.ApproveAccessRequest(ctx, project, accessRequest, "")
+		assert.EqualError(t, err, "error granting the reader role in project project to user@example.com: "+
+			"database is unavailable")
+		assert.Equal(t, models.AccessRequestPending, accessRequest.Status)
+		accessRequestRepository.AssertExpectations(t)
+	})
+
+	t.Run("already reviewed", func(t *testing.T) {
+		s := NewAccessRequestsService(&mocks.ProjectAccessRequestRepository{}, nil, nil)
+		_, err := s.DenyAccessRequest(ctx, project, &models.ProjectAccessRequest{
+			ID:     2,
+			Status: models.AccessRequestApproved,
+		}, "")
+		assert.EqualError(t, err, "access request 2 has already been approved")
+	})
+}
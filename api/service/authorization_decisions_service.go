@@ -0,0 +1,51 @@
+package service
+
+import (
+	"time"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// AuthorizationDecisionsService queries the authorization decision log stored in the database
+type AuthorizationDecisionsService interface {
+	// ListDecisions returns the decisions matching the filter, most recent first
+	ListDecisions(filter repository.AuthorizationDecisionFilter) ([]*models.AuthorizationDecision,
+		*pagination.Paging, error)
+	// PruneDecisions deletes the decisions made before the given time and returns how many were deleted
+	PruneDecisions(before time.Time) (int64, error)
+}
+
+func NewAuthorizationDecisionsService(
+	decisionRepository repository.AuthorizationDecisionRepository,
+	stored bool) AuthorizationDecisionsService {
+	return &authorizationDecisionsService{
+		decisionRepository: decisionRepository,
+		stored:             stored,
+	}
+}
+
+type authorizationDecisionsService struct {
+	decisionRepository repository.AuthorizationDecisionRepository
+	// stored is true if the decisions are written to the database
+	stored bool
+}
+
+func (s *authorizationDecisionsService) ListDecisions(filter repository.AuthorizationDecisionFilter) (
+	[]*models.AuthorizationDecision, *pagination.Paging, error) {
+	if !s.stored {
+		return nil, nil, apperrors.NewPreconditionFailedErrorf(
+			"the authorization decisions are not stored in the database, the decision log sink must be database")
+	}
+	decisions, count, err := s.decisionRepository.List(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return decisions, pagination.ToPaging(filter.Options, count), nil
+}
+
+func (s *authorizationDecisionsService) PruneDecisions(before time.Time) (int64, error) {
+	return s.decisionRepository.DeleteBefore(before)
+}
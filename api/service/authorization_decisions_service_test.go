@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestAuthorizationDecisionsService_ListDecisions(t *testing.T) {
+	page, pageSize := int32(2), int32(1)
+	filter := repository.AuthorizationDecisionFilter{
+		Subject: "alice@example.com",
+		Options: pagination.Options{Page: &page, PageSize: &pageSize},
+	}
+	decisions := []*models.AuthorizationDecision{
+		{ID: 2, Subject: "alice@example.com", Permission: "mlp.projects.1.secrets.get", Allowed: true},
+	}
+	decisionRepository := &mocks.AuthorizationDecisionRepository{}
+	decisionRepository.On("List", filter).Return(decisions, 3, nil)
+
+	listed, paging, err := NewAuthorizationDecisionsService(decisionRepository, true).ListDecisions(filter)
+	require.NoError(t, err)
+	assert.Equal(t, decisions, listed)
+	assert.Equal(t, &pagination.Paging{Page: 2, Pages: 3, Total: 3}, paging)
+
+	_, _, err = NewAuthorizationDecisionsService(decisionRepository, false).ListDecisions(filter)
+	assert.EqualError(t, err,
+		"the authorization decisions are not stored in the database, the decision log sink must be database")
+}
+
+func TestDecisionLogPruner_Prune(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	decisionRepository := &mocks.AuthorizationDecisionRepository{}
+	decisionRepository.On("DeleteBefore", now.Add(-24*time.Hour)).Return(int64(5), nil)
+
+	pruner := NewDecisionLogPruner(NewAuthorizationDecisionsService(decisionRepository, true), 24*time.Hour,
+		time.Hour)
+	pruner.now = func() time.Time { return now }
+	pruner.Prune()
+	decisionRepository.AssertExpectations(t)
+}
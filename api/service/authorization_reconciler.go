@@ -0,0 +1,471 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+const (
+	// authorizationReconcileConcurrency is the max number of roles and groups whose relations are read concurrently
+	authorizationReconcileConcurrency = 10
+	// authorizationReconcileBatchSize is the max number of roles and groups updated by a single authorization update
+	authorizationReconcileBatchSize = 100
+)
+
+// projectPermissionPattern matches the permissions and the roles specific to a project and captures the ID of the
+// project
+var projectPermissionPattern = regexp.MustCompile(`^mlp\.projects\.([0-9]+)\.`)
+
+// projectRolePrefix is the prefix of the roles specific to a project, which are listed to find the roles of the
+// deleted projects
+const projectRolePrefix = "mlp.projects."
+
+// AuthorizationChange is the difference between the relations of a role or a group and the relations expected from
+// the resources stored in the database
+type AuthorizationChange struct {
+	// Role is the role whose permissions or members differ, if Group is empty
+	Role string
+	// Group is the group whose members differ
+	Group              string
+	AddedPermissions   []string
+	RemovedPermissions []string
+	AddedMembers       []string
+	RemovedMembers     []string
+}
+
+// AuthorizationReconcileOptions controls how the authorization relations are reconciled
+type AuthorizationReconcileOptions struct {
+	// DryRun only plans the changes without applying them
+	DryRun bool
+	// RevokeOnly only removes the permissions and members which are not expected, without adding the missing ones.
+	// The removals are checked against the resources read again after the current relations, so that a relation
+	// granted or revoked concurrently is never reverted from a stale read of the resources. The missing relations are
+	// added by the authorization syncs of the resources, or by a reconciliation which is not revoke-only.
+	RevokeOnly bool
+}
+
+// AuthorizationReconciler repairs the relations granting the permissions, stored in Keto or in the database
+// authorization backend, which can be lost or fall out of sync with the resources, e.g. if the update of the
+// relations failed after the resource was saved
+type AuthorizationReconciler interface {
+	// Reconcile recomputes the relations expected from the projects, with their custom role assignments, the groups,
+	// the streams and the teams, with the same logic as their services, and applies the differences with the current
+	// relations. The changes applied, or planned in case of a dry run, are returned ordered by role and group. The
+	// members of the MLP administrators and of the readers of all projects, which are set by the bootstrap command,
+	// are left unchanged. The permissions are only removed from the roles when they are specific to a project that
+	// does not exist anymore, since other components can grant additional permissions, and all the members of the
+	// roles of such projects are removed.
+	Reconcile(ctx context.Context, options AuthorizationReconcileOptions) ([]*AuthorizationChange, error)
+}
+
+func NewAuthorizationReconciler(
+	projectRepository repository.ProjectRepository,
+	assignmentRepository repository.RoleAssignmentRepository,
+	groupRepository repository.GroupRepository,
+	streamRepository repository.StreamRepository,
+	teamRepository repository.TeamRepository,
+	authEnforcer enforcer.Enforcer) AuthorizationReconciler {
+	return &authorizationReconciler{
+		projectRepository:    projectRepository,
+		assignmentRepository: assignmentRepository,
+		groupRepository:      groupRepository,
+		streamRepository:     streamRepository,
+		teamRepository:       teamRepository,
+		authEnforcer:         authEnforcer,
+	}
+}
+
+type authorizationReconciler struct {
+	projectRepository    repository.ProjectRepository
+	assignmentRepository repository.RoleAssignmentRepository
+	groupRepository      repository.GroupRepository
+	streamRepository     repository.StreamRepository
+	teamRepository       repository.TeamRepository
+	authEnforcer         enforcer.Enforcer
+}
+
+// expectedAuthorization is the union of the authorization policies of the resources
+type expectedAuthorization struct {
+	rolePermissions map[string][]string
+	roleMembers     map[string][]string
+	groupMembers    map[string][]string
+	// projectIDs are the IDs of the existing projects
+	projectIDs map[string]bool
+}
+
+func (r *authorizationReconciler) Reconcile(ctx context.Context,
+	options AuthorizationReconcileOptions) ([]*AuthorizationChange, error) {
+	if r.authEnforcer == nil {
+		return nil, errors.New("authorization is not enabled")
+	}
+
+	expected, err := r.expectedAuthorization()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := r.planChanges(ctx, expected)
+	if err != nil {
+		return nil, err
+	}
+	if options.RevokeOnly {
+		changes, err = r.planRevocations(changes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.DryRun {
+		return changes, nil
+	}
+
+	// unless the reconciliation is revoke-only, the changes of a concurrent update of a resource can be reverted if
+	// the resource is saved after being read above, until the next reconciliation
+	for start := 0; start < len(changes); start += authorizationReconcileBatchSize {
+		end := start + authorizationReconcileBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		updateRequest := enforcer.NewAuthorizationUpdateRequest()
+		for _, change := range changes[start:end] {
+			change.addTo(updateRequest, expected)
+		}
+		if err := r.authEnforcer.UpdateAuthorization(ctx, updateRequest); err != nil {
+			return changes[:start], fmt.Errorf("error applying authorization changes: %w", err)
+		}
+	}
+	return changes, nil
+}
+
+// expectedAuthorization merges the authorization policies of all resources
+func (r *authorizationReconciler) expectedAuthorization() (*expectedAuthorization, error) {
+	expected := &expectedAuthorization{
+		rolePermissions: map[string][]string{enforcer.MLPAdminRole: MLPAdminPermissions()},
+		roleMembers:     make(map[string][]string),
+		groupMembers:    make(map[string][]string),
+		projectIDs:      make(map[string]bool),
+	}
+
+	projects, err := r.projectRepository.ListAll()
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+	for _, project := range projects {
+		expected.projectIDs[project.ID.String()] = true
+		policy, err := projectAuthorizationPolicy(project)
+		if err != nil {
+			return nil, fmt.Errorf("error computing authorization policy of project %s: %w", project.Name, err)
+		}
+		expected.merge(policy)
+
+		assignments, err := r.assignmentRepository.ListByProject(project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing role assignments of project %s: %w", project.Name, err)
+		}
+		for _, assignment := range assignments {
+			policy, err := roleAssignmentAuthorizationPolicy(assignment.Role, assignment)
+			if err != nil {
+				return nil, fmt.Errorf("error computing authorization policy of role %s in project %s: %w",
+					assignment.Role.Name, project.Name, err)
+			}
+			expected.merge(policy)
+		}
+	}
+
+	groups, err := r.groupRepository.List()
+	if err != nil {
+		return nil, fmt.Errorf("error listing groups: %w", err)
+	}
+	for _, group := range groups {
+		policy, err := groupAuthorizationPolicy(group)
+		if err != nil {
+			return nil, fmt.Errorf("error computing authorization policy of group %s: %w", group.Name, err)
+		}
+		expected.merge(policy)
+	}
+
+	streams, err := r.streamRepository.List()
+	if err != nil {
+		return nil, fmt.Errorf("error listing streams: %w", err)
+	}
+	for _, stream := range streams {
+		policy := enforcer.NewAuthorizationUpdateRequest()
+		if err := streamResource.grantOwners(policy, stream.ID, stream.Owners); err != nil {
+			return nil, fmt.Errorf("error computing authorization policy of stream %s: %w", stream.Name, err)
+		}
+		expected.merge(policy)
+	}
+	teams, err := r.teamRepository.List(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing teams: %w", err)
+	}
+	for _, team := range teams {
+		policy := enforcer.NewAuthorizationUpdateRequest()
+		if err := teamResource.grantOwners(policy, team.ID, team.Owners); err != nil {
+			return nil, fmt.Errorf("error computing authorization policy of team %s: %w", team.Name, err)
+		}
+		expected.merge(policy)
+	}
+	return expected, nil
+}
+
+// merge adds the policy of a resource. The permissions of the roles shared by several resources, such as the MLP
+// administrators, are the union of the permissions granted by each resource.
+func (e *expectedAuthorization) merge(policy enforcer.AuthorizationUpdateRequest) {
+	for role, permissions := range policy.RolePermissions {
+		for _, permission := range permissions {
+			if !slices.Contains(e.rolePermissions[role], permission) {
+				e.rolePermissions[role] = append(e.rolePermissions[role], permission)
+			}
+		}
+	}
+	for role, members := range policy.RoleMembers {
+		e.roleMembers[role] = members
+	}
+	for group, members := range policy.GroupMembers {
+		e.groupMembers[group] = members
+	}
+}
+
+// isStale returns true if the permission or the role is specific to a project that does not exist anymore
+func (e *expectedAuthorization) isStale(permissionOrRole string) bool {
+	match := projectPermissionPattern.FindStringSubmatch(permissionOrRole)
+	return match != nil && !e.projectIDs[match[1]]
+}
+
+// planChanges compares the expected relations with the current ones, reading them concurrently. The roles of the
+// deleted projects are expected to have no members.
+func (r *authorizationReconciler) planChanges(ctx context.Context,
+	expected *expectedAuthorization) ([]*AuthorizationChange, error) {
+	projectRoles, err := r.authEnforcer.ListRoles(ctx, projectRolePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("error listing project roles: %w", err)
+	}
+	for _, role := range projectRoles {
+		if _, ok := expected.roleMembers[role]; !ok && expected.isStale(role) {
+			expected.roleMembers[role] = []string{}
+		}
+	}
+
+	roles := make([]string, 0, len(expected.rolePermissions))
+	for role := range expected.rolePermissions {
+		roles = append(roles, role)
+	}
+	for role := range expected.roleMembers {
+		if _, ok := expected.rolePermissions[role]; !ok {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	groups := make([]string, 0, len(expected.groupMembers))
+	for group := range expected.groupMembers {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	changes := make([]*AuthorizationChange, len(roles)+len(groups))
+	workers := new(errgroup.Group)
+	workers.SetLimit(authorizationReconcileConcurrency)
+	for i, role := range roles {
+		i, role := i, role
+		workers.Go(func() error {
+			change, err := r.planRoleChange(ctx, role, expected)
+			changes[i] = change
+			return err
+		})
+	}
+	for i, group := range groups {
+		i, group := len(roles)+i, group
+		workers.Go(func() error {
+			members, err := r.authEnforcer.GetGroupMembers(ctx, group)
+			if err != nil {
+				return fmt.Errorf("error fetching members of group %s: %w", group, err)
+			}
+			change := &AuthorizationChange{Group: group}
+			change.AddedMembers, change.RemovedMembers = diffMembers(members, expected.groupMembers[group])
+			changes[i] = change
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+
+	planned := make([]*AuthorizationChange, 0)
+	for _, change := range changes {
+		if !change.isEmpty() {
+			planned = append(planned, change)
+		}
+	}
+	return planned, nil
+}
+
+func (r *authorizationReconciler) planRoleChange(ctx context.Context, role string,
+	expected *expectedAuthorization) (*AuthorizationChange, error) {
+	change := &AuthorizationChange{Role: role}
+	permissions, err := r.authEnforcer.GetRolePermissions(ctx, role)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching permissions of role %s: %w", role, err)
+	}
+	for _, permission := range expected.rolePermissions[role] {
+		if !slices.Contains(permissions, permission) {
+			change.AddedPermissions = append(change.AddedPermissions, permission)
+		}
+	}
+	for _, permission := range permissions {
+		if expected.isStale(permission) && !slices.Contains(expected.rolePermissions[role], permission) {
+			change.RemovedPermissions = append(change.RemovedPermissions, permission)
+		}
+	}
+	sort.Strings(change.AddedPermissions)
+	sort.Strings(change.RemovedPermissions)
+
+	if expectedMembers, ok := expected.roleMembers[role]; ok {
+		members, err := r.authEnforcer.GetRoleMembers(ctx, role)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching members of role %s: %w", role, err)
+		}
+		change.AddedMembers, change.RemovedMembers = diffMembers(members, expectedMembers)
+	}
+	return change, nil
+}
+
+// planRevocations reads the resources again and keeps the removals of the changes which are still expected, so that
+// the relations granted concurrently after the resources were first read are not removed
+func (r *authorizationReconciler) planRevocations(changes []*AuthorizationChange) ([]*AuthorizationChange, error) {
+	expected, err := r.expectedAuthorization()
+	if err != nil {
+		return nil, err
+	}
+
+	revocations := make([]*AuthorizationChange, 0)
+	for _, change := range changes {
+		revocation := &AuthorizationChange{Role: change.Role, Group: change.Group}
+		expectedMembers := expected.roleMembers[change.Role]
+		if change.Group != "" {
+			expectedMembers = expected.groupMembers[change.Group]
+		}
+		for _, permission := range change.RemovedPermissions {
+			if expected.isStale(permission) && !slices.Contains(expected.rolePermissions[change.Role], permission) {
+				revocation.RemovedPermissions = append(revocation.RemovedPermissions, permission)
+			}
+		}
+		for _, member := range change.RemovedMembers {
+			if !slices.Contains(expectedMembers, member) {
+				revocation.RemovedMembers = append(revocation.RemovedMembers, member)
+			}
+		}
+		if !revocation.isEmpty() {
+			revocations = append(revocations, revocation)
+		}
+	}
+	return revocations, nil
+}
+
+// diffMembers returns the expected members which are missing and the current members which are not expected
+func diffMembers(current []string, expected []string) ([]string, []string) {
+	var added, removed []string
+	for _, member := range expected {
+		if !slices.Contains(current, member) {
+			added = append(added, member)
+		}
+	}
+	for _, member := range current {
+		if !slices.Contains(expected, member) {
+			removed = append(removed, member)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func (c *AuthorizationChange) isEmpty() bool {
+	return len(c.AddedPermissions)+len(c.RemovedPermissions)+len(c.AddedMembers)+len(c.RemovedMembers) == 0
+}
+
+// addTo adds the change to the update request. The expected members of the role or the group are set if members are
+// added, otherwise only the removed members are removed so that no member is ever granted by a removal.
+func (c *AuthorizationChange) addTo(updateRequest enforcer.AuthorizationUpdateRequest,
+	expected *expectedAuthorization) {
+	if c.Group != "" {
+		if len(c.AddedMembers) > 0 {
+			updateRequest.SetGroupMembers(c.Group, expected.groupMembers[c.Group])
+		} else {
+			updateRequest.RemoveGroupMembers(c.Group, c.RemovedMembers)
+		}
+		return
+	}
+	if len(c.AddedPermissions) > 0 {
+		updateRequest.AddRolePermissions(c.Role, c.AddedPermissions)
+	}
+	if len(c.RemovedPermissions) > 0 {
+		updateRequest.RemoveRolePermissions(c.Role, c.RemovedPermissions)
+	}
+	if len(c.AddedMembers) > 0 {
+		updateRequest.SetRoleMembers(c.Role, expected.roleMembers[c.Role])
+	} else if len(c.RemovedMembers) > 0 {
+		updateRequest.RemoveRoleMembers(c.Role, c.RemovedMembers)
+	}
+}
+
+// ScheduledAuthorizationReconciler periodically reconciles the authorization relations. The scheduled reconciliations
+// are revoke-only, so that they never re-grant a relation revoked concurrently.
+type ScheduledAuthorizationReconciler struct {
+	reconciler AuthorizationReconciler
+	interval   time.Duration
+}
+
+func NewScheduledAuthorizationReconciler(reconciler AuthorizationReconciler,
+	interval time.Duration) *ScheduledAuthorizationReconciler {
+	return &ScheduledAuthorizationReconciler{
+		reconciler: reconciler,
+		interval:   interval,
+	}
+}
+
+// Run reconciles the authorization relations every interval until the context is cancelled
+func (s *ScheduledAuthorizationReconciler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Reconcile(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile reconciles the authorization relations once. Errors are logged, the changes that failed are retried by
+// the next reconciliation.
+func (s *ScheduledAuthorizationReconciler) Reconcile(ctx context.Context) {
+	changes, err := s.reconciler.Reconcile(ctx, AuthorizationReconcileOptions{RevokeOnly: true})
+	if err != nil {
+		log.Errorf("error reconciling authorization: %s", err)
+	}
+	if len(changes) > 0 {
+		log.Infof("reconciled the authorization of %d roles and groups", len(changes))
+	}
+}
+
+// String summarizes the change in the logs and the reports of the reconciliation
+func (c *AuthorizationChange) String() string {
+	subject := "role " + c.Role
+	if c.Group != "" {
+		subject = "group " + c.Group
+	}
+	return fmt.Sprintf("%s: %d added, %d removed", subject, len(c.AddedPermissions)+len(c.AddedMembers),
+		len(c.RemovedPermissions)+len(c.RemovedMembers))
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestAuthorizationReconciler_Reconcile(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com"},
+		Readers:        []string{"reader@example.com"},
+		SecretReaders:  []string{},
+	}
+	group := &models.Group{ID: 2, Name: "data-science", Members: []string{"alice@example.com"}}
+	stream := &models.Stream{ID: 3, Name: "stream", Owners: []string{"owner@example.com"}}
+
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListAll").Return([]*models.Project{project}, nil)
+	assignmentRepository := &mocks.RoleAssignmentRepository{}
+	assignmentRepository.On("ListByProject", project.ID).Return([]*models.RoleAssignment{}, nil)
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("List").Return([]*models.Group{group}, nil)
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("List").Return([]*models.Stream{stream}, nil)
+	teamRepository := &mocks.TeamRepository{}
+	teamRepository.On("List", (*models.ID)(nil)).Return([]*models.Team{}, nil)
+
+	reconciler := NewAuthorizationReconciler(projectRepository, assignmentRepository, groupRepository,
+		streamRepository, teamRepository, nil).(*authorizationReconciler)
+	expected, err := reconciler.expectedAuthorization()
+	require.NoError(t, err)
+
+	// the relations are up to date, except the secret readers of the project which lost their permissions, an
+	// administrator who was removed from the project, a permission and a role member of a deleted project
+	secretReaderRole := "mlp.projects.1.secret_reader"
+	adminRole := "mlp.projects.1.administrator"
+	deletedProjectRole := "mlp.projects.9.reader"
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("ListRoles", mock.Anything, "mlp.projects.").Return([]string{adminRole, deletedProjectRole}, nil)
+	authEnforcer.On("GetRolePermissions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, role string) []string {
+			switch role {
+			case secretReaderRole:
+				return []string{}
+			case enforcer.MLPAdminRole:
+				return append([]string{"mlp.projects.9.get", "other.permission"},
+					expected.rolePermissions[role]...)
+			}
+			return expected.rolePermissions[role]
+		}, nil)
+	authEnforcer.On("GetRoleMembers", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, role string) []string {
+			switch role {
+			case adminRole:
+				return []string{"former-admin@example.com", "admin@example.com"}
+			case deletedProjectRole:
+				return []string{"reader@example.com"}
+			}
+			return expected.roleMembers[role]
+		}, nil)
+	authEnforcer.On("GetGroupMembers", mock.Anything, group.Name).Return([]string{"alice@example.com"}, nil)
+	reconciler.authEnforcer = authEnforcer
+
+	expectedChanges := []*AuthorizationChange{
+		{Role: enforcer.MLPAdminRole, RemovedPermissions: []string{"mlp.projects.9.get"}},
+		{Role: adminRole, RemovedMembers: []string{"former-admin@example.com"}},
+		{Role: secretReaderRole, AddedPermissions: expected.rolePermissions[secretReaderRole]},
+		{Role: deletedProjectRole, RemovedMembers: []string{"reader@example.com"}},
+	}
+
+	changes, err := reconciler.Reconcile(context.Background(), AuthorizationReconcileOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+	authEnforcer.AssertNotCalled(t, "UpdateAuthorization", mock.Anything, mock.Anything)
+
+	authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+	changes, err = reconciler.Reconcile(context.Background(), AuthorizationReconcileOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, expectedChanges, changes)
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRolePermissions(enforcer.MLPAdminRole, []string{"mlp.projects.9.get"})
+	updateRequest.RemoveRoleMembers(adminRole, []string{"former-admin@example.com"})
+	updateRequest.AddRolePermissions(secretReaderRole, expected.rolePermissions[secretReaderRole])
+	updateRequest.RemoveRoleMembers(deletedProjectRole, []string{"reader@example.com"})
+	authEnforcer.AssertCalled(t, "UpdateAuthorization", mock.Anything, updateRequest)
+}
+
+func TestAuthorizationReconciler_ReconcileRevokeOnly(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com"},
+		Readers:        []string{},
+		SecretReaders:  []string{},
+	}
+	// an administrator is added to the project after the resources are first read
+	updatedProject := *project
+	updatedProject.Administrators = []string{"admin@example.com", "new-admin@example.com"}
+
+	projectRepository := &mocks.ProjectRepository{}
+	// the project is read by the test, then by the planning of the changes, before the update
+	projectRepository.On("ListAll").Return([]*models.Project{project}, nil).Twice()
+	projectRepository.On("ListAll").Return([]*models.Project{&updatedProject}, nil)
+	assignmentRepository := &mocks.RoleAssignmentRepository{}
+	assignmentRepository.On("ListByProject", project.ID).Return([]*models.RoleAssignment{}, nil)
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("List").Return([]*models.Group{}, nil)
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("List").Return([]*models.Stream{}, nil)
+	teamRepository := &mocks.TeamRepository{}
+	teamRepository.On("List", (*models.ID)(nil)).Return([]*models.Team{}, nil)
+
+	reconciler := NewAuthorizationReconciler(projectRepository, assignmentRepository, groupRepository,
+		streamRepository, teamRepository, nil).(*authorizationReconciler)
+	expected, err := reconciler.expectedAuthorization()
+	require.NoError(t, err)
+
+	// the permissions of the readers are missing, and the administrator role has a former administrator as well as
+	// the new one
+	adminRole := "mlp.projects.1.administrator"
+	readerRole := "mlp.projects.1.reader"
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("ListRoles", mock.Anything, "mlp.projects.").Return([]string{adminRole}, nil)
+	authEnforcer.On("GetRolePermissions", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, role string) []string {
+			if role == readerRole {
+				return []string{}
+			}
+			return expected.rolePermissions[role]
+		}, nil)
+	authEnforcer.On("GetRoleMembers", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, role string) []string {
+			if role == adminRole {
+				return []string{"admin@example.com", "former-admin@example.com", "new-admin@example.com"}
+			}
+			return expected.roleMembers[role]
+		}, nil)
+	authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+	reconciler.authEnforcer = authEnforcer
+
+	// the missing permissions are not added and the new administrator is not removed
+	changes, err := reconciler.Reconcile(context.Background(), AuthorizationReconcileOptions{RevokeOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []*AuthorizationChange{
+		{Role: adminRole, RemovedMembers: []string{"former-admin@example.com"}},
+	}, changes)
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRoleMembers(adminRole, []string{"former-admin@example.com"})
+	authEnforcer.AssertCalled(t, "UpdateAuthorization", mock.Anything, updateRequest)
+}
+
+func TestAuthorizationReconciler_ReconcileDisabled(t *testing.T) {
+	reconciler := NewAuthorizationReconciler(&mocks.ProjectRepository{}, &mocks.RoleAssignmentRepository{},
+		&mocks.GroupRepository{}, &mocks.StreamRepository{}, &mocks.TeamRepository{}, nil)
+	_, err := reconciler.Reconcile(context.Background(), AuthorizationReconcileOptions{})
+	assert.EqualError(t, err, "authorization is not enabled")
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+const (
+	// CheckOtherSubjectsPermission is the permission required to check the permissions of other users than the caller
+	CheckOtherSubjectsPermission = "mlp.authz.check"
+	// ListDecisionsPermission is the permission required to query the authorization decision log
+	ListDecisionsPermission = "mlp.authz.decisions.get"
+)
+
+// MLPAdminPermissions returns the permissions of the MLP administrators which are not specific to a resource
+func MLPAdminPermissions() []string {
+	return []string{"mlp.projects.post", "mlp.streams.post", "mlp.teams.post", "mlp.groups.post", "mlp.roles.post",
+		"mlp.roles.put", "mlp.roles.delete", CheckOtherSubjectsPermission, ListDecisionsPermission}
+}
+
+// AuthorizationService exposes the roles and permissions granted to the users
+type AuthorizationService interface {
+	// GetUserAuthorization returns the roles and the permissions of the user, either all of them or only those
+	// granting permissions in the project if projectID is not nil
+	GetUserAuthorization(ctx context.Context, user string, projectID *models.ID) (*models.UserAuthorization, error)
+	// CheckPermissions evaluates the permissions of the checks on behalf of the user. Checking the permissions of
+	// other subjects than the user requires the permission mlp.authz.check.
+	CheckPermissions(ctx context.Context, user string, checks []models.PermissionCheck) ([]models.PermissionCheck,
+		error)
+}
+
+func NewAuthorizationService(
+	groupsService GroupsService,
+	authEnforcer enforcer.Enforcer,
+	authEnabled bool) AuthorizationService {
+	return &authorizationService{
+		groupsService: groupsService,
+		authEnforcer:  authEnforcer,
+		authEnabled:   authEnabled,
+	}
+}
+
+type authorizationService struct {
+	groupsService GroupsService
+	authEnforcer  enforcer.Enforcer
+	authEnabled   bool
+}
+
+func (s *authorizationService) GetUserAuthorization(ctx context.Context, user string,
+	projectID *models.ID) (*models.UserAuthorization, error) {
+	groups, err := s.groupsService.ListUserGroups(user)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching groups of user %s: %w", user, err)
+	}
+	authorization := &models.UserAuthorization{
+		User:                 user,
+		AuthorizationEnabled: s.authEnabled,
+		Groups:               groups,
+		Roles:                []string{},
+		Permissions:          []string{},
+	}
+	if !s.authEnabled {
+		return authorization, nil
+	}
+	if user == "" {
+		return nil, apperrors.NewUnauthenticatedErrorf("the user of the request is unknown")
+	}
+
+	roles, err := s.authEnforcer.GetUserRoles(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		groupRoles, err := s.authEnforcer.GetGroupRoles(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, groupRoles...)
+	}
+
+	rolePermissions, err := s.getRolePermissions(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+	permissionPrefix := ""
+	if projectID != nil {
+		permissionPrefix = fmt.Sprintf("mlp.projects.%d.", *projectID)
+	}
+	permissionSet := make(map[string]bool)
+	for role, permissions := range rolePermissions {
+		granted := false
+		for _, permission := range permissions {
+			if strings.HasPrefix(permission, permissionPrefix) {
+				permissionSet[permission] = true
+				granted = true
+			}
+		}
+		if granted {
+			authorization.Roles = append(authorization.Roles, role)
+		}
+	}
+	for permission := range permissionSet {
+		authorization.Permissions = append(authorization.Permissions, permission)
+	}
+	sort.Strings(authorization.Roles)
+	sort.Strings(authorization.Permissions)
+	return authorization, nil
+}
+
+// getRolePermissions returns the permissions granted by each of the roles
+func (s *authorizationService) getRolePermissions(ctx context.Context, roles []string) (map[string][]string,
+	error) {
+	results := make([][]string, len(roles))
+	workers := new(errgroup.Group)
+	for i, role := range roles {
+		i, role := i, role
+		workers.Go(func() error {
+			permissions, err := s.authEnforcer.GetRolePermissions(ctx, role)
+			if err != nil {
+				return fmt.Errorf("error fetching permissions of role %s: %w", role, err)
+			}
+			results[i] = permissions
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+
+	rolePermissions := make(map[string][]string, len(roles))
+	for i, role := range roles {
+		rolePermissions[role] = append(rolePermissions[role], results[i]...)
+	}
+	return rolePermissions, nil
+}
+
+func (s *authorizationService) CheckPermissions(ctx context.Context, user string,
+	checks []models.PermissionCheck) ([]models.PermissionCheck, error) {
+	results := make([]models.PermissionCheck, len(checks))
+	checksOtherSubjects := false
+	for i, check := range checks {
+		if check.Subject == "" {
+			check.Subject = user
+		}
+		check.Allowed = !s.authEnabled
+		results[i] = check
+		checksOtherSubjects = checksOtherSubjects || check.Subject != user
+	}
+	if !s.authEnabled {
+		return results, nil
+	}
+	if user == "" {
+		return nil, apperrors.NewUnauthenticatedErrorf("the user of the request is unknown")
+	}
+
+	if checksOtherSubjects {
+		allowed, err := s.authEnforcer.IsUserGrantedPermission(ctx, user, CheckOtherSubjectsPermission)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, apperrors.NewUnauthenticatedErrorf(
+				"%s does not have the permission %s to check the permissions of other users", user,
+				CheckOtherSubjectsPermission)
+		}
+	}
+
+	// the enforcer caches the results, if enabled, so repeated checks do not call Keto
+	workers := new(errgroup.Group)
+	for i := range results {
+		check := &results[i]
+		workers.Go(func() error {
+			allowed, err := s.authEnforcer.IsUserGrantedPermission(ctx, check.Subject, check.Permission)
+			if err != nil {
+				return fmt.Errorf("error checking permission %s of %s: %w", check.Permission, check.Subject, err)
+			}
+			check.Allowed = allowed
+			return nil
+		})
+	}
+	if err := workers.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
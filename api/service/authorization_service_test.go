@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestAuthorizationService_GetUserAuthorization(t *testing.T) {
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("ListByMember", "a@example.com").Return([]*models.Group{{ID: 1, Name: "credit-risk"}}, nil)
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("GetUserRoles", context.Background(), "a@example.com").
+		Return([]string{"mlp.projects.reader", "mlp.projects.1.administrator"}, nil)
+	authEnforcer.On("GetGroupRoles", context.Background(), "credit-risk").
+		Return([]string{"mlp.projects.2.reader"}, nil)
+	authEnforcer.On("GetRolePermissions", context.Background(), "mlp.projects.reader").
+		Return([]string{"mlp.projects.get"}, nil)
+	authEnforcer.On("GetRolePermissions", context.Background(), "mlp.projects.1.administrator").
+		Return([]string{"mlp.projects.1.put", "mlp.projects.1.get"}, nil)
+	authEnforcer.On("GetRolePermissions", context.Background(), "mlp.projects.2.reader").
+		Return([]string{"mlp.projects.2.get"}, nil)
+	s := NewAuthorizationService(&groupsService{groupRepository: groupRepository}, authEnforcer, true)
+
+	authorization, err := s.GetUserAuthorization(context.Background(), "a@example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, &models.UserAuthorization{
+		User:                 "a@example.com",
+		AuthorizationEnabled: true,
+		Groups:               []string{"credit-risk"},
+		Roles:                []string{"mlp.projects.1.administrator", "mlp.projects.2.reader", "mlp.projects.reader"},
+		Permissions:          []string{"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.2.get", "mlp.projects.get"},
+	}, authorization)
+
+	projectID := models.ID(2)
+	authorization, err = s.GetUserAuthorization(context.Background(), "a@example.com", &projectID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mlp.projects.2.reader"}, authorization.Roles)
+	assert.Equal(t, []string{"mlp.projects.2.get"}, authorization.Permissions)
+}
+
+func TestAuthorizationService_CheckPermissions(t *testing.T) {
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("IsUserGrantedPermission", context.Background(), "a@example.com", "mlp.projects.1.get").
+		Return(true, nil)
+	authEnforcer.On("IsUserGrantedPermission", context.Background(), "a@example.com", "mlp.projects.2.get").
+		Return(false, nil)
+	authEnforcer.On("IsUserGrantedPermission", context.Background(), "a@example.com", "mlp.authz.check").
+		Return(false, nil)
+	s := NewAuthorizationService(nil, authEnforcer, true)
+
+	checks, err := s.CheckPermissions(context.Background(), "a@example.com", []models.PermissionCheck{
+		{Permission: "mlp.projects.1.get"},
+		{Subject: "a@example.com", Permission: "mlp.projects.2.get"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.PermissionCheck{
+		{Subject: "a@example.com", Permission: "mlp.projects.1.get", Allowed: true},
+		{Subject: "a@example.com", Permission: "mlp.projects.2.get", Allowed: false},
+	}, checks)
+
+	_, err = s.CheckPermissions(context.Background(), "a@example.com", []models.PermissionCheck{
+		{Subject: "b@example.com", Permission: "mlp.projects.1.get"},
+	})
+	assert.EqualError(t, err,
+		"a@example.com does not have the permission mlp.authz.check to check the permissions of other users")
+}
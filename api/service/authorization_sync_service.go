@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+const (
+	// authorizationSyncInitialBackoff is the delay before the first retry of a failed sync, doubled by each retry
+	authorizationSyncInitialBackoff = 10 * time.Second
+	// authorizationSyncMaxBackoff is the max delay between two retries of a failed sync
+	authorizationSyncMaxBackoff = time.Hour
+	// authorizationSyncBatchSize is the max number of projects synced by each run of the worker
+	authorizationSyncBatchSize = 100
+)
+
+// AuthorizationSyncService applies the outbox of the authorization policy changes of the projects. The policy of a
+// project is computed from the project when its syncs are applied, rather than when they are recorded, so that a
+// retried sync never reverts a more recent change of the project.
+type AuthorizationSyncService interface {
+	// ListProjectSyncs returns the pending and failed syncs of the project, oldest first
+	ListProjectSyncs(projectID models.ID) ([]*models.AuthorizationSync, error)
+	// SyncProject applies the authorization policy of the project if it has pending or failed syncs. The syncs that
+	// fail are retried by SyncDue with an exponential backoff.
+	SyncProject(ctx context.Context, projectID models.ID) error
+	// SyncDue applies the authorization policies of the projects whose syncs are due to be retried and returns the
+	// number of projects synced
+	SyncDue(ctx context.Context) (int, error)
+}
+
+func NewAuthorizationSyncService(
+	syncRepository repository.AuthorizationSyncRepository,
+	projectRepository repository.ProjectRepository,
+	authEnforcer enforcer.Enforcer) AuthorizationSyncService {
+	return &authorizationSyncService{
+		syncRepository:    syncRepository,
+		projectRepository: projectRepository,
+		authEnforcer:      authEnforcer,
+		now:               time.Now,
+	}
+}
+
+type authorizationSyncService struct {
+	syncRepository    repository.AuthorizationSyncRepository
+	projectRepository repository.ProjectRepository
+	authEnforcer      enforcer.Enforcer
+	now               func() time.Time
+}
+
+func (s *authorizationSyncService) ListProjectSyncs(projectID models.ID) ([]*models.AuthorizationSync, error) {
+	return s.syncRepository.ListByProject(projectID)
+}
+
+func (s *authorizationSyncService) SyncProject(ctx context.Context, projectID models.ID) error {
+	return s.syncRepository.Apply(projectID, func(syncs []*models.AuthorizationSync) error {
+		// the last sync supersedes the previous ones, e.g. the removal of the project
+		err := s.applyPolicy(ctx, projectID, syncs[len(syncs)-1].Action)
+		if err != nil {
+			now := s.now().UTC()
+			for _, sync := range syncs {
+				sync.Attempts++
+				sync.Status = models.AuthorizationSyncFailed
+				sync.LastError = err.Error()
+				sync.NextAttemptAt = now.Add(authorizationSyncBackoff(sync.Attempts))
+			}
+			return fmt.Errorf("error syncing authorization policy of project %s: %w", projectID, err)
+		}
+		return nil
+	})
+}
+
+func (s *authorizationSyncService) SyncDue(ctx context.Context) (int, error) {
+	projectIDs, err := s.syncRepository.ListDueProjects(s.now().UTC(), authorizationSyncBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error listing authorization syncs: %w", err)
+	}
+
+	synced, failed := 0, 0
+	for _, projectID := range projectIDs {
+		if err := s.SyncProject(ctx, projectID); err != nil {
+			log.Errorf("%s", err)
+			failed++
+			continue
+		}
+		synced++
+	}
+	if failed > 0 {
+		return synced, fmt.Errorf("failed to sync the authorization policies of %d projects", failed)
+	}
+	return synced, nil
+}
+
+func (s *authorizationSyncService) applyPolicy(ctx context.Context, projectID models.ID,
+	action models.AuthorizationSyncAction) error {
+	var updateRequest enforcer.AuthorizationUpdateRequest
+	var err error
+	switch action {
+	case models.AuthorizationSyncRemove:
+		updateRequest, err = projectRemovalAuthorizationPolicy(&models.Project{ID: projectID})
+	default:
+		project, getErr := s.projectRepository.Get(projectID)
+		if errors.Is(getErr, &apperrors.NotFoundError{}) {
+			// the project has been deleted since, its policy is removed by the sync of the deletion
+			return nil
+		}
+		if getErr != nil {
+			return getErr
+		}
+		updateRequest, err = projectAuthorizationPolicy(project)
+	}
+	if err != nil {
+		return err
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// authorizationSyncBackoff returns the delay before the next attempt of a sync which failed the given number of times
+func authorizationSyncBackoff(attempts int) time.Duration {
+	backoff := authorizationSyncInitialBackoff
+	for i := 1; i < attempts && backoff < authorizationSyncMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > authorizationSyncMaxBackoff {
+		return authorizationSyncMaxBackoff
+	}
+	return backoff
+}
+
+// AuthorizationSyncWorker periodically retries the authorization syncs which failed to be applied
+type AuthorizationSyncWorker struct {
+	syncService AuthorizationSyncService
+	interval    time.Duration
+}
+
+func NewAuthorizationSyncWorker(syncService AuthorizationSyncService,
+	interval time.Duration) *AuthorizationSyncWorker {
+	return &AuthorizationSyncWorker{
+		syncService: syncService,
+		interval:    interval,
+	}
+}
+
+// Run applies the due syncs every interval until the context is cancelled
+func (w *AuthorizationSyncWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.Sync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync applies the due syncs once. Errors are logged, the syncs that failed are retried later.
+func (w *AuthorizationSyncWorker) Sync(ctx context.Context) {
+	synced, err := w.syncService.SyncDue(ctx)
+	if err != nil {
+		log.Errorf("error syncing authorization policies: %s", err)
+	}
+	if synced > 0 {
+		log.Infof("synced the authorization policies of %d projects", synced)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+// applySyncs returns the Apply mock implementation calling apply with the syncs
+func applySyncs(syncs ...*models.AuthorizationSync) func(models.ID,
+	func([]*models.AuthorizationSync) error) error {
+	return func(_ models.ID, apply func([]*models.AuthorizationSync) error) error {
+		return apply(syncs)
+	}
+}
+
+func TestAuthorizationSyncService_SyncProject(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	project := &models.Project{ID: 1, Name: "project", Administrators: []string{"admin@example.com"}}
+	policy, err := projectAuthorizationPolicy(project)
+	require.NoError(t, err)
+	removalPolicy, err := projectRemovalAuthorizationPolicy(project)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		syncs           []*models.AuthorizationSync
+		projectErr      error
+		expectedUpdate  interface{}
+		updateErr       error
+		expectedError   string
+		expectedAttempt int
+	}{
+		{
+			name: "update",
+			syncs: []*models.AuthorizationSync{
+				{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncUpdate},
+				{ID: 2, ProjectID: 1, Action: models.AuthorizationSyncUpdate},
+			},
+			expectedUpdate: policy,
+		},
+		{
+			name: "removal supersedes the previous updates",
+			syncs: []*models.AuthorizationSync{
+				{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncUpdate},
+				{ID: 2, ProjectID: 1, Action: models.AuthorizationSyncRemove},
+			},
+			expectedUpdate: removalPolicy,
+		},
+		{
+			name:       "project deleted since",
+			syncs:      []*models.AuthorizationSync{{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncUpdate}},
+			projectErr: apperrors.NewNotFoundErrorf("project with ID 1 not found"),
+		},
+		{
+			name: "failure",
+			syncs: []*models.AuthorizationSync{
+				{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncUpdate, Attempts: 2},
+			},
+			expectedUpdate:  policy,
+			updateErr:       errors.New("keto is unavailable"),
+			expectedError:   "error syncing authorization policy of project 1: keto is unavailable",
+			expectedAttempt: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncRepository := &mocks.AuthorizationSyncRepository{}
+			syncRepository.On("Apply", project.ID, mock.Anything).Return(applySyncs(tt.syncs...))
+			projectRepository := &mocks.ProjectRepository{}
+			if tt.projectErr != nil {
+				projectRepository.On("Get", project.ID).Return(nil, tt.projectErr)
+			} else {
+				projectRepository.On("Get", project.ID).Return(project, nil)
+			}
+			authEnforcer := &enforcerMock.Enforcer{}
+			if tt.expectedUpdate != nil {
+				authEnforcer.On("UpdateAuthorization", mock.Anything, tt.expectedUpdate).Return(tt.updateErr)
+			}
+
+			syncService := NewAuthorizationSyncService(syncRepository, projectRepository, authEnforcer)
+			syncService.(*authorizationSyncService).now = func() time.Time { return now }
+			err := syncService.SyncProject(context.Background(), project.ID)
+			authEnforcer.AssertExpectations(t)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedError)
+			for _, sync := range tt.syncs {
+				assert.Equal(t, models.AuthorizationSyncFailed, sync.Status)
+				assert.Equal(t, tt.expectedAttempt, sync.Attempts)
+				assert.Equal(t, "keto is unavailable", sync.LastError)
+				assert.Equal(t, now.Add(40*time.Second), sync.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestAuthorizationSyncService_SyncDue(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	syncRepository := &mocks.AuthorizationSyncRepository{}
+	syncRepository.On("ListDueProjects", now, authorizationSyncBatchSize).Return([]models.ID{1, 2}, nil)
+	syncRepository.On("Apply", models.ID(1), mock.Anything).Return(
+		applySyncs(&models.AuthorizationSync{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncRemove}))
+	syncRepository.On("Apply", models.ID(2), mock.Anything).Return(
+		applySyncs(&models.AuthorizationSync{ID: 2, ProjectID: 2, Action: models.AuthorizationSyncRemove}))
+	removalPolicy, err := projectRemovalAuthorizationPolicy(&models.Project{ID: 2})
+	require.NoError(t, err)
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, removalPolicy).Return(errors.New("keto is unavailable"))
+	authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+
+	syncService := NewAuthorizationSyncService(syncRepository, &mocks.ProjectRepository{}, authEnforcer)
+	syncService.(*authorizationSyncService).now = func() time.Time { return now }
+	synced, err := syncService.SyncDue(context.Background())
+	assert.Equal(t, 1, synced)
+	assert.EqualError(t, err, "failed to sync the authorization policies of 1 projects")
+}
+
+func TestAuthorizationSyncBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, authorizationSyncBackoff(1))
+	assert.Equal(t, 20*time.Second, authorizationSyncBackoff(2))
+	assert.Equal(t, 80*time.Second, authorizationSyncBackoff(4))
+	assert.Equal(t, time.Hour, authorizationSyncBackoff(20))
+}
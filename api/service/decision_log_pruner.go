@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+)
+
+// DecisionLogPruner periodically deletes the authorization decisions older than the retention
+type DecisionLogPruner struct {
+	decisionsService AuthorizationDecisionsService
+	retention        time.Duration
+	interval         time.Duration
+	now              func() time.Time
+}
+
+func NewDecisionLogPruner(decisionsService AuthorizationDecisionsService, retention time.Duration,
+	interval time.Duration) *DecisionLogPruner {
+	return &DecisionLogPruner{
+		decisionsService: decisionsService,
+		retention:        retention,
+		interval:         interval,
+		now:              time.Now,
+	}
+}
+
+// Run deletes the expired decisions every interval until the context is cancelled
+func (p *DecisionLogPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.Prune()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes the decisions older than the retention. Errors are logged, the decisions are deleted by the next
+// pruning.
+func (p *DecisionLogPruner) Prune() {
+	deleted, err := p.decisionsService.PruneDecisions(p.now().UTC().Add(-p.retention))
+	if err != nil {
+		log.Errorf("error pruning authorization decisions: %s", err)
+	}
+	if deleted > 0 {
+		log.Infof("pruned %d authorization decisions", deleted)
+	}
+}
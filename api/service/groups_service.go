@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// GroupSyncResult lists the names of the groups changed by SyncGroups
+type GroupSyncResult struct {
+	Created []string
+	Updated []string
+	Deleted []string
+}
+
+// GroupsService manages the groups of users that can be granted access to projects. A group is granted access by
+// adding its subject, e.g. group:credit-risk, to the administrators or the readers of a project.
+type GroupsService interface {
+	// ListGroups returns all groups ordered by name
+	ListGroups() ([]*models.Group, error)
+	FindGroupByID(id models.ID) (*models.Group, error)
+	CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// UpdateGroup updates the group. A group granted access to projects cannot be renamed.
+	UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// DeleteGroup deletes the group. A group granted access to projects cannot be deleted.
+	DeleteGroup(ctx context.Context, group *models.Group) error
+	// ListUserGroups returns the names of the groups of which the user is a member
+	ListUserGroups(user string) ([]string, error)
+	// ValidateProject checks that the groups granted access to the project exist
+	ValidateProject(project *models.Project) error
+	// SyncGroups creates and updates the groups so that they match the given groups, e.g. read from a directory
+	// export. The groups that are not given are deleted if prune is set.
+	SyncGroups(ctx context.Context, groups []*models.Group, prune bool) (*GroupSyncResult, error)
+}
+
+func NewGroupsService(
+	groupRepository repository.GroupRepository,
+	projectRepository repository.ProjectRepository,
+	authEnforcer enforcer.Enforcer,
+	authEnabled bool) GroupsService {
+	return &groupsService{
+		groupRepository:   groupRepository,
+		projectRepository: projectRepository,
+		authEnforcer:      authEnforcer,
+		authEnabled:       authEnabled,
+	}
+}
+
+type groupsService struct {
+	groupRepository   repository.GroupRepository
+	projectRepository repository.ProjectRepository
+	authEnforcer      enforcer.Enforcer
+	authEnabled       bool
+}
+
+func (s *groupsService) ListGroups() ([]*models.Group, error) {
+	return s.groupRepository.List()
+}
+
+func (s *groupsService) FindGroupByID(id models.ID) (*models.Group, error) {
+	return s.groupRepository.Get(id)
+}
+
+func (s *groupsService) CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	if err := validateGroupMembers(group); err != nil {
+		return nil, err
+	}
+	_, err := s.groupRepository.GetByName(group.Name)
+	if err == nil {
+		return nil, apperrors.NewAlreadyExistsErrorf("group %s already exists", group.Name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return nil, err
+	}
+
+	group, err = s.groupRepository.Save(group)
+	if err != nil {
+		return nil, fmt.Errorf("error creating group %s: %w", group.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, nil, group); err != nil {
+		return nil, fmt.Errorf("error while creating authorization policy for group %s: %w", group.Name, err)
+	}
+	return group, nil
+}
+
+func (s *groupsService) UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	if err := validateGroupMembers(group); err != nil {
+		return nil, err
+	}
+	existingGroup, err := s.groupRepository.Get(group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Name != existingGroup.Name {
+		if err := s.checkNotGrantedToProjects(existingGroup, "renamed"); err != nil {
+			return nil, err
+		}
+		_, err := s.groupRepository.GetByName(group.Name)
+		if err == nil {
+			return nil, apperrors.NewAlreadyExistsErrorf("group %s already exists", group.Name)
+		}
+		if !errors.Is(err, &apperrors.NotFoundError{}) {
+			return nil, err
+		}
+	}
+
+	group, err = s.groupRepository.Save(group)
+	if err != nil {
+		return nil, fmt.Errorf("error updating group %s: %w", existingGroup.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, existingGroup, group); err != nil {
+		return nil, fmt.Errorf("error while updating authorization policy for group %s: %w", group.Name, err)
+	}
+	return group, nil
+}
+
+func (s *groupsService) DeleteGroup(ctx context.Context, group *models.Group) error {
+	if err := s.checkNotGrantedToProjects(group, "deleted"); err != nil {
+		return err
+	}
+
+	if err := s.groupRepository.Delete(group.ID); err != nil {
+		return fmt.Errorf("error deleting group %s: %w", group.Name, err)
+	}
+	if err := s.removeAuthorizationPolicy(ctx, group); err != nil {
+		return fmt.Errorf("error while removing authorization policy of group %s: %w", group.Name, err)
+	}
+	return nil
+}
+
+func (s *groupsService) ListUserGroups(user string) ([]string, error) {
+	groups, err := s.groupRepository.ListByMember(user)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	return names, nil
+}
+
+func (s *groupsService) ValidateProject(project *models.Project) error {
+	violations := make([]apperrors.FieldViolation, 0)
+	for _, subjects := range []struct {
+		field    string
+		subjects []string
+	}{
+		{"administrators", project.Administrators},
+		{"readers", project.Readers},
+		{"secret_readers", project.SecretReaders},
+	} {
+		for _, subject := range subjects.subjects {
+			name, ok := models.ParseGroupSubject(subject)
+			if !ok {
+				continue
+			}
+			_, err := s.groupRepository.GetByName(name)
+			if errors.Is(err, &apperrors.NotFoundError{}) {
+				violations = append(violations, apperrors.FieldViolation{
+					Field:   subjects.field,
+					Rule:    "known_group",
+					Message: fmt.Sprintf("group %s does not exist", name),
+				})
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return apperrors.NewValidationError("project is granted to unknown groups", violations...)
+	}
+	return nil
+}
+
+func (s *groupsService) SyncGroups(ctx context.Context, groups []*models.Group, prune bool) (*GroupSyncResult,
+	error) {
+	existingGroups, err := s.groupRepository.List()
+	if err != nil {
+		return nil, fmt.Errorf("error listing groups: %w", err)
+	}
+	existingGroupsByName := make(map[string]*models.Group)
+	for _, group := range existingGroups {
+		existingGroupsByName[group.Name] = group
+	}
+
+	result := &GroupSyncResult{Created: []string{}, Updated: []string{}, Deleted: []string{}}
+	names := make(map[string]bool)
+	for _, group := range groups {
+		if names[group.Name] {
+			return result, apperrors.NewInvalidArgumentErrorf("group %s is defined more than once", group.Name)
+		}
+		names[group.Name] = true
+
+		existingGroup, ok := existingGroupsByName[group.Name]
+		if !ok {
+			if _, err := s.CreateGroup(ctx, group); err != nil {
+				return result, fmt.Errorf("error syncing group %s: %w", group.Name, err)
+			}
+			log.Infof("created group %s", group.Name)
+			result.Created = append(result.Created, group.Name)
+			continue
+		}
+
+		if existingGroup.Description == group.Description &&
+			slices.Equal(existingGroup.Members, group.Members) &&
+			slices.Equal(existingGroup.Owners, group.Owners) {
+			continue
+		}
+		updatedGroup := *existingGroup
+		updatedGroup.Description = group.Description
+		updatedGroup.Members = group.Members
+		updatedGroup.Owners = group.Owners
+		if _, err := s.UpdateGroup(ctx, &updatedGroup); err != nil {
+			return result, fmt.Errorf("error syncing group %s: %w", group.Name, err)
+		}
+		log.Infof("updated group %s", group.Name)
+		result.Updated = append(result.Updated, group.Name)
+	}
+
+	if !prune {
+		return result, nil
+	}
+	for _, existingGroup := range existingGroups {
+		if names[existingGroup.Name] {
+			continue
+		}
+		if err := s.DeleteGroup(ctx, existingGroup); err != nil {
+			return result, fmt.Errorf("error syncing group %s: %w", existingGroup.Name, err)
+		}
+		log.Infof("deleted group %s", existingGroup.Name)
+		result.Deleted = append(result.Deleted, existingGroup.Name)
+	}
+	return result, nil
+}
+
+// checkNotGrantedToProjects returns an invalid argument error if the group is granted access to any active project
+func (s *groupsService) checkNotGrantedToProjects(group *models.Group, action string) error {
+	page, pageSize := int32(1), int32(1)
+	_, count, err := s.projectRepository.ListProjects(repository.ProjectFilter{
+		Member:  models.GroupSubject(group.Name),
+		Options: pagination.Options{Page: &page, PageSize: &pageSize},
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewInvalidArgumentErrorf("group %s is granted access to %d projects and cannot be %s",
+			group.Name, count, action)
+	}
+	return nil
+}
+
+// updateAuthorizationPolicy sets the members of the group in Keto, so that the roles granted to the group are
+// granted to its members, and allows the owners of the group to update and delete it
+func (s *groupsService) updateAuthorizationPolicy(ctx context.Context, before *models.Group,
+	group *models.Group) error {
+	if !s.authEnabled {
+		return nil
+	}
+	updateRequest, err := groupAuthorizationPolicy(group)
+	if err != nil {
+		return err
+	}
+	if before != nil && before.Name != group.Name {
+		updateRequest.SetGroupMembers(before.Name, []string{})
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// groupAuthorizationPolicy returns the update request setting the members of the group and granting the permissions
+// of its owners
+func groupAuthorizationPolicy(group *models.Group) (enforcer.AuthorizationUpdateRequest, error) {
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	members := []string(group.Members)
+	if members == nil {
+		members = []string{}
+	}
+	updateRequest.SetGroupMembers(group.Name, members)
+	if err := groupResource.grantOwners(updateRequest, group.ID, group.Owners); err != nil {
+		return updateRequest, err
+	}
+	return updateRequest, nil
+}
+
+// removeAuthorizationPolicy reverts the changes made by updateAuthorizationPolicy
+func (s *groupsService) removeAuthorizationPolicy(ctx context.Context, group *models.Group) error {
+	if !s.authEnabled {
+		return nil
+	}
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	updateRequest.SetGroupMembers(group.Name, []string{})
+	if err := groupResource.revokeOwners(updateRequest, group.ID); err != nil {
+		return err
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// validateGroupMembers rejects groups nested in other groups, which are not expanded when granting access
+func validateGroupMembers(group *models.Group) error {
+	for _, member := range group.Members {
+		if _, ok := models.ParseGroupSubject(member); ok {
+			return apperrors.NewValidationError("groups cannot be nested", apperrors.FieldViolation{
+				Field:   "members",
+				Rule:    "no_nested_groups",
+				Message: fmt.Sprintf("member %s is a group", member),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestGroupsService_CreateGroup(t *testing.T) {
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("GetByName", "credit-risk").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	groupRepository.On("Save", mock.Anything).Return(&models.Group{
+		ID:      2,
+		Name:    "credit-risk",
+		Members: []string{"alice@example.com"},
+		Owners:  []string{"owner@example.com"},
+	}, nil)
+
+	expectedUpdate := enforcer.NewAuthorizationUpdateRequest()
+	expectedUpdate.SetGroupMembers("credit-risk", []string{"alice@example.com"})
+	expectedUpdate.SetRoleMembers("mlp.groups.2.owner", []string{"owner@example.com"})
+	expectedUpdate.AddRolePermissions(enforcer.MLPAdminRole, []string{"mlp.groups.2.put", "mlp.groups.2.delete"})
+	expectedUpdate.AddRolePermissions("mlp.groups.2.owner", []string{"mlp.groups.2.put", "mlp.groups.2.delete"})
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, expectedUpdate).Return(nil)
+
+	s := NewGroupsService(groupRepository, &mocks.ProjectRepository{}, authEnforcer, true)
+	group, err := s.CreateGroup(context.Background(), &models.Group{
+		Name:    "credit-risk",
+		Members: []string{"alice@example.com"},
+		Owners:  []string{"owner@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(2), group.ID)
+	authEnforcer.AssertExpectations(t)
+
+	_, err = s.CreateGroup(context.Background(), &models.Group{
+		Name:    "nested",
+		Members: []string{"group:credit-risk"},
+	})
+	var validationErr *apperrors.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "no_nested_groups", validationErr.Violations[0].Rule)
+}
+
+func TestGroupsService_UpdateGroup(t *testing.T) {
+	existingGroup := &models.Group{ID: 2, Name: "credit-risk", Members: []string{"alice@example.com"}}
+	tests := map[string]struct {
+		group        *models.Group
+		projectCount int
+		expectedErr  string
+	}{
+		"update members": {
+			group: &models.Group{ID: 2, Name: "credit-risk", Members: []string{"bob@example.com"}},
+		},
+		"rename group granted to projects": {
+			group:        &models.Group{ID: 2, Name: "risk", Members: []string{"alice@example.com"}},
+			projectCount: 3,
+			expectedErr:  "group credit-risk is granted access to 3 projects and cannot be renamed",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			groupRepository := &mocks.GroupRepository{}
+			groupRepository.On("Get", models.ID(2)).Return(existingGroup, nil)
+			groupRepository.On("Save", tt.group).Return(tt.group, nil)
+			projectRepository := &mocks.ProjectRepository{}
+			projectRepository.On("ListProjects", mock.MatchedBy(func(filter repository.ProjectFilter) bool {
+				return filter.Member == "group:credit-risk"
+			})).Return([]*models.Project{}, tt.projectCount, nil)
+
+			s := NewGroupsService(groupRepository, projectRepository, nil, false)
+			group, err := s.UpdateGroup(context.Background(), tt.group)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.group, group)
+		})
+	}
+}
+
+func TestGroupsService_ValidateProject(t *testing.T) {
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("GetByName", "credit-risk").Return(&models.Group{ID: 1, Name: "credit-risk"}, nil)
+	groupRepository.On("GetByName", mock.Anything).Return(nil, apperrors.NewNotFoundErrorf("not found"))
+
+	s := NewGroupsService(groupRepository, &mocks.ProjectRepository{}, nil, false)
+	assert.NoError(t, s.ValidateProject(&models.Project{
+		Administrators: []string{"admin@example.com", "group:credit-risk"},
+		Readers:        []string{"reader@example.com"},
+	}))
+
+	err := s.ValidateProject(&models.Project{
+		Administrators: []string{"group:credit-risk"},
+		Readers:        []string{"group:crdit-risk"},
+	})
+	assert.Equal(t, apperrors.NewValidationError("project is granted to unknown groups", apperrors.FieldViolation{
+		Field:   "readers",
+		Rule:    "known_group",
+		Message: "group crdit-risk does not exist",
+	}), err)
+}
+
+func TestGroupsService_SyncGroups(t *testing.T) {
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("List").Return([]*models.Group{
+		{ID: 1, Name: "credit-risk", Members: []string{"alice@example.com"}},
+		{ID: 2, Name: "growth", Members: []string{"bob@example.com"}},
+		{ID: 3, Name: "legacy"},
+	}, nil)
+	groupRepository.On("GetByName", "fraud").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	groupRepository.On("Save", mock.MatchedBy(func(group *models.Group) bool {
+		return group.Name == "fraud"
+	})).Return(&models.Group{ID: 4, Name: "fraud"}, nil).Once()
+	groupRepository.On("Get", models.ID(2)).Return(&models.Group{ID: 2, Name: "growth"}, nil)
+	groupRepository.On("Save", mock.MatchedBy(func(group *models.Group) bool {
+		return group.ID == 2 && len(group.Members) == 2
+	})).Return(&models.Group{ID: 2, Name: "growth"}, nil).Once()
+	groupRepository.On("Delete", models.ID(3)).Return(nil)
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", mock.Anything).Return([]*models.Project{}, 0, nil)
+
+	s := NewGroupsService(groupRepository, projectRepository, nil, false)
+	result, err := s.SyncGroups(context.Background(), []*models.Group{
+		{Name: "credit-risk", Members: []string{"alice@example.com"}},
+		{Name: "growth", Members: []string{"bob@example.com", "carol@example.com"}},
+		{Name: "fraud"},
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, &GroupSyncResult{
+		Created: []string{"fraud"},
+		Updated: []string{"growth"},
+		Deleted: []string{"legacy"},
+	}, result)
+	groupRepository.AssertExpectations(t)
+}
+
+func TestProjectsService_ListProjectsIncludesGroups(t *testing.T) {
+	groupRepository := &mocks.GroupRepository{}
+	groupRepository.On("ListByMember", "alice@example.com").Return([]*models.Group{{ID: 1, Name: "credit-risk"}},
+		nil)
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", repository.ProjectFilter{
+		Member:       "alice@example.com",
+		MemberGroups: []string{"group:credit-risk"},
+	}).Return([]*models.Project{{ID: 1, Name: "project"}}, 1, nil)
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("GetUserRoles", mock.Anything, "alice@example.com").Return([]string{}, nil)
+
+	groupsService := NewGroupsService(groupRepository, projectRepository, authEnforcer, true)
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, authEnforcer, true,
+		nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, groupsService, nil, nil)
+	require.NoError(t, err)
+
+	projects, _, err := projectsService.ListProjects(context.Background(), repository.ProjectFilter{},
+		"alice@example.com")
+	require.NoError(t, err)
+	assert.Len(t, projects, 1)
+	projectRepository.AssertExpectations(t)
+}
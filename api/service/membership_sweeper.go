@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+// membershipSweeperActor is recorded as the actor of the removals of expired members in the audit log
+const membershipSweeperActor = "mlp-membership-sweeper"
+
+// MembershipSweeper periodically removes the members whose membership of a project has expired
+type MembershipSweeper struct {
+	projectsService ProjectsService
+	interval        time.Duration
+	now             func() time.Time
+}
+
+func NewMembershipSweeper(projectsService ProjectsService, interval time.Duration) *MembershipSweeper {
+	return &MembershipSweeper{
+		projectsService: projectsService,
+		interval:        interval,
+		now:             time.Now,
+	}
+}
+
+// Run removes the expired members every interval until the context is cancelled
+func (s *MembershipSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep removes the members whose membership has expired. Errors are logged, the removals that failed are retried by
+// the next sweep.
+func (s *MembershipSweeper) Sweep(ctx context.Context) {
+	removed, err := s.projectsService.RemoveExpiredMembers(requestctx.WithActor(ctx, membershipSweeperActor),
+		s.now())
+	if err != nil {
+		log.Errorf("error removing expired project members: %s", err)
+	}
+	if removed > 0 {
+		log.Infof("removed %d expired project memberships", removed)
+	}
+}
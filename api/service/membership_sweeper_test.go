@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	"github.com/caraml-dev/mlp/api/pkg/webhooks"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestMembershipSweeper_Sweep(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiredMembership := models.ProjectMemberExpiry{
+		Member:    "contractor@example.com",
+		Role:      models.ProjectAdministratorRole,
+		ExpiresAt: now.Add(-time.Minute),
+	}
+	activeMembership := models.ProjectMemberExpiry{
+		Member:    "oncall@example.com",
+		Role:      models.ProjectReaderRole,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com", "contractor@example.com"},
+		Readers:        []string{"oncall@example.com"},
+		MemberExpiries: models.ProjectMemberExpiries{expiredMembership, activeMembership},
+	}
+
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", expiredMembersFilter(now)).Return([]*models.Project{project}, 1, nil)
+	projectRepository.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+		return assert.ObjectsAreEqual([]string{"admin@example.com"}, []string(p.Administrators)) &&
+			assert.ObjectsAreEqual([]string{"oncall@example.com"}, []string(p.Readers)) &&
+			assert.ObjectsAreEqual(models.ProjectMemberExpiries{activeMembership}, p.MemberExpiries)
+	}), mock.MatchedBy(func(options repository.ProjectWriteOptions) bool {
+		return options.AuditLog.Action == models.ProjectMembersExpiredAction &&
+			options.AuditLog.Actor == "mlp-membership-sweeper"
+	})).Return(func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project { return p }, nil)
+	auditLogRepository := &mocks.ProjectAuditLogRepository{}
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+	webhookManager := &webhooks.MockWebhookManager{}
+	webhookManager.On("IsEventConfigured", ProjectMembersExpiredEvent).Return(true)
+	webhookManager.On("InvokeWebhooks", mock.Anything, ProjectMembersExpiredEvent,
+		mock.MatchedBy(func(payload ProjectMembersExpiredPayload) bool {
+			return assert.ObjectsAreEqual(models.ProjectMemberExpiries{expiredMembership}, payload.ExpiredMembers)
+		}), mock.Anything, mock.Anything).Return(nil)
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, auditLogRepository, nil,
+		authEnforcer, true, webhookManager, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+	sweeper := NewMembershipSweeper(projectsService, time.Minute)
+	sweeper.now = func() time.Time { return now }
+	sweeper.Sweep(context.Background())
+
+	projectRepository.AssertExpectations(t)
+	authEnforcer.AssertExpectations(t)
+	webhookManager.AssertExpectations(t)
+}
+
+func TestProjectsService_RemoveExpiredMembersIgnoresWebhookErrors(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com", "contractor@example.com"},
+		MemberExpiries: models.ProjectMemberExpiries{{
+			Member:    "contractor@example.com",
+			Role:      models.ProjectAdministratorRole,
+			ExpiresAt: now.Add(-time.Minute),
+		}},
+	}
+
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", expiredMembersFilter(now)).Return([]*models.Project{project}, 1, nil)
+	projectRepository.On("SaveWith", mock.Anything, repository.ProjectWriteOptions{}).Return(
+		func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project { return p }, nil)
+	webhookManager := &webhooks.MockWebhookManager{}
+	webhookManager.On("IsEventConfigured", ProjectMembersExpiredEvent).Return(true)
+	webhookManager.On("InvokeWebhooks", mock.Anything, ProjectMembersExpiredEvent, mock.Anything, mock.Anything,
+		mock.Anything).Return(errors.New("webhook failed"))
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+		webhookManager, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// the membership has been revoked even though the webhook failed
+	removed, err := projectsService.RemoveExpiredMembers(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	webhookManager.AssertExpectations(t)
+}
+
+func TestProjectsService_RemoveExpiredMembersOfArchivedProject(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	archivedAt := now.Add(-time.Hour)
+	project := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com", "contractor@example.com"},
+		MemberExpiries: models.ProjectMemberExpiries{{
+			Member:    "contractor@example.com",
+			Role:      models.ProjectAdministratorRole,
+			ExpiresAt: now.Add(-time.Minute),
+		}},
+		ArchivedAt: &archivedAt,
+	}
+
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", expiredMembersFilter(now)).Return([]*models.Project{project}, 1, nil)
+	projectRepository.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+		return p.ArchivedAt != nil &&
+			assert.ObjectsAreEqual([]string{"admin@example.com"}, []string(p.Administrators)) &&
+			len(p.MemberExpiries) == 0
+	}), repository.ProjectWriteOptions{}).Return(
+		func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project { return p }, nil)
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+		nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	removed, err := projectsService.RemoveExpiredMembers(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	projectRepository.AssertExpectations(t)
+}
+
+func TestProjectsService_RemoveExpiredMembersKeepsRegrantedMember(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	stored := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com", "contractor@example.com"},
+		MemberExpiries: models.ProjectMemberExpiries{{
+			Member:    "contractor@example.com",
+			Role:      models.ProjectAdministratorRole,
+			ExpiresAt: now.Add(-time.Minute),
+		}},
+	}
+
+	// the repository keeps the last saved project, so that the sweeper lists what the updates have saved
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("Get", models.ID(1)).Return(func(models.ID) *models.Project {
+		project := *stored
+		return &project
+	}, nil)
+	projectRepository.On("SaveWith", mock.Anything, repository.ProjectWriteOptions{}).Return(
+		func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project {
+			stored = p
+			return p
+		}, nil).Twice()
+	projectRepository.On("ListProjects", expiredMembersFilter(now)).Return(
+		func(repository.ProjectFilter) []*models.Project {
+			if len(stored.MemberExpiries.Expired(now)) == 0 {
+				return []*models.Project{}
+			}
+			return []*models.Project{stored}
+		}, func(repository.ProjectFilter) int {
+			return len(stored.MemberExpiries.Expired(now))
+		}, nil)
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+		nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	// the member is removed without removing their expiry, then granted the role again without an expiry
+	removedMember := &models.Project{
+		ID:             1,
+		Name:           "project",
+		Administrators: []string{"admin@example.com"},
+		MemberExpiries: stored.MemberExpiries,
+	}
+	_, _, err = projectsService.UpdateProject(context.Background(), removedMember)
+	require.NoError(t, err)
+	assert.Empty(t, stored.MemberExpiries)
+	_, err = projectsService.GrantProjectAccess(context.Background(), stored, "contractor@example.com",
+		models.ProjectAdministratorRole)
+	require.NoError(t, err)
+
+	removed, err := projectsService.RemoveExpiredMembers(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	assert.Equal(t, []string{"admin@example.com", "contractor@example.com"}, []string(stored.Administrators))
+	projectRepository.AssertExpectations(t)
+}
+
+func expiredMembersFilter(now time.Time) repository.ProjectFilter {
+	return repository.ProjectFilter{MembershipExpiredBefore: &now, IncludeArchived: true}
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/caraml-dev/mlp/api/models"
@@ -73,6 +75,27 @@ func (_m *SecretService) FindByID(secretID models.ID) (*models.Secret, error) {
 	return r0, r1
 }
 
+// IsReadable provides a mock function with given fields: ctx, user, secret
+func (_m *SecretService) IsReadable(ctx context.Context, user string, secret *models.Secret) (bool, error) {
+	ret := _m.Called(ctx, user, secret)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Secret) bool); ok {
+		r0 = rf(ctx, user, secret)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.Secret) error); ok {
+		r1 = rf(ctx, user, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: projectID
 func (_m *SecretService) List(projectID models.ID) ([]*models.Secret, error) {
 	ret := _m.Called(projectID)
@@ -96,6 +119,20 @@ func (_m *SecretService) List(projectID models.ID) ([]*models.Secret, error) {
 	return r0, r1
 }
 
+// RedactUnreadable provides a mock function with given fields: ctx, user, secrets
+func (_m *SecretService) RedactUnreadable(ctx context.Context, user string, secrets []*models.Secret) error {
+	ret := _m.Called(ctx, user, secrets)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*models.Secret) error); ok {
+		r0 = rf(ctx, user, secrets)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: secret
 func (_m *SecretService) Update(secret *models.Secret) (*models.Secret, error) {
 	ret := _m.Called(secret)
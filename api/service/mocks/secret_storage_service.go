@@ -142,6 +142,29 @@ func (_m *SecretStorageService) Update(storage *models.SecretStorage) (*models.S
 	return r0, r1
 }
 
+// UpdateGlobal provides a mock function with given fields: storage
+func (_m *SecretStorageService) UpdateGlobal(storage *models.SecretStorage) (*models.SecretStorage, error) {
+	ret := _m.Called(storage)
+
+	var r0 *models.SecretStorage
+	if rf, ok := ret.Get(0).(func(*models.SecretStorage) *models.SecretStorage); ok {
+		r0 = rf(storage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SecretStorage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.SecretStorage) error); ok {
+		r1 = rf(storage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewSecretStorageService interface {
 	mock.TestingT
 	Cleanup(func())
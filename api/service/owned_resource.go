@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+)
+
+// ownedResource describes the authorization policy of a resource that can be updated and deleted by its owners, in
+// addition to the MLP administrators
+type ownedResource struct {
+	// path is the first segment of the API path of the resource, used to build the permissions
+	path string
+	// ownerRole is the template of the role granted to the owners of the resource
+	ownerRole string
+	// idKey is the name of the template variable holding the resource ID in ownerRole
+	idKey string
+}
+
+var (
+	streamResource = ownedResource{path: "streams", ownerRole: enforcer.MLPStreamOwnerRole, idKey: "StreamId"}
+	teamResource   = ownedResource{path: "teams", ownerRole: enforcer.MLPTeamOwnerRole, idKey: "TeamId"}
+	groupResource  = ownedResource{path: "groups", ownerRole: enforcer.MLPGroupOwnerRole, idKey: "GroupId"}
+)
+
+func (r ownedResource) permissions(id models.ID) []string {
+	permissions := make([]string, 0)
+	for _, method := range []string{"put", "delete"} {
+		permissions = append(permissions, fmt.Sprintf("mlp.%s.%d.%s", r.path, id, method))
+	}
+	return permissions
+}
+
+// grantOwners adds to the update request the owners of the resource and the permissions of the owners and the MLP
+// administrators
+func (r ownedResource) grantOwners(updateRequest enforcer.AuthorizationUpdateRequest, id models.ID,
+	owners []string) error {
+	ownerRole, err := enforcer.ParseRole(r.ownerRole, map[string]string{r.idKey: id.String()})
+	if err != nil {
+		return err
+	}
+	if owners == nil {
+		owners = []string{}
+	}
+
+	updateRequest.SetRoleMembers(ownerRole, owners)
+	for _, role := range []string{enforcer.MLPAdminRole, ownerRole} {
+		updateRequest.AddRolePermissions(role, r.permissions(id))
+	}
+	return nil
+}
+
+// revokeOwners adds to the update request the removal of the changes made by grantOwners
+func (r ownedResource) revokeOwners(updateRequest enforcer.AuthorizationUpdateRequest, id models.ID) error {
+	ownerRole, err := enforcer.ParseRole(r.ownerRole, map[string]string{r.idKey: id.String()})
+	if err != nil {
+		return err
+	}
+
+	updateRequest.SetRoleMembers(ownerRole, []string{})
+	for _, role := range []string{enforcer.MLPAdminRole, ownerRole} {
+		updateRequest.RemoveRolePermissions(role, r.permissions(id))
+	}
+	return nil
+}
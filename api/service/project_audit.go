@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+)
+
+// auditedProjectFields are the project fields whose changes are recorded in the audit log, keyed by their JSON name.
+// Empty values are normalised to nil so that e.g. an empty and a missing list of readers are considered equal.
+var auditedProjectFields = map[string]func(project *models.Project) interface{}{
+	"name": func(project *models.Project) interface{} {
+		if project.Name == "" {
+			return nil
+		}
+		return project.Name
+	},
+	"administrators": func(project *models.Project) interface{} {
+		if len(project.Administrators) == 0 {
+			return nil
+		}
+		return []string(project.Administrators)
+	},
+	"readers": func(project *models.Project) interface{} {
+		if len(project.Readers) == 0 {
+			return nil
+		}
+		return []string(project.Readers)
+	},
+	"secret_readers": func(project *models.Project) interface{} {
+		if len(project.SecretReaders) == 0 {
+			return nil
+		}
+		return []string(project.SecretReaders)
+	},
+	"member_expiries": func(project *models.Project) interface{} {
+		if len(project.MemberExpiries) == 0 {
+			return nil
+		}
+		return project.MemberExpiries
+	},
+	"team": func(project *models.Project) interface{} {
+		if project.Team == "" {
+			return nil
+		}
+		return project.Team
+	},
+	"stream": func(project *models.Project) interface{} {
+		if project.Stream == "" {
+			return nil
+		}
+		return project.Stream
+	},
+	"labels": func(project *models.Project) interface{} {
+		if len(project.Labels) == 0 {
+			return nil
+		}
+		return project.Labels
+	},
+	"archived_at": func(project *models.Project) interface{} {
+		if project.ArchivedAt == nil {
+			return nil
+		}
+		return *project.ArchivedAt
+	},
+}
+
+// diffProjects returns the audited fields that differ between the two versions of a project. A nil project is
+// treated as having all fields unset, e.g. before a project is created or after it is deleted.
+func diffProjects(before *models.Project, after *models.Project) models.ProjectChanges {
+	changes := models.ProjectChanges{}
+	for field, value := range auditedProjectFields {
+		var beforeValue, afterValue interface{}
+		if before != nil {
+			beforeValue = value(before)
+		}
+		if after != nil {
+			afterValue = value(after)
+		}
+		if !reflect.DeepEqual(beforeValue, afterValue) {
+			changes[field] = models.ProjectChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	return changes
+}
+
+// newAuditLog returns the audit log of the change made to the project by the user of the request, which is recorded
+// in the same transaction as the change. It returns nil if the service is not configured with an audit log repository,
+// in which case nothing is recorded.
+func (service *projectsService) newAuditLog(ctx context.Context, action models.ProjectAuditAction,
+	before *models.Project, after *models.Project) *models.ProjectAuditLog {
+	if service.auditLogRepository == nil {
+		return nil
+	}
+
+	return &models.ProjectAuditLog{
+		Action:    action,
+		Actor:     requestctx.Actor(ctx),
+		RequestID: requestctx.RequestID(ctx),
+		Changes:   diffProjects(before, after),
+	}
+}
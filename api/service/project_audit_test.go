@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func Test_diffProjects(t *testing.T) {
+	archivedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"admin@email.com"},
+		Team:           "dsp",
+		Stream:         "dsp",
+		Labels:         models.Labels{{Key: "env", Value: "dev"}},
+	}
+
+	tests := map[string]struct {
+		before   *models.Project
+		after    *models.Project
+		expected models.ProjectChanges
+	}{
+		"created": {
+			after: project,
+			expected: models.ProjectChanges{
+				"name":           {After: "my-project"},
+				"administrators": {After: []string{"admin@email.com"}},
+				"team":           {After: "dsp"},
+				"stream":         {After: "dsp"},
+				"labels":         {After: models.Labels{{Key: "env", Value: "dev"}}},
+			},
+		},
+		"reader added": {
+			before: project,
+			after: &models.Project{
+				ID:             1,
+				Name:           "my-project",
+				Administrators: []string{"admin@email.com"},
+				Readers:        []string{"reader@email.com"},
+				Team:           "dsp",
+				Stream:         "dsp",
+				Labels:         models.Labels{{Key: "env", Value: "dev"}},
+			},
+			expected: models.ProjectChanges{
+				"readers": {After: []string{"reader@email.com"}},
+			},
+		},
+		"archived and unaudited fields changed": {
+			before: project,
+			after: &models.Project{
+				ID:                1,
+				Name:              "my-project",
+				MLFlowTrackingURL: MLFlowTrackingURL,
+				Administrators:    []string{"admin@email.com"},
+				Readers:           []string{},
+				Team:              "dsp",
+				Stream:            "dsp",
+				Labels:            models.Labels{{Key: "env", Value: "dev"}},
+				ArchivedAt:        &archivedAt,
+				Version:           2,
+			},
+			expected: models.ProjectChanges{
+				"archived_at": {After: archivedAt},
+			},
+		},
+		"deleted": {
+			before: project,
+			expected: models.ProjectChanges{
+				"name":           {Before: "my-project"},
+				"administrators": {Before: []string{"admin@email.com"}},
+				"team":           {Before: "dsp"},
+				"stream":         {Before: "dsp"},
+				"labels":         {Before: models.Labels{{Key: "env", Value: "dev"}}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffProjects(tt.before, tt.after))
+		})
+	}
+}
+
+func TestProjectsService_UpdateProjectRecordsAuditLog(t *testing.T) {
+	existingProject := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"admin@email.com"},
+		Team:           "dsp",
+		Stream:         "dsp",
+	}
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"admin@email.com", "other@email.com"},
+		Team:           "dsp",
+		Stream:         "dsp",
+	}
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("Get", project.ID).Return(existingProject, nil)
+	storage.On("SaveWith", project, repository.ProjectWriteOptions{
+		AuditLog: &models.ProjectAuditLog{
+			Action:    models.ProjectUpdatedAction,
+			Actor:     "admin@email.com",
+			RequestID: "request-id",
+			Changes: models.ProjectChanges{
+				"administrators": {
+					Before: []string{"admin@email.com"},
+					After:  []string{"admin@email.com", "other@email.com"},
+				},
+			},
+		},
+	}).Return(project, nil)
+
+	// the audit log is recorded by the project repository, in the same transaction as the project
+	auditLogRepository := &mocks.ProjectAuditLogRepository{}
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, auditLogRepository, nil, nil, false, nil,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	ctx := requestctx.WithActor(context.Background(), "admin@email.com")
+	ctx = requestctx.WithRequestID(ctx, "request-id")
+	_, _, err = projectsService.UpdateProject(ctx, project)
+	assert.NoError(t, err)
+
+	storage.AssertExpectations(t)
+}
+
+func TestProjectsService_ArchiveProjectRecordsAuditLog(t *testing.T) {
+	project := &models.Project{ID: 1, Name: "my-project"}
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("SaveWith", project, mock.MatchedBy(func(options repository.ProjectWriteOptions) bool {
+		_, archived := options.AuditLog.Changes["archived_at"]
+		return options.AuditLog.Action == models.ProjectArchivedAction && archived &&
+			len(options.AuditLog.Changes) == 1 && !options.SyncAuthorization
+	})).Return(project, nil)
+
+	auditLogRepository := &mocks.ProjectAuditLogRepository{}
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, auditLogRepository, nil, nil, false, nil,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	_, err = projectsService.ArchiveProject(context.Background(), project)
+	assert.NoError(t, err)
+
+	storage.AssertExpectations(t)
+}
+
+func TestProjectsService_ArchiveProjectFailsIfAuditLogFails(t *testing.T) {
+	project := &models.Project{ID: 1, Name: "my-project"}
+
+	// the project is not saved either, as the audit log is recorded in the same transaction
+	storage := &mocks.ProjectRepository{}
+	storage.On("SaveWith", project, mock.Anything).Return(nil, errors.New("audit log cannot be recorded"))
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, &mocks.ProjectAuditLogRepository{}, nil, nil, false, nil,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	_, err = projectsService.ArchiveProject(context.Background(), project)
+	assert.EqualError(t, err, "audit log cannot be recorded")
+	storage.AssertExpectations(t)
+}
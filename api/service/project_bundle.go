@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// SecretEncryptor encrypts the data of the secrets written to project bundles and decrypts it when they are imported
+type SecretEncryptor interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// ExportOptions controls which projects and resources are exported to a project bundle
+type ExportOptions struct {
+	// ProjectNames restricts the export to the given projects. All projects are exported if it is empty.
+	ProjectNames []string
+	// IncludeSecrets exports the secrets of the projects, in plain text unless an Encryptor is set
+	IncludeSecrets bool
+	Encryptor      SecretEncryptor
+}
+
+// ImportOptions controls how a project bundle is imported
+type ImportOptions struct {
+	// Encryptor decrypts the encrypted secrets of the bundle. It must be set if the bundle contains encrypted secrets.
+	Encryptor SecretEncryptor
+}
+
+// ProjectBundleService exports projects together with their resources to project bundles and imports them back
+type ProjectBundleService interface {
+	// Export returns a bundle of the projects selected by the options, sorted by name
+	Export(ctx context.Context, options ExportOptions) (*models.ProjectBundle, error)
+	// Import creates or updates the projects of the bundle together with their secret storages and secrets. Resources
+	// that already match the bundle are left untouched, so importing the same bundle twice has no effect.
+	Import(ctx context.Context, bundle *models.ProjectBundle, options ImportOptions) error
+}
+
+func NewProjectBundleService(
+	projectRepository repository.ProjectRepository,
+	projectsService ProjectsService,
+	secretStorageService SecretStorageService,
+	secretService SecretService) ProjectBundleService {
+	return &projectBundleService{
+		projectRepository:    projectRepository,
+		projectsService:      projectsService,
+		secretStorageService: secretStorageService,
+		secretService:        secretService,
+	}
+}
+
+type projectBundleService struct {
+	projectRepository    repository.ProjectRepository
+	projectsService      ProjectsService
+	secretStorageService SecretStorageService
+	secretService        SecretService
+}
+
+func (s *projectBundleService) Export(_ context.Context, options ExportOptions) (*models.ProjectBundle, error) {
+	projects, err := s.listProjects(options.ProjectNames)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &models.ProjectBundle{
+		Version:    models.ProjectBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Projects:   make([]*models.BundledProject, 0, len(projects)),
+	}
+	for _, project := range projects {
+		bundledProject, err := s.exportProject(project, options)
+		if err != nil {
+			return nil, fmt.Errorf("error exporting project %s: %w", project.Name, err)
+		}
+		bundle.Projects = append(bundle.Projects, bundledProject)
+	}
+	return bundle, nil
+}
+
+func (s *projectBundleService) Import(ctx context.Context, bundle *models.ProjectBundle, options ImportOptions) error {
+	if bundle.Version != models.ProjectBundleVersion {
+		return apperrors.NewInvalidArgumentErrorf("unsupported project bundle version %s", bundle.Version)
+	}
+
+	// fail before anything is imported rather than leaving the environment partially imported
+	if options.Encryptor == nil {
+		for _, bundledProject := range bundle.Projects {
+			for _, secret := range bundledProject.Secrets {
+				if secret.Encrypted {
+					return apperrors.NewInvalidArgumentErrorf(
+						"secret %s of project %s is encrypted but no encryption key is provided",
+						secret.Name, bundledProject.Name)
+				}
+			}
+		}
+	}
+
+	for _, bundledProject := range bundle.Projects {
+		if err := s.importProject(ctx, bundledProject, options); err != nil {
+			return fmt.Errorf("error importing project %s: %w", bundledProject.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *projectBundleService) listProjects(names []string) ([]*models.Project, error) {
+	var projects []*models.Project
+	if len(names) == 0 {
+		allProjects, err := s.projectRepository.ListAll()
+		if err != nil {
+			return nil, fmt.Errorf("error listing projects: %w", err)
+		}
+		projects = allProjects
+	} else {
+		for _, name := range names {
+			project, err := s.projectsService.FindByName(name)
+			if err != nil {
+				return nil, err
+			}
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+	return projects, nil
+}
+
+func (s *projectBundleService) exportProject(project *models.Project, options ExportOptions) (*models.BundledProject,
+	error) {
+	bundledProject := &models.BundledProject{
+		Name:           project.Name,
+		Administrators: project.Administrators,
+		Readers:        project.Readers,
+		SecretReaders:  project.SecretReaders,
+		MemberExpiries: project.MemberExpiries,
+		Team:           project.Team,
+		Stream:         project.Stream,
+		Labels:         project.Labels,
+		ArchivedAt:     project.ArchivedAt,
+	}
+
+	storages, err := s.secretStorageService.List(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, storage := range storages {
+		// global secret storages are configured per environment and are referred to by name only
+		if storage.Scope != models.ProjectSecretStorageScope {
+			continue
+		}
+		bundledProject.SecretStorages = append(bundledProject.SecretStorages, &models.BundledSecretStorage{
+			Name:   storage.Name,
+			Type:   storage.Type,
+			Config: bundledSecretStorageConfig(storage.Config),
+		})
+	}
+	sort.Slice(bundledProject.SecretStorages, func(i, j int) bool {
+		return bundledProject.SecretStorages[i].Name < bundledProject.SecretStorages[j].Name
+	})
+
+	if !options.IncludeSecrets {
+		return bundledProject, nil
+	}
+
+	secrets, err := s.secretService.List(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		bundledSecret := &models.BundledSecret{
+			Name:          secret.Name,
+			SecretStorage: secret.SecretStorage.Name,
+			Data:          secret.Data,
+		}
+		if options.Encryptor != nil {
+			bundledSecret.Data, err = options.Encryptor.Encrypt(secret.Data)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encrypt secret %s: %w", secret.Name, err)
+			}
+			bundledSecret.Encrypted = true
+		}
+		bundledProject.Secrets = append(bundledProject.Secrets, bundledSecret)
+	}
+	sort.Slice(bundledProject.Secrets, func(i, j int) bool {
+		return bundledProject.Secrets[i].Name < bundledProject.Secrets[j].Name
+	})
+
+	return bundledProject, nil
+}
+
+func (s *projectBundleService) importProject(ctx context.Context, bundledProject *models.BundledProject,
+	options ImportOptions) error {
+	project, err := s.projectsService.FindByName(bundledProject.Name)
+	switch {
+	case errors.Is(err, &apperrors.NotFoundError{}):
+		project, err = s.projectsService.CreateProject(ctx, &models.Project{
+			Name:           bundledProject.Name,
+			Administrators: bundledProject.Administrators,
+			Readers:        bundledProject.Readers,
+			SecretReaders:  bundledProject.SecretReaders,
+			MemberExpiries: bundledProject.MemberExpiries,
+			Team:           bundledProject.Team,
+			Stream:         bundledProject.Stream,
+			Labels:         bundledProject.Labels,
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("created project %s", project.Name)
+	case err != nil:
+		return err
+	default:
+		updatedProject := *project
+		updatedProject.Administrators = bundledProject.Administrators
+		updatedProject.Readers = bundledProject.Readers
+		updatedProject.SecretReaders = bundledProject.SecretReaders
+		updatedProject.MemberExpiries = bundledProject.MemberExpiries
+		updatedProject.Team = bundledProject.Team
+		updatedProject.Stream = bundledProject.Stream
+		updatedProject.Labels = bundledProject.Labels
+		if len(diffProjects(project, &updatedProject)) > 0 {
+			project, _, err = s.projectsService.UpdateProject(ctx, &updatedProject)
+			if err != nil {
+				return err
+			}
+			log.Infof("updated project %s", project.Name)
+		}
+	}
+
+	if bundledProject.ArchivedAt != nil && !project.IsArchived() {
+		project, err = s.projectsService.ArchiveProject(ctx, project)
+	} else if bundledProject.ArchivedAt == nil && project.IsArchived() {
+		project, err = s.projectsService.UnarchiveProject(ctx, project)
+	}
+	if err != nil {
+		return err
+	}
+
+	storages, err := s.importSecretStorages(project, bundledProject.SecretStorages)
+	if err != nil {
+		return err
+	}
+	return s.importSecrets(project, storages, bundledProject.Secrets, options)
+}
+
+// importSecretStorages creates or updates the secret storages of the project and returns all the secret storages
+// available to the project, including the global ones, keyed by name
+func (s *projectBundleService) importSecretStorages(project *models.Project,
+	bundledStorages []*models.BundledSecretStorage) (map[string]*models.SecretStorage, error) {
+	storages, err := s.secretStorageService.List(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	storagesByName := make(map[string]*models.SecretStorage)
+	for _, storage := range storages {
+		storagesByName[storage.Name] = storage
+	}
+
+	for _, bundledStorage := range bundledStorages {
+		existingStorage, ok := storagesByName[bundledStorage.Name]
+		if !ok {
+			storage := &models.SecretStorage{
+				Name:      bundledStorage.Name,
+				Type:      bundledStorage.Type,
+				Scope:     models.ProjectSecretStorageScope,
+				ProjectID: &project.ID,
+				Project:   project,
+				Config:    bundledStorage.Config,
+			}
+			if err := storage.ValidateForCreation(); err != nil {
+				return nil, apperrors.NewInvalidArgumentErrorf(err.Error())
+			}
+			createdStorage, err := s.secretStorageService.Create(storage)
+			if err != nil {
+				return nil, err
+			}
+			log.Infof("created secret storage %s of project %s", createdStorage.Name, project.Name)
+			storagesByName[createdStorage.Name] = createdStorage
+			continue
+		}
+
+		if existingStorage.Scope != models.ProjectSecretStorageScope {
+			return nil, apperrors.NewInvalidArgumentErrorf("secret storage %s conflicts with a global secret storage",
+				bundledStorage.Name)
+		}
+		config := withSecretStorageCredentials(bundledStorage.Config, existingStorage.Config)
+		if existingStorage.Type == bundledStorage.Type && reflect.DeepEqual(existingStorage.Config, config) {
+			continue
+		}
+
+		updatedStorage := *existingStorage
+		updatedStorage.Type = bundledStorage.Type
+		updatedStorage.Config = config
+		storage, err := s.secretStorageService.Update(&updatedStorage)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("updated secret storage %s of project %s", storage.Name, project.Name)
+		storagesByName[storage.Name] = storage
+	}
+	return storagesByName, nil
+}
+
+// bundledSecretStorageConfig returns the configuration of the secret storage without its credentials, which must not
+// be written in plain text to the bundle
+func bundledSecretStorageConfig(config models.SecretStorageConfig) models.SecretStorageConfig {
+	if config.VaultConfig != nil {
+		vaultConfig := *config.VaultConfig
+		vaultConfig.Token = ""
+		config.VaultConfig = &vaultConfig
+	}
+	return config
+}
+
+// withSecretStorageCredentials returns the bundled configuration of a secret storage with the credentials of the
+// existing secret storage, since the credentials are not part of the bundle
+func withSecretStorageCredentials(config models.SecretStorageConfig,
+	existingConfig models.SecretStorageConfig) models.SecretStorageConfig {
+	if config.VaultConfig != nil && config.VaultConfig.Token == "" && existingConfig.VaultConfig != nil {
+		vaultConfig := *config.VaultConfig
+		vaultConfig.Token = existingConfig.VaultConfig.Token
+		config.VaultConfig = &vaultConfig
+	}
+	return config
+}
+
+func (s *projectBundleService) importSecrets(project *models.Project, storages map[string]*models.SecretStorage,
+	bundledSecrets []*models.BundledSecret, options ImportOptions) error {
+	if len(bundledSecrets) == 0 {
+		return nil
+	}
+
+	secrets, err := s.secretService.List(project.ID)
+	if err != nil {
+		return err
+	}
+	secretsByName := make(map[string]*models.Secret)
+	for _, secret := range secrets {
+		secretsByName[secret.Name] = secret
+	}
+
+	for _, bundledSecret := range bundledSecrets {
+		storage, ok := storages[bundledSecret.SecretStorage]
+		if !ok {
+			return apperrors.NewNotFoundErrorf("secret storage %s of secret %s not found",
+				bundledSecret.SecretStorage, bundledSecret.Name)
+		}
+
+		data := bundledSecret.Data
+		if bundledSecret.Encrypted {
+			data, err = options.Encryptor.Decrypt(bundledSecret.Data)
+			if err != nil {
+				return fmt.Errorf("unable to decrypt secret %s: %w", bundledSecret.Name, err)
+			}
+		}
+
+		existingSecret, ok := secretsByName[bundledSecret.Name]
+		if !ok {
+			_, err = s.secretService.Create(&models.Secret{
+				ProjectID:       project.ID,
+				Name:            bundledSecret.Name,
+				Data:            data,
+				SecretStorageID: &storage.ID,
+			})
+			if err != nil {
+				return err
+			}
+			log.Infof("created secret %s of project %s", bundledSecret.Name, project.Name)
+			continue
+		}
+
+		if existingSecret.Data == data && *existingSecret.SecretStorageID == storage.ID {
+			continue
+		}
+		updatedSecret := *existingSecret
+		updatedSecret.Data = data
+		updatedSecret.SecretStorageID = &storage.ID
+		if _, err = s.secretService.Update(&updatedSecret); err != nil {
+			return err
+		}
+		log.Infof("updated secret %s of project %s", bundledSecret.Name, project.Name)
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+	servicemocks "github.com/caraml-dev/mlp/api/service/mocks"
+)
+
+// prefixEncryptor is a reversible SecretEncryptor that makes encrypted values easy to recognise in assertions
+type prefixEncryptor struct{}
+
+func (prefixEncryptor) Encrypt(plaintext string) (string, error) {
+	return "encrypted:" + plaintext, nil
+}
+
+func (prefixEncryptor) Decrypt(ciphertext string) (string, error) {
+	return strings.TrimPrefix(ciphertext, "encrypted:"), nil
+}
+
+var (
+	bundleGlobalStorage = &models.SecretStorage{
+		ID:    1,
+		Name:  "default-secret-storage",
+		Type:  models.VaultSecretStorageType,
+		Scope: models.GlobalSecretStorageScope,
+	}
+	bundleProjectStorage = &models.SecretStorage{
+		ID:        2,
+		Name:      "project-secret-storage",
+		Type:      models.VaultSecretStorageType,
+		Scope:     models.ProjectSecretStorageScope,
+		ProjectID: idPtr(1),
+		Config: models.SecretStorageConfig{
+			VaultConfig: &models.VaultConfig{
+				URL:        "http://vault:8200",
+				MountPath:  "secret",
+				AuthMethod: models.TokenAuthMethod,
+				Token:      "vault-token",
+			},
+		},
+	}
+	// bundledProjectStorageConfig is the configuration of the project secret storage without its Vault token
+	bundledProjectStorageConfig = models.SecretStorageConfig{
+		VaultConfig: &models.VaultConfig{
+			URL:        "http://vault:8200",
+			MountPath:  "secret",
+			AuthMethod: models.TokenAuthMethod,
+		},
+	}
+)
+
+func idPtr(id models.ID) *models.ID {
+	return &id
+}
+
+func TestProjectBundleService_Export(t *testing.T) {
+	archivedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListAll").Return([]*models.Project{
+		{ID: 2, Name: "project-b", Team: "team-b", Stream: "stream-b", ArchivedAt: &archivedAt},
+		{
+			ID:             1,
+			Name:           "project-a",
+			Administrators: []string{"admin@example.com"},
+			Readers:        []string{"reader@example.com"},
+			SecretReaders:  []string{"secret-reader@example.com"},
+			Team:           "team-a",
+			Stream:         "stream-a",
+			Labels:         models.Labels{{Key: "env", Value: "production"}},
+		},
+	}, nil)
+
+	secretStorageService := &servicemocks.SecretStorageService{}
+	secretStorageService.On("List", models.ID(1)).
+		Return([]*models.SecretStorage{bundleGlobalStorage, bundleProjectStorage}, nil)
+	secretStorageService.On("List", models.ID(2)).Return([]*models.SecretStorage{bundleGlobalStorage}, nil)
+
+	secretService := &servicemocks.SecretService{}
+	secretService.On("List", models.ID(1)).Return([]*models.Secret{
+		{Name: "secret-2", Data: "data-2", SecretStorage: bundleProjectStorage},
+		{Name: "secret-1", Data: "data-1", SecretStorage: bundleGlobalStorage},
+	}, nil)
+	secretService.On("List", models.ID(2)).Return([]*models.Secret{}, nil)
+
+	bundleService := NewProjectBundleService(projectRepository, nil, secretStorageService, secretService)
+
+	tests := map[string]struct {
+		options         ExportOptions
+		expectedSecrets []*models.BundledSecret
+	}{
+		"without secrets": {
+			options: ExportOptions{},
+		},
+		"with plain text secrets": {
+			options: ExportOptions{IncludeSecrets: true},
+			expectedSecrets: []*models.BundledSecret{
+				{Name: "secret-1", SecretStorage: "default-secret-storage", Data: "data-1"},
+				{Name: "secret-2", SecretStorage: "project-secret-storage", Data: "data-2"},
+			},
+		},
+		"with encrypted secrets": {
+			options: ExportOptions{IncludeSecrets: true, Encryptor: prefixEncryptor{}},
+			expectedSecrets: []*models.BundledSecret{
+				{Name: "secret-1", SecretStorage: "default-secret-storage", Data: "encrypted:data-1", Encrypted: true},
+				{Name: "secret-2", SecretStorage: "project-secret-storage", Data: "encrypted:data-2", Encrypted: true},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle, err := bundleService.Export(context.Background(), tt.options)
+			require.NoError(t, err)
+
+			assert.Equal(t, models.ProjectBundleVersion, bundle.Version)
+			assert.Equal(t, []*models.BundledProject{
+				{
+					Name:           "project-a",
+					Administrators: []string{"admin@example.com"},
+					Readers:        []string{"reader@example.com"},
+					SecretReaders:  []string{"secret-reader@example.com"},
+					Team:           "team-a",
+					Stream:         "stream-a",
+					Labels:         models.Labels{{Key: "env", Value: "production"}},
+					SecretStorages: []*models.BundledSecretStorage{
+						{
+							Name:   "project-secret-storage",
+							Type:   models.VaultSecretStorageType,
+							Config: bundledProjectStorageConfig,
+						},
+					},
+					Secrets: tt.expectedSecrets,
+				},
+				{Name: "project-b", Team: "team-b", Stream: "stream-b", ArchivedAt: &archivedAt},
+			}, bundle.Projects)
+		})
+	}
+}
+
+func TestProjectBundleService_Import(t *testing.T) {
+	existingProject := &models.Project{
+		ID:                1,
+		Name:              "project-a",
+		MLFlowTrackingURL: MLFlowTrackingURL,
+		Administrators:    []string{"admin@example.com"},
+		SecretReaders:     []string{"secret-reader@example.com"},
+		Team:              "team-a",
+		Stream:            "stream-a",
+	}
+	bundledProject := &models.BundledProject{
+		Name:           "project-a",
+		Administrators: []string{"admin@example.com"},
+		SecretReaders:  []string{"secret-reader@example.com"},
+		Team:           "team-a",
+		Stream:         "stream-a",
+		SecretStorages: []*models.BundledSecretStorage{
+			{Name: bundleProjectStorage.Name, Type: bundleProjectStorage.Type, Config: bundledProjectStorageConfig},
+		},
+		Secrets: []*models.BundledSecret{
+			{Name: "secret-1", SecretStorage: "default-secret-storage", Data: "encrypted:data-1", Encrypted: true},
+		},
+	}
+	bundle := &models.ProjectBundle{
+		Version:  models.ProjectBundleVersion,
+		Projects: []*models.BundledProject{bundledProject},
+	}
+	existingSecret := &models.Secret{
+		ID:              1,
+		ProjectID:       1,
+		Name:            "secret-1",
+		Data:            "data-1",
+		SecretStorageID: idPtr(bundleGlobalStorage.ID),
+		SecretStorage:   bundleGlobalStorage,
+	}
+
+	tests := map[string]struct {
+		bundle      *models.ProjectBundle
+		options     ImportOptions
+		setupMocks  func(*mocks.ProjectRepository, *servicemocks.SecretStorageService, *servicemocks.SecretService)
+		expectedErr string
+	}{
+		"create missing project and its resources": {
+			bundle:  bundle,
+			options: ImportOptions{Encryptor: prefixEncryptor{}},
+			setupMocks: func(projectRepository *mocks.ProjectRepository,
+				secretStorageService *servicemocks.SecretStorageService, secretService *servicemocks.SecretService) {
+				projectRepository.On("GetByName", "project-a").
+					Return(nil, apperrors.NewNotFoundErrorf("project project-a not found"))
+				projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+					return project.Name == "project-a" && project.Team == "team-a" &&
+						assert.ObjectsAreEqual([]string{"secret-reader@example.com"}, []string(project.SecretReaders))
+				}), repository.ProjectWriteOptions{}).Return(existingProject, nil)
+				secretStorageService.On("List", models.ID(1)).Return([]*models.SecretStorage{bundleGlobalStorage}, nil)
+				secretStorageService.On("Create", mock.MatchedBy(func(storage *models.SecretStorage) bool {
+					return storage.Name == bundleProjectStorage.Name && *storage.ProjectID == 1 &&
+						storage.Scope == models.ProjectSecretStorageScope
+				})).Return(bundleProjectStorage, nil)
+				secretService.On("List", models.ID(1)).Return([]*models.Secret{}, nil)
+				secretService.On("Create", &models.Secret{
+					ProjectID:       1,
+					Name:            "secret-1",
+					Data:            "data-1",
+					SecretStorageID: idPtr(bundleGlobalStorage.ID),
+				}).Return(existingSecret, nil)
+			},
+		},
+		"leave matching project and resources untouched": {
+			bundle:  bundle,
+			options: ImportOptions{Encryptor: prefixEncryptor{}},
+			setupMocks: func(projectRepository *mocks.ProjectRepository,
+				secretStorageService *servicemocks.SecretStorageService, secretService *servicemocks.SecretService) {
+				projectRepository.On("GetByName", "project-a").Return(existingProject, nil)
+				secretStorageService.On("List", models.ID(1)).
+					Return([]*models.SecretStorage{bundleGlobalStorage, bundleProjectStorage}, nil)
+				secretService.On("List", models.ID(1)).Return([]*models.Secret{existingSecret}, nil)
+			},
+		},
+		"update changed secret storage and keep its credentials": {
+			bundle: &models.ProjectBundle{
+				Version: models.ProjectBundleVersion,
+				Projects: []*models.BundledProject{
+					{
+						Name:           "project-a",
+						Administrators: []string{"admin@example.com"},
+						SecretReaders:  []string{"secret-reader@example.com"},
+						Team:           "team-a",
+						Stream:         "stream-a",
+						SecretStorages: []*models.BundledSecretStorage{
+							{
+								Name: bundleProjectStorage.Name,
+								Type: bundleProjectStorage.Type,
+								Config: models.SecretStorageConfig{
+									VaultConfig: &models.VaultConfig{
+										URL:        "http://vault:8200",
+										MountPath:  "kv",
+										AuthMethod: models.TokenAuthMethod,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			setupMocks: func(projectRepository *mocks.ProjectRepository,
+				secretStorageService *servicemocks.SecretStorageService, secretService *servicemocks.SecretService) {
+				projectRepository.On("GetByName", "project-a").Return(existingProject, nil)
+				secretStorageService.On("List", models.ID(1)).
+					Return([]*models.SecretStorage{bundleGlobalStorage, bundleProjectStorage}, nil)
+				secretStorageService.On("Update", mock.MatchedBy(func(storage *models.SecretStorage) bool {
+					return storage.ID == bundleProjectStorage.ID && storage.Config.VaultConfig.MountPath == "kv" &&
+						storage.Config.VaultConfig.Token == "vault-token"
+				})).Return(bundleProjectStorage, nil)
+			},
+		},
+		"update changed project and secret": {
+			bundle: &models.ProjectBundle{
+				Version: models.ProjectBundleVersion,
+				Projects: []*models.BundledProject{
+					{
+						Name:           "project-a",
+						Administrators: []string{"admin@example.com"},
+						Readers:        []string{"reader@example.com"},
+						SecretReaders:  []string{"ml-engineer@example.com"},
+						Team:           "team-a",
+						Stream:         "stream-a",
+						Secrets: []*models.BundledSecret{
+							{Name: "secret-1", SecretStorage: "default-secret-storage", Data: "new-data"},
+						},
+					},
+				},
+			},
+			setupMocks: func(projectRepository *mocks.ProjectRepository,
+				secretStorageService *servicemocks.SecretStorageService, secretService *servicemocks.SecretService) {
+				projectRepository.On("GetByName", "project-a").Return(existingProject, nil)
+				projectRepository.On("Get", models.ID(1)).Return(existingProject, nil)
+				projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+					return len(project.Readers) == 1 && project.Readers[0] == "reader@example.com" &&
+						assert.ObjectsAreEqual([]string{"ml-engineer@example.com"}, []string(project.SecretReaders))
+				}), repository.ProjectWriteOptions{}).Return(existingProject, nil)
+				secretStorageService.On("List", models.ID(1)).Return([]*models.SecretStorage{bundleGlobalStorage}, nil)
+				secretService.On("List", models.ID(1)).Return([]*models.Secret{existingSecret}, nil)
+				secretService.On("Update", mock.MatchedBy(func(secret *models.Secret) bool {
+					return secret.ID == 1 && secret.Data == "new-data"
+				})).Return(existingSecret, nil)
+			},
+		},
+		"unknown secret storage": {
+			bundle: &models.ProjectBundle{
+				Version: models.ProjectBundleVersion,
+				Projects: []*models.BundledProject{
+					{
+						Name:           "project-a",
+						Administrators: []string{"admin@example.com"},
+						SecretReaders:  []string{"secret-reader@example.com"},
+						Team:           "team-a",
+						Stream:         "stream-a",
+						Secrets: []*models.BundledSecret{
+							{Name: "secret-1", SecretStorage: "unknown-secret-storage", Data: "data-1"},
+						},
+					},
+				},
+			},
+			setupMocks: func(projectRepository *mocks.ProjectRepository,
+				secretStorageService *servicemocks.SecretStorageService, secretService *servicemocks.SecretService) {
+				projectRepository.On("GetByName", "project-a").Return(existingProject, nil)
+				secretStorageService.On("List", models.ID(1)).Return([]*models.SecretStorage{bundleGlobalStorage}, nil)
+				secretService.On("List", models.ID(1)).Return([]*models.Secret{existingSecret}, nil)
+			},
+			expectedErr: "error importing project project-a: secret storage unknown-secret-storage of secret " +
+				"secret-1 not found",
+		},
+		"encrypted secrets without encryption key": {
+			bundle:      bundle,
+			expectedErr: "secret secret-1 of project project-a is encrypted but no encryption key is provided",
+		},
+		"unsupported version": {
+			bundle:      &models.ProjectBundle{Version: "v0"},
+			expectedErr: "unsupported project bundle version v0",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			projectRepository := &mocks.ProjectRepository{}
+			secretStorageService := &servicemocks.SecretStorageService{}
+			secretService := &servicemocks.SecretService{}
+			if tt.setupMocks != nil {
+				tt.setupMocks(projectRepository, secretStorageService, secretService)
+			}
+
+			projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+				nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+			require.NoError(t, err)
+
+			bundleService := NewProjectBundleService(projectRepository, projectsService, secretStorageService,
+				secretService)
+			err = bundleService.Import(context.Background(), tt.bundle, tt.options)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			projectRepository.AssertExpectations(t)
+			secretStorageService.AssertExpectations(t)
+			secretService.AssertExpectations(t)
+		})
+	}
+}
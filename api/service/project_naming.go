@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+// systemNamespaces are the names of the namespaces of the platform components, which cannot be used by projects
+// regardless of the configured reserved names
+var systemNamespaces = []string{
+	"infrastructure",
+	"kube-system",
+	"knative-serving",
+	"kfserving-system",
+	"knative-monitoring",
+}
+
+// projectNamingPolicy validates the names of projects against the configured naming policy
+type projectNamingPolicy struct {
+	reservedNames     []string
+	reservedPrefixes  []string
+	allowPatterns     []*regexp.Regexp
+	denyPatterns      []*regexp.Regexp
+	streamConventions map[string]streamNamingConvention
+}
+
+type streamNamingConvention struct {
+	requireTeamPrefix bool
+	prefix            string
+	pattern           *regexp.Regexp
+}
+
+func newProjectNamingPolicy(cfg config.ProjectNamingConfig) (*projectNamingPolicy, error) {
+	allowPatterns, err := compilePatterns(cfg.AllowPatterns)
+	if err != nil {
+		return nil, err
+	}
+	denyPatterns, err := compilePatterns(cfg.DenyPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	streamConventions := make(map[string]streamNamingConvention)
+	for stream, convention := range cfg.StreamConventions {
+		var pattern *regexp.Regexp
+		if convention.Pattern != "" {
+			pattern, err = regexp.Compile(convention.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid naming pattern of stream %s: %w", stream, err)
+			}
+		}
+		streamConventions[stream] = streamNamingConvention{
+			requireTeamPrefix: convention.RequireTeamPrefix,
+			prefix:            convention.Prefix,
+			pattern:           pattern,
+		}
+	}
+
+	return &projectNamingPolicy{
+		reservedNames:     append(slices.Clone(systemNamespaces), cfg.ReservedNames...),
+		reservedPrefixes:  cfg.ReservedPrefixes,
+		allowPatterns:     allowPatterns,
+		denyPatterns:      denyPatterns,
+		streamConventions: streamConventions,
+	}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project naming pattern: %w", err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// Validate returns a ValidationError listing every rule of the naming policy that the project name violates
+func (policy *projectNamingPolicy) Validate(project *models.Project) error {
+	violations := make([]apperrors.FieldViolation, 0)
+	violate := func(rule string, format string, a ...any) {
+		violations = append(violations, apperrors.FieldViolation{
+			Field:   "name",
+			Rule:    rule,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	name := project.Name
+	if slices.Contains(policy.reservedNames, name) {
+		violate("reserved_name", "%s is a reserved project name", name)
+	}
+	for _, prefix := range policy.reservedPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			violate("reserved_prefix", "project name must not start with the reserved prefix %s", prefix)
+		}
+	}
+	if len(policy.allowPatterns) > 0 && !slices.ContainsFunc(policy.allowPatterns, func(re *regexp.Regexp) bool {
+		return re.MatchString(name)
+	}) {
+		violate("allow_pattern", "project name must match one of the allowed patterns %s",
+			joinPatterns(policy.allowPatterns))
+	}
+	for _, re := range policy.denyPatterns {
+		if re.MatchString(name) {
+			violate("deny_pattern", "project name must not match the pattern %s", re)
+		}
+	}
+
+	if convention, ok := policy.streamConventions[project.Stream]; ok {
+		if convention.requireTeamPrefix && !strings.HasPrefix(name, project.Team+"-") {
+			violate("team_prefix", "names of projects in stream %s must start with the team name followed by a "+
+				"hyphen: %s-", project.Stream, project.Team)
+		}
+		if convention.prefix != "" && !strings.HasPrefix(name, convention.prefix) {
+			violate("stream_prefix", "names of projects in stream %s must start with %s", project.Stream,
+				convention.prefix)
+		}
+		if convention.pattern != nil && !convention.pattern.MatchString(name) {
+			violate("stream_pattern", "names of projects in stream %s must match the pattern %s", project.Stream,
+				convention.pattern)
+		}
+	}
+
+	if len(violations) > 0 {
+		return apperrors.NewValidationError(
+			fmt.Sprintf("project name %s violates the naming policy", name), violations...)
+	}
+	return nil
+}
+
+func joinPatterns(patterns []*regexp.Regexp) string {
+	joined := make([]string, 0, len(patterns))
+	for _, re := range patterns {
+		joined = append(joined, re.String())
+	}
+	return strings.Join(joined, ", ")
+}
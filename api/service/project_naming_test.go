@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+)
+
+func Test_projectNamingPolicy_Validate(t *testing.T) {
+	policy, err := newProjectNamingPolicy(config.ProjectNamingConfig{
+		ReservedNames:    []string{"infrastructure", "monitoring"},
+		ReservedPrefixes: []string{"kube-", "knative-"},
+		AllowPatterns:    []string{"^[a-z][a-z0-9-]*$"},
+		DenyPatterns:     []string{"--", "-test$"},
+		StreamConventions: map[string]config.StreamNamingConvention{
+			"dsp":    {RequireTeamPrefix: true},
+			"growth": {Prefix: "gr-", Pattern: "^gr-[a-z]+$"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		project            *models.Project
+		expectedViolations []apperrors.FieldViolation
+	}{
+		"valid name": {
+			project: &models.Project{Name: "my-project", Team: "fraud", Stream: "risk"},
+		},
+		"reserved name": {
+			project: &models.Project{Name: "monitoring", Team: "fraud", Stream: "risk"},
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "name", Rule: "reserved_name", Message: "monitoring is a reserved project name"},
+			},
+		},
+		"system namespace": {
+			project: &models.Project{Name: "kfserving-system", Team: "fraud", Stream: "risk"},
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "name", Rule: "reserved_name", Message: "kfserving-system is a reserved project name"},
+			},
+		},
+		"reserved prefix and deny pattern": {
+			project: &models.Project{Name: "kube--test", Team: "fraud", Stream: "risk"},
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "name", Rule: "reserved_prefix", Message: "project name must not start with the reserved prefix kube-"},
+				{Field: "name", Rule: "deny_pattern", Message: "project name must not match the pattern --"},
+				{Field: "name", Rule: "deny_pattern", Message: "project name must not match the pattern -test$"},
+			},
+		},
+		"not allowed": {
+			project: &models.Project{Name: "1project", Team: "fraud", Stream: "risk"},
+			expectedViolations: []apperrors.FieldViolation{
+				{
+					Field:   "name",
+					Rule:    "allow_pattern",
+					Message: "project name must match one of the allowed patterns ^[a-z][a-z0-9-]*$",
+				},
+			},
+		},
+		"team prefix": {
+			project: &models.Project{Name: "fraud-detection", Team: "fraud", Stream: "dsp"},
+		},
+		"missing team prefix": {
+			project: &models.Project{Name: "detection", Team: "fraud", Stream: "dsp"},
+			expectedViolations: []apperrors.FieldViolation{
+				{
+					Field:   "name",
+					Rule:    "team_prefix",
+					Message: "names of projects in stream dsp must start with the team name followed by a hyphen: fraud-",
+				},
+			},
+		},
+		"stream prefix and pattern": {
+			project: &models.Project{Name: "growth-1", Team: "fraud", Stream: "growth"},
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "name", Rule: "stream_prefix", Message: "names of projects in stream growth must start with gr-"},
+				{
+					Field:   "name",
+					Rule:    "stream_pattern",
+					Message: "names of projects in stream growth must match the pattern ^gr-[a-z]+$",
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := policy.Validate(tt.project)
+			if tt.expectedViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *apperrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.expectedViolations, validationErr.Violations)
+		})
+	}
+}
+
+func Test_newProjectNamingPolicy(t *testing.T) {
+	_, err := newProjectNamingPolicy(config.ProjectNamingConfig{DenyPatterns: []string{"(unclosed"}})
+	assert.EqualError(t, err, "invalid project naming pattern: error parsing regexp: missing closing ): `(unclosed`")
+
+	_, err = newProjectNamingPolicy(config.ProjectNamingConfig{
+		StreamConventions: map[string]config.StreamNamingConvention{"dsp": {Pattern: "["}},
+	})
+	assert.EqualError(t, err, "invalid naming pattern of stream dsp: error parsing regexp: missing closing ]: `[`")
+}
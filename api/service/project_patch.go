@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/go-playground/validator"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/validation"
+)
+
+// patchValidator validates the patched projects with the same rules as the bodies of the project routes, and refers to
+// the fields by their JSON names as they appear in the patch
+var patchValidator = newPatchValidator()
+
+// patchableProjectFields are the JSON names of the project fields that can be patched, which are the ones that can be
+// updated with UpdateProject
+var patchableProjectFields = map[string]bool{
+	"administrators":  true,
+	"readers":         true,
+	"secret_readers":  true,
+	"member_expiries": true,
+	"team":            true,
+	"stream":          true,
+	"labels":          true,
+}
+
+// PatchType is the format of a patch document, identified by its media type
+type PatchType string
+
+const (
+	// MergePatchType is a JSON merge patch as described in RFC 7396
+	MergePatchType PatchType = "application/merge-patch+json"
+	// JSONPatchType is a JSON patch as described in RFC 6902
+	JSONPatchType PatchType = "application/json-patch+json"
+)
+
+// applyProjectPatch returns a copy of the project with the patch applied. Only the fields that can be updated with
+// UpdateProject can be patched, and patches modifying any other field are rejected. The patched project is validated
+// as the body of UpdateProject would be, and the fields failing validation are reported in a ValidationError.
+func applyProjectPatch(project *models.Project, patchType PatchType, patch []byte) (*models.Project, error) {
+	original, err := json.Marshal(project)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch patchType {
+	case MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, patch)
+		if err == nil {
+			if err := validateMergePatchFields(patch); err != nil {
+				return nil, err
+			}
+		}
+	case JSONPatchType:
+		var operations jsonpatch.Patch
+		operations, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			if err := validateJSONPatchPaths(operations); err != nil {
+				return nil, err
+			}
+			patched, err = operations.Apply(original)
+		}
+	default:
+		return nil, apperrors.NewInvalidArgumentErrorf("unsupported patch type %s", patchType)
+	}
+	if err != nil {
+		return nil, apperrors.NewInvalidArgumentErrorf("unable to apply patch to project %s: %s", project.Name, err)
+	}
+
+	var patchedProject models.Project
+	if err := json.Unmarshal(patched, &patchedProject); err != nil {
+		return nil, apperrors.NewInvalidArgumentErrorf("patched project %s is invalid: %s", project.Name, err)
+	}
+
+	result := *project
+	result.Administrators = patchedProject.Administrators
+	result.Readers = patchedProject.Readers
+	result.SecretReaders = patchedProject.SecretReaders
+	result.MemberExpiries = patchedProject.MemberExpiries
+	result.Team = patchedProject.Team
+	result.Stream = patchedProject.Stream
+	result.Labels = patchedProject.Labels
+	if err := patchValidator.Struct(&result); err != nil {
+		violations := make([]apperrors.FieldViolation, 0)
+		for _, fieldError := range err.(validator.ValidationErrors) {
+			violations = append(violations, patchViolation(fieldError))
+		}
+		return nil, apperrors.NewValidationError(fmt.Sprintf("patched project %s is invalid", project.Name),
+			violations...)
+	}
+	return &result, nil
+}
+
+func newPatchValidator() *validator.Validate {
+	instance := validation.NewValidator()
+	instance.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	})
+	return instance
+}
+
+// validateMergePatchFields rejects merge patches setting any other field than the patchable ones
+func validateMergePatchFields(patch []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		// a merge patch that is not an object replaces the whole project
+		return apperrors.NewInvalidArgumentErrorf("merge patch must be a JSON object")
+	}
+	for field := range fields {
+		if !patchableProjectFields[field] {
+			return errNotPatchable(field)
+		}
+	}
+	return nil
+}
+
+// validateJSONPatchPaths rejects JSON patches with operations modifying any other field than the patchable ones. Test
+// operations do not modify the project and can refer to any field.
+func validateJSONPatchPaths(operations jsonpatch.Patch) error {
+	for _, operation := range operations {
+		if operation.Kind() == "test" {
+			continue
+		}
+		path, err := operation.Path()
+		if err != nil {
+			return apperrors.NewInvalidArgumentErrorf("invalid JSON patch: %s", err)
+		}
+		paths := []string{path}
+		if operation.Kind() == "move" {
+			from, err := operation.From()
+			if err != nil {
+				return apperrors.NewInvalidArgumentErrorf("invalid JSON patch: %s", err)
+			}
+			paths = append(paths, from)
+		}
+		for _, path := range paths {
+			// the field is the first token of the JSON pointer, in which ~1 and ~0 escape / and ~
+			field := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)[0]
+			field = strings.NewReplacer("~1", "/", "~0", "~").Replace(field)
+			if !patchableProjectFields[field] {
+				return errNotPatchable(field)
+			}
+		}
+	}
+	return nil
+}
+
+func errNotPatchable(field string) error {
+	return apperrors.NewInvalidArgumentErrorf("field %q cannot be patched, only administrators, readers, "+
+		"secret_readers, member_expiries, team, stream and labels can be patched", field)
+}
+
+// patchViolation describes a field of the patched project failing a validation rule. The field is the path of the
+// field in the project, e.g. member_expiries[0].role.
+func patchViolation(fieldError validator.FieldError) apperrors.FieldViolation {
+	field := fieldError.Namespace()
+	if idx := strings.Index(field, "."); idx >= 0 {
+		field = field[idx+1:]
+	}
+	message := fieldError.Translate(validation.EN)
+	// rules without a translation would otherwise be described by the raw error of the validator
+	if message == fieldError.(error).Error() {
+		message = fmt.Sprintf("%s does not satisfy the %s rule", field, fieldError.Tag())
+	}
+	return apperrors.FieldViolation{Field: field, Rule: fieldError.Tag(), Message: message}
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+)
+
+func Test_applyProjectPatch(t *testing.T) {
+	project := &models.Project{
+		ID:                1,
+		Name:              "my-project",
+		MLFlowTrackingURL: MLFlowTrackingURL,
+		Administrators:    []string{"admin@email.com"},
+		Readers:           []string{"reader@email.com"},
+		Team:              "dsp",
+		Stream:            "dsp",
+		Labels:            models.Labels{{Key: "env", Value: "dev"}, {Key: "app", Value: "merlin"}},
+		Version:           2,
+	}
+
+	tests := map[string]struct {
+		patchType          PatchType
+		patch              string
+		expected           *models.Project
+		expectedErr        string
+		expectedViolations []apperrors.FieldViolation
+	}{
+		"merge patch": {
+			patchType: MergePatchType,
+			patch:     `{"team": "growth", "readers": ["reader@email.com", "other@email.com"]}`,
+			expected: &models.Project{
+				ID:                1,
+				Name:              "my-project",
+				MLFlowTrackingURL: MLFlowTrackingURL,
+				Administrators:    []string{"admin@email.com"},
+				Readers:           []string{"reader@email.com", "other@email.com"},
+				Team:              "growth",
+				Stream:            "dsp",
+				Labels:            models.Labels{{Key: "env", Value: "dev"}, {Key: "app", Value: "merlin"}},
+				Version:           2,
+			},
+		},
+		"merge patch removing a field": {
+			patchType: MergePatchType,
+			patch:     `{"labels": null}`,
+			expected: &models.Project{
+				ID:                1,
+				Name:              "my-project",
+				MLFlowTrackingURL: MLFlowTrackingURL,
+				Administrators:    []string{"admin@email.com"},
+				Readers:           []string{"reader@email.com"},
+				Team:              "dsp",
+				Stream:            "dsp",
+				Version:           2,
+			},
+		},
+		"json patch": {
+			patchType: JSONPatchType,
+			patch: `[{"op": "add", "path": "/administrators/-", "value": "other@email.com"},
+				{"op": "replace", "path": "/labels/0/value", "value": "prod"}]`,
+			expected: &models.Project{
+				ID:                1,
+				Name:              "my-project",
+				MLFlowTrackingURL: MLFlowTrackingURL,
+				Administrators:    []string{"admin@email.com", "other@email.com"},
+				Readers:           []string{"reader@email.com"},
+				Team:              "dsp",
+				Stream:            "dsp",
+				Labels:            models.Labels{{Key: "env", Value: "prod"}, {Key: "app", Value: "merlin"}},
+				Version:           2,
+			},
+		},
+		"json patch with failing test operation": {
+			patchType:   JSONPatchType,
+			patch:       `[{"op": "test", "path": "/team", "value": "growth"}]`,
+			expectedErr: "unable to apply patch to project my-project: testing value /team failed: test failed",
+		},
+		"malformed patch": {
+			patchType:   MergePatchType,
+			patch:       `{"team":`,
+			expectedErr: "unable to apply patch to project my-project: Invalid JSON Patch",
+		},
+		"renaming the project": {
+			patchType: MergePatchType,
+			patch:     `{"name": "other-project"}`,
+			expectedErr: "field \"name\" cannot be patched, only administrators, readers, secret_readers, " +
+				"member_expiries, team, stream and labels can be patched",
+		},
+		"merge patch replacing the project": {
+			patchType:   MergePatchType,
+			patch:       `["my-project"]`,
+			expectedErr: "merge patch must be a JSON object",
+		},
+		"merge patch of an unknown field": {
+			patchType: MergePatchType,
+			patch:     `{"team": "growth", "version": 1}`,
+			expectedErr: "field \"version\" cannot be patched, only administrators, readers, secret_readers, " +
+				"member_expiries, team, stream and labels can be patched",
+		},
+		"json patch archiving the project": {
+			patchType: JSONPatchType,
+			patch:     `[{"op": "add", "path": "/archived_at", "value": "2024-01-01T00:00:00Z"}]`,
+			expectedErr: "field \"archived_at\" cannot be patched, only administrators, readers, secret_readers, " +
+				"member_expiries, team, stream and labels can be patched",
+		},
+		"json patch moving a field that cannot be patched": {
+			patchType: JSONPatchType,
+			patch:     `[{"op": "move", "from": "/mlflow_tracking_url", "path": "/team"}]`,
+			expectedErr: "field \"mlflow_tracking_url\" cannot be patched, only administrators, readers, " +
+				"secret_readers, member_expiries, team, stream and labels can be patched",
+		},
+		"removing the team": {
+			patchType:   MergePatchType,
+			patch:       `{"team": ""}`,
+			expectedErr: "patched project my-project is invalid: Team is required",
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "team", Rule: "required", Message: "Team is required"},
+			},
+		},
+		"oversized stream": {
+			patchType:   JSONPatchType,
+			patch:       `[{"op": "replace", "path": "/stream", "value": "` + strings.Repeat("s", 65) + `"}]`,
+			expectedErr: "patched project my-project is invalid: Stream should be less than 64 characters",
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "stream", Rule: "max", Message: "Stream should be less than 64 characters"},
+			},
+		},
+		"invalid member expiry": {
+			patchType: MergePatchType,
+			patch: `{"member_expiries": [{"member": "reader@email.com", "role": "owner",
+				"expires_at": "2024-01-01T00:00:00Z"}]}`,
+			expectedErr: "patched project my-project is invalid: Role should be one of reader administrator",
+			expectedViolations: []apperrors.FieldViolation{
+				{Field: "member_expiries[0].role", Rule: "oneof", Message: "Role should be one of reader administrator"},
+			},
+		},
+		"unsupported patch type": {
+			patchType:   "application/json",
+			patch:       `{}`,
+			expectedErr: "unsupported patch type application/json",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			patched, err := applyProjectPatch(project, tt.patchType, []byte(tt.patch))
+			if tt.expectedViolations != nil {
+				var validationErr *apperrors.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.expectedViolations, validationErr.Violations)
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, &apperrors.InvalidArgumentError{})
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, patched)
+		})
+	}
+}
+
+func TestProjectsService_PatchProject(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"admin@email.com"},
+		Team:           "dsp",
+		Stream:         "dsp",
+		Labels:         models.Labels{{Key: "label1", Value: "value1"}},
+	}
+
+	tests := map[string]struct {
+		patch       string
+		authEnabled bool
+		expectedErr string
+	}{
+		"success": {
+			patch:       `{"readers": ["reader@email.com"]}`,
+			authEnabled: true,
+		},
+		"blacklisted label changed": {
+			patch: `{"labels": [{"key": "label1", "value": "value2"}]}`,
+			expectedErr: "one or more labels are blacklisted or have been removed or changed values and cannot " +
+				"be updated",
+		},
+		"invalid label": {
+			patch: `{"labels": [{"key": "label1", "value": "value1"}, {"key": "in valid", "value": "value"}]}`,
+			expectedErr: "label key \"in valid\" must have a name of at most 63 characters, consisting of " +
+				"alphanumeric characters, '-', '_' or '.', and starting and ending with an alphanumeric character",
+		},
+		"empty team": {
+			patch:       `{"team": ""}`,
+			expectedErr: "patched project my-project is invalid: Team is required",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			storage := &mocks.ProjectRepository{}
+			// the patched project is validated before the project is read again
+			storage.On("Get", project.ID).Return(project, nil).Maybe()
+
+			authEnforcer := &enforcerMock.Enforcer{}
+			if tt.expectedErr == "" {
+				storage.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+					return len(p.Readers) == 1 && p.Readers[0] == "reader@email.com"
+				}), repository.ProjectWriteOptions{}).Return(
+					func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project { return p }, nil)
+				authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			projectsService, err := NewProjectsService(
+				MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, tt.authEnabled, nil,
+				config.UpdateProjectConfig{LabelsBlacklist: []string{"label1"}},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			assert.NoError(t, err)
+
+			res, _, err := projectsService.PatchProject(context.Background(), project, MergePatchType, []byte(tt.patch))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"reader@email.com"}, res.Readers)
+				assert.Nil(t, project.Readers)
+			}
+
+			storage.AssertExpectations(t)
+			authEnforcer.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/caraml-dev/mlp/api/log"
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+type ReconcileActionType string
+
+const (
+	ReconcileCreateAction    ReconcileActionType = "create"
+	ReconcileUpdateAction    ReconcileActionType = "update"
+	ReconcileUnarchiveAction ReconcileActionType = "unarchive"
+	ReconcileArchiveAction   ReconcileActionType = "archive"
+)
+
+// ReconcileAction is a change to a project needed to bring it in line with its manifest
+type ReconcileAction struct {
+	Type    ReconcileActionType
+	Project string
+	// Changes are the fields changed by a create or an update
+	Changes models.ProjectChanges
+
+	// project is the desired state of the project after the action
+	project *models.Project
+}
+
+// ReconcileOptions controls how projects are reconciled with their manifests
+type ReconcileOptions struct {
+	// DryRun only plans the actions without applying them
+	DryRun bool
+	// Prune archives the projects that have no manifest. Projects are archived rather than deleted so that a
+	// manifest removed by mistake does not destroy the resources of the project.
+	Prune bool
+}
+
+// ProjectReconciler applies declarative project manifests to the projects stored in the database
+type ProjectReconciler interface {
+	// Reconcile creates and updates the projects so that they match the manifests, and returns the actions taken, or
+	// planned in case of a dry run, ordered by project name. On failure, the actions applied before the failure are
+	// returned together with the error.
+	Reconcile(ctx context.Context, manifests []*models.ProjectManifest, options ReconcileOptions) ([]*ReconcileAction,
+		error)
+}
+
+func NewProjectReconciler(projectRepository repository.ProjectRepository,
+	projectsService ProjectsService) ProjectReconciler {
+	return &projectReconciler{
+		projectRepository: projectRepository,
+		projectsService:   projectsService,
+	}
+}
+
+type projectReconciler struct {
+	projectRepository repository.ProjectRepository
+	projectsService   ProjectsService
+}
+
+func (r *projectReconciler) Reconcile(ctx context.Context, manifests []*models.ProjectManifest,
+	options ReconcileOptions) ([]*ReconcileAction, error) {
+	if err := validateManifests(manifests); err != nil {
+		return nil, err
+	}
+
+	projects, err := r.projectRepository.ListAll()
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+
+	actions := planReconcileActions(manifests, projects, options.Prune)
+	if options.DryRun {
+		return actions, nil
+	}
+
+	var previous *models.Project
+	for i, action := range actions {
+		// a project that is unarchived and then updated must be updated based on the version saved by the unarchive
+		if previous != nil && previous.Name == action.Project {
+			action.project.Version = previous.Version
+		}
+
+		previous, err = r.apply(ctx, action)
+		if err != nil {
+			return actions[:i], fmt.Errorf("error reconciling project %s: %w", action.Project, err)
+		}
+		log.Infof("reconciled project %s: %s", action.Project, action.Type)
+	}
+	return actions, nil
+}
+
+func (r *projectReconciler) apply(ctx context.Context, action *ReconcileAction) (*models.Project, error) {
+	switch action.Type {
+	case ReconcileCreateAction:
+		return r.projectsService.CreateProject(ctx, action.project)
+	case ReconcileUpdateAction:
+		project, _, err := r.projectsService.UpdateProject(ctx, action.project)
+		return project, err
+	case ReconcileUnarchiveAction:
+		return r.projectsService.UnarchiveProject(ctx, action.project)
+	case ReconcileArchiveAction:
+		return r.projectsService.ArchiveProject(ctx, action.project)
+	default:
+		return nil, fmt.Errorf("unknown reconcile action %s", action.Type)
+	}
+}
+
+func validateManifests(manifests []*models.ProjectManifest) error {
+	names := make(map[string]bool)
+	for _, manifest := range manifests {
+		if manifest.Name == "" {
+			return apperrors.NewInvalidArgumentErrorf("project manifest must have a name")
+		}
+		if names[manifest.Name] {
+			return apperrors.NewInvalidArgumentErrorf("project %s is defined by more than one manifest", manifest.Name)
+		}
+		names[manifest.Name] = true
+	}
+	return nil
+}
+
+// planReconcileActions compares the manifests with the existing projects and returns the actions needed to
+// reconcile them, ordered by project name
+func planReconcileActions(manifests []*models.ProjectManifest, projects []*models.Project,
+	prune bool) []*ReconcileAction {
+	projectsByName := make(map[string]*models.Project)
+	for _, project := range projects {
+		projectsByName[project.Name] = project
+	}
+
+	actions := make([]*ReconcileAction, 0)
+	for _, manifest := range manifests {
+		existingProject, ok := projectsByName[manifest.Name]
+		if !ok {
+			project := &models.Project{Name: manifest.Name}
+			applyManifest(project, manifest)
+			actions = append(actions, &ReconcileAction{
+				Type:    ReconcileCreateAction,
+				Project: manifest.Name,
+				Changes: diffProjects(nil, project),
+				project: project,
+			})
+			continue
+		}
+
+		// the archived project is unarchived first, so that the update applies to the unarchived project
+		desiredProject := *existingProject
+		if existingProject.IsArchived() {
+			actions = append(actions, &ReconcileAction{
+				Type:    ReconcileUnarchiveAction,
+				Project: manifest.Name,
+				project: existingProject,
+			})
+			desiredProject.ArchivedAt = nil
+		}
+
+		applyManifest(&desiredProject, manifest)
+		before := *existingProject
+		before.ArchivedAt = nil
+		if changes := diffProjects(&before, &desiredProject); len(changes) > 0 {
+			actions = append(actions, &ReconcileAction{
+				Type:    ReconcileUpdateAction,
+				Project: manifest.Name,
+				Changes: changes,
+				project: &desiredProject,
+			})
+		}
+	}
+
+	if prune {
+		manifestNames := make(map[string]bool)
+		for _, manifest := range manifests {
+			manifestNames[manifest.Name] = true
+		}
+		for _, project := range projects {
+			if !manifestNames[project.Name] && !project.IsArchived() {
+				actions = append(actions, &ReconcileAction{
+					Type:    ReconcileArchiveAction,
+					Project: project.Name,
+					project: project,
+				})
+			}
+		}
+	}
+
+	// sort.SliceStable keeps the unarchive action of a project before its update
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Project < actions[j].Project
+	})
+	return actions
+}
+
+func applyManifest(project *models.Project, manifest *models.ProjectManifest) {
+	project.Team = manifest.Team
+	project.Stream = manifest.Stream
+	project.Administrators = manifest.Administrators
+	project.Readers = manifest.Readers
+	project.Labels = manifest.Labels
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestProjectReconciler_Reconcile(t *testing.T) {
+	archivedAt := time.Now()
+	newProjects := func() []*models.Project {
+		return []*models.Project{
+			{
+				ID:             1,
+				Name:           "unchanged-project",
+				Administrators: []string{"admin@example.com"},
+				Team:           "team",
+				Stream:         "stream",
+				Version:        1,
+			},
+			{
+				ID:             2,
+				Name:           "changed-project",
+				Administrators: []string{"admin@example.com"},
+				Team:           "team",
+				Stream:         "stream",
+				Version:        3,
+			},
+			{
+				ID:             3,
+				Name:           "archived-project",
+				Administrators: []string{"admin@example.com"},
+				Team:           "team",
+				Stream:         "stream",
+				ArchivedAt:     &archivedAt,
+				Version:        5,
+			},
+			{
+				ID:             4,
+				Name:           "unmanaged-project",
+				Administrators: []string{"admin@example.com"},
+				Team:           "team",
+				Stream:         "stream",
+			},
+		}
+	}
+	manifests := []*models.ProjectManifest{
+		{Name: "unchanged-project", Administrators: []string{"admin@example.com"}, Team: "team", Stream: "stream"},
+		{
+			Name:           "changed-project",
+			Administrators: []string{"admin@example.com"},
+			Readers:        []string{"reader@example.com"},
+			Team:           "team",
+			Stream:         "stream",
+		},
+		{Name: "archived-project", Administrators: []string{"admin@example.com"}, Team: "team", Stream: "stream"},
+		{Name: "new-project", Administrators: []string{"admin@example.com"}, Team: "team", Stream: "stream"},
+	}
+
+	type expectedAction struct {
+		actionType ReconcileActionType
+		project    string
+		changes    models.ProjectChanges
+	}
+	tests := map[string]struct {
+		options         ReconcileOptions
+		setupMocks      func(projectRepository *mocks.ProjectRepository, projects []*models.Project)
+		expectedActions []expectedAction
+	}{
+		"dry run": {
+			options: ReconcileOptions{DryRun: true, Prune: true},
+			expectedActions: []expectedAction{
+				{actionType: ReconcileUnarchiveAction, project: "archived-project"},
+				{
+					actionType: ReconcileUpdateAction,
+					project:    "changed-project",
+					changes: models.ProjectChanges{
+						"readers": {Before: nil, After: []string{"reader@example.com"}},
+					},
+				},
+				{
+					actionType: ReconcileCreateAction,
+					project:    "new-project",
+					changes: models.ProjectChanges{
+						"name":           {Before: nil, After: "new-project"},
+						"administrators": {Before: nil, After: []string{"admin@example.com"}},
+						"team":           {Before: nil, After: "team"},
+						"stream":         {Before: nil, After: "stream"},
+					},
+				},
+				{actionType: ReconcileArchiveAction, project: "unmanaged-project"},
+			},
+		},
+		"apply without prune": {
+			setupMocks: func(projectRepository *mocks.ProjectRepository, projects []*models.Project) {
+				projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+					return project.Name == "archived-project" && project.ArchivedAt == nil
+				}), repository.ProjectWriteOptions{}).
+					Return(func(project *models.Project, _ repository.ProjectWriteOptions) *models.Project {
+						project.Version++
+						return project
+					}, nil).Once()
+				projectRepository.On("Get", models.ID(2)).Return(projects[1], nil)
+				projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+					return project.Name == "changed-project" && len(project.Readers) == 1 && project.Version == 3
+				}), repository.ProjectWriteOptions{}).Return(projects[1], nil)
+				projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+					return project.Name == "new-project" && project.MLFlowTrackingURL == MLFlowTrackingURL
+				}), repository.ProjectWriteOptions{}).Return(&models.Project{ID: 5, Name: "new-project"}, nil)
+			},
+			expectedActions: []expectedAction{
+				{actionType: ReconcileUnarchiveAction, project: "archived-project"},
+				{
+					actionType: ReconcileUpdateAction,
+					project:    "changed-project",
+					changes: models.ProjectChanges{
+						"readers": {Before: nil, After: []string{"reader@example.com"}},
+					},
+				},
+				{
+					actionType: ReconcileCreateAction,
+					project:    "new-project",
+					changes: models.ProjectChanges{
+						"name":           {Before: nil, After: "new-project"},
+						"administrators": {Before: nil, After: []string{"admin@example.com"}},
+						"team":           {Before: nil, After: "team"},
+						"stream":         {Before: nil, After: "stream"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			projects := newProjects()
+			projectRepository := &mocks.ProjectRepository{}
+			projectRepository.On("ListAll").Return(projects, nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(projectRepository, projects)
+			}
+
+			projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+				nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+			require.NoError(t, err)
+
+			reconciler := NewProjectReconciler(projectRepository, projectsService)
+			actions, err := reconciler.Reconcile(context.Background(), manifests, tt.options)
+			require.NoError(t, err)
+
+			actualActions := make([]expectedAction, 0, len(actions))
+			for _, action := range actions {
+				actualActions = append(actualActions, expectedAction{
+					actionType: action.Type,
+					project:    action.Project,
+					changes:    action.Changes,
+				})
+			}
+			assert.Equal(t, tt.expectedActions, actualActions)
+			projectRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectReconciler_ReconcileFailure(t *testing.T) {
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListAll").Return([]*models.Project{}, nil)
+	projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+		return project.Name == "project-a"
+	}), repository.ProjectWriteOptions{}).Return(&models.Project{ID: 1, Name: "project-a"}, nil)
+	projectRepository.On("SaveWith", mock.MatchedBy(func(project *models.Project) bool {
+		return project.Name == "project-b"
+	}), repository.ProjectWriteOptions{}).Return(nil, errors.New("db is down"))
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false,
+		nil, config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	reconciler := NewProjectReconciler(projectRepository, projectsService)
+	actions, err := reconciler.Reconcile(context.Background(), []*models.ProjectManifest{
+		{Name: "project-b", Team: "team", Stream: "stream"},
+		{Name: "project-a", Team: "team", Stream: "stream"},
+	}, ReconcileOptions{})
+	assert.EqualError(t, err, "error reconciling project project-b: unable to create new project")
+	require.Len(t, actions, 1)
+	assert.Equal(t, "project-a", actions[0].Project)
+}
+
+func TestProjectReconciler_ReconcileInvalidManifests(t *testing.T) {
+	tests := map[string]struct {
+		manifests   []*models.ProjectManifest
+		expectedErr string
+	}{
+		"missing name": {
+			manifests:   []*models.ProjectManifest{{Team: "team", Stream: "stream"}},
+			expectedErr: "project manifest must have a name",
+		},
+		"duplicate name": {
+			manifests: []*models.ProjectManifest{
+				{Name: "project", Team: "team", Stream: "stream"},
+				{Name: "project", Team: "other-team", Stream: "stream"},
+			},
+			expectedErr: "project project is defined by more than one manifest",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			reconciler := NewProjectReconciler(&mocks.ProjectRepository{}, nil)
+			_, err := reconciler.Reconcile(context.Background(), tt.manifests, ReconcileOptions{})
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
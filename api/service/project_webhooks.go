@@ -1,15 +1,51 @@
 package service
 
 import (
+	"github.com/caraml-dev/mlp/api/models"
 	wh "github.com/caraml-dev/mlp/api/pkg/webhooks"
 )
 
 const (
 	ProjectCreatedEvent wh.EventType = "OnProjectCreated"
 	ProjectUpdatedEvent wh.EventType = "OnProjectUpdated"
+	ProjectDeletedEvent wh.EventType = "OnProjectDeleted"
+	ProjectRenamedEvent wh.EventType = "OnProjectRenamed"
+	// ProjectMembersExpiredEvent is sent when the members whose membership has expired are removed from a project
+	ProjectMembersExpiredEvent wh.EventType = "OnProjectMembersExpired"
+
+	ProjectAccessRequestedEvent wh.EventType = "OnProjectAccessRequested"
+	ProjectAccessApprovedEvent  wh.EventType = "OnProjectAccessApproved"
+	ProjectAccessDeniedEvent    wh.EventType = "OnProjectAccessDenied"
 )
 
 var EventList = []wh.EventType{
 	ProjectCreatedEvent,
 	ProjectUpdatedEvent,
+	ProjectDeletedEvent,
+	ProjectRenamedEvent,
+	ProjectMembersExpiredEvent,
+	ProjectAccessRequestedEvent,
+	ProjectAccessApprovedEvent,
+	ProjectAccessDeniedEvent,
+}
+
+// ProjectRenamedPayload is sent to the OnProjectRenamed webhooks. It contains the renamed project together with its
+// previous name, so that downstream services can migrate resources named after the project.
+type ProjectRenamedPayload struct {
+	*models.Project
+	PreviousName string `json:"previous_name"`
+}
+
+// ProjectMembersExpiredPayload is sent to the OnProjectMembersExpired webhooks. It contains the project after the
+// expired members were removed together with the memberships that expired.
+type ProjectMembersExpiredPayload struct {
+	*models.Project
+	ExpiredMembers models.ProjectMemberExpiries `json:"expired_members"`
+}
+
+// ProjectAccessRequestPayload is sent to the webhooks of the project access request events, e.g. to notify the
+// administrators of the project of a new request or the requester of its review
+type ProjectAccessRequestPayload struct {
+	AccessRequest *models.ProjectAccessRequest `json:"access_request"`
+	Project       *models.Project              `json:"project"`
 }
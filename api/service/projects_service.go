@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"bytes"
 	"html/template"
@@ -20,36 +21,67 @@ import (
 	"github.com/caraml-dev/mlp/api/log"
 	"github.com/caraml-dev/mlp/api/models"
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	k8slabels "github.com/caraml-dev/mlp/api/pkg/labels"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/pkg/secretstorage"
 	"github.com/caraml-dev/mlp/api/pkg/webhooks"
 )
 
 type ProjectsService interface {
-	ListProjects(ctx context.Context, name string, user string) ([]*models.Project, error)
+	// ListProjects returns the projects matching the filter that the user has access to. Paging information is only
+	// returned if the filter has pagination options set.
+	ListProjects(ctx context.Context, filter repository.ProjectFilter, user string) ([]*models.Project,
+		*pagination.Paging, error)
 	CreateProject(ctx context.Context, project *models.Project) (*models.Project, error)
 	UpdateProject(ctx context.Context, project *models.Project) (*models.Project, map[string]interface{}, error)
+	// PatchProject applies a JSON merge patch or JSON patch to the project and updates it the same way as
+	// UpdateProject
+	PatchProject(ctx context.Context, project *models.Project, patchType PatchType, patch []byte) (*models.Project,
+		map[string]interface{}, error)
 	FindByID(projectID models.ID) (*models.Project, error)
 	FindByName(projectName string) (*models.Project, error)
-}
-
-var reservedProjectName = map[string]bool{
-	"infrastructure":     true,
-	"kube-system":        true,
-	"knative-serving":    true,
-	"kfserving-system":   true,
-	"knative-monitoring": true,
+	ArchiveProject(ctx context.Context, project *models.Project) (*models.Project, error)
+	UnarchiveProject(ctx context.Context, project *models.Project) (*models.Project, error)
+	DeleteProject(ctx context.Context, project *models.Project) error
+	// RenameProject changes the name of the project and moves its secrets to the paths of the new name
+	RenameProject(ctx context.Context, project *models.Project, name string) (*models.Project, error)
+	// GrantProjectAccess adds the member to the administrators or the readers of the project and updates it the same
+	// way as UpdateProject
+	GrantProjectAccess(ctx context.Context, project *models.Project, member string, role models.ProjectRole) (
+		*models.Project, error)
+	// RemoveExpiredMembers removes the members whose membership expired at or before the given time from the active
+	// projects and from their authorization policies. It returns the number of memberships removed.
+	RemoveExpiredMembers(ctx context.Context, now time.Time) (int, error)
+	// ListProjectHistory returns the audit logs of the project, most recent first
+	ListProjectHistory(projectID models.ID, options pagination.Options) ([]*models.ProjectAuditLog,
+		*pagination.Paging, error)
 }
 
 func NewProjectsService(
 	mlflowURL string,
 	projectRepository repository.ProjectRepository,
+	secretStorageRepository repository.SecretStorageRepository,
+	auditLogRepository repository.ProjectAuditLogRepository,
+	storageClientRegistry *secretstorage.Registry,
 	authEnforcer enforcer.Enforcer,
 	authEnabled bool,
 	webhookManager webhooks.WebhookManager,
-	updateProjectConfig config.UpdateProjectConfig) (ProjectsService, error) {
+	updateProjectConfig config.UpdateProjectConfig,
+	projectNamingConfig config.ProjectNamingConfig,
+	streamsService StreamsService,
+	groupsService GroupsService,
+	serviceAccountsService ServiceAccountsService,
+	authorizationSyncService AuthorizationSyncService) (ProjectsService, error) {
 	if strings.TrimSpace(mlflowURL) == "" {
 		return nil, errors.New("default mlflow tracking url should be provided")
 	}
 
+	namingPolicy, err := newProjectNamingPolicy(projectNamingConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	labelsBlacklistMap := make(map[string]bool)
 	for _, key := range updateProjectConfig.LabelsBlacklist {
 		labelsBlacklistMap[key] = true
@@ -57,6 +89,9 @@ func NewProjectsService(
 
 	return &projectsService{
 		projectRepository:             projectRepository,
+		secretStorageRepository:       secretStorageRepository,
+		auditLogRepository:            auditLogRepository,
+		storageClientRegistry:         storageClientRegistry,
 		defaultMlflowTrackingServer:   mlflowURL,
 		authEnforcer:                  authEnforcer,
 		authEnabled:                   authEnabled,
@@ -65,11 +100,19 @@ func NewProjectsService(
 		updateProjectPayloadTemplate:  updateProjectConfig.PayloadTemplate,
 		updateProjectResponseTemplate: updateProjectConfig.ResponseTemplate,
 		labelsBlacklistMap:            labelsBlacklistMap,
+		namingPolicy:                  namingPolicy,
+		streamsService:                streamsService,
+		groupsService:                 groupsService,
+		serviceAccountsService:        serviceAccountsService,
+		authorizationSyncService:      authorizationSyncService,
 	}, nil
 }
 
 type projectsService struct {
 	projectRepository             repository.ProjectRepository
+	secretStorageRepository       repository.SecretStorageRepository
+	auditLogRepository            repository.ProjectAuditLogRepository
+	storageClientRegistry         *secretstorage.Registry
 	defaultMlflowTrackingServer   string
 	authEnforcer                  enforcer.Enforcer
 	authEnabled                   bool
@@ -78,24 +121,57 @@ type projectsService struct {
 	updateProjectPayloadTemplate  string
 	updateProjectResponseTemplate string
 	labelsBlacklistMap            map[string]bool
+	namingPolicy                  *projectNamingPolicy
+	// streamsService validates the stream and the team of the projects against the catalogue, if it is set
+	streamsService StreamsService
+	// groupsService validates the groups granted access to the projects and expands the project listing to the
+	// projects granted to the groups of the user, if it is set
+	groupsService GroupsService
+	// serviceAccountsService validates the service accounts granted access to the projects, if it is set
+	serviceAccountsService ServiceAccountsService
+	// authorizationSyncService applies the authorization policies recorded in the outbox together with the projects,
+	// if it is set. Otherwise, the policies are applied directly after the projects are saved.
+	authorizationSyncService AuthorizationSyncService
 }
 
 func (service *projectsService) CreateProject(ctx context.Context, project *models.Project) (*models.Project, error) {
-	if _, ok := reservedProjectName[project.Name]; ok {
-		return nil, fmt.Errorf("unable to use reserved project name: %s", project.Name)
+	if err := service.namingPolicy.Validate(project); err != nil {
+		return nil, err
+	}
+	if service.streamsService != nil {
+		if err := service.streamsService.ValidateProject(project); err != nil {
+			return nil, err
+		}
+	}
+	if service.groupsService != nil {
+		if err := service.groupsService.ValidateProject(project); err != nil {
+			return nil, err
+		}
+	}
+	if service.serviceAccountsService != nil {
+		if err := service.serviceAccountsService.ValidateProject(project); err != nil {
+			return nil, err
+		}
 	}
 
 	if strings.TrimSpace(project.MLFlowTrackingURL) == "" {
 		project.MLFlowTrackingURL = service.defaultMlflowTrackingServer
 	}
+	// the expiries of members removed from the project are dropped together with them
+	project.PruneMemberExpiries()
+	project.ArchivedAt = nil
 
-	project, err := service.save(project)
+	if err := validateLabels(project.Labels, nil); err != nil {
+		return nil, err
+	}
+
+	project, err := service.save(ctx, models.ProjectCreatedAction, nil, project)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create new project")
 	}
 
 	if service.authEnabled {
-		err = service.updateAuthorizationPolicy(ctx, project)
+		err = service.syncAuthorizationPolicy(ctx, project)
 		if err != nil {
 			return nil, fmt.Errorf("error while creating authorization policy for project %s", project.Name)
 		}
@@ -110,7 +186,9 @@ func (service *projectsService) CreateProject(ctx context.Context, project *mode
 		if err := json.Unmarshal(p, &tmpproject); err != nil {
 			return webhooks.NewWebhookError(err)
 		}
-		project, err = service.save(&tmpproject)
+		// the webhook response is saved on top of the project that was just created, unless it has since been modified
+		tmpproject.Version = project.Version
+		project, err = service.save(ctx, models.ProjectUpdatedAction, project, &tmpproject)
 		if err != nil {
 			return webhooks.NewWebhookError(err)
 		}
@@ -127,36 +205,62 @@ func (service *projectsService) CreateProject(ctx context.Context, project *mode
 	return project, nil
 }
 
-func (service *projectsService) ListProjects(ctx context.Context, name string, user string) (projects []*models.Project,
-	err error) {
-	allProjects, err := service.projectRepository.ListProjects(name)
+func (service *projectsService) ListProjects(ctx context.Context, filter repository.ProjectFilter,
+	user string) ([]*models.Project, *pagination.Paging, error) {
+	if service.authEnabled {
+		err := service.applyAuthorizationFilter(ctx, &filter, user)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	projects, count, err := service.projectRepository.ListProjects(filter)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if service.authEnabled {
-		return service.filterAuthorizedProjects(ctx, allProjects, user)
+	if filter.Page == nil || filter.PageSize == nil {
+		return projects, nil, nil
 	}
-	return allProjects, nil
+	return projects, pagination.ToPaging(filter.Options, count), nil
 }
 
 func (service *projectsService) UpdateProject(ctx context.Context, project *models.Project) (*models.Project,
 	map[string]interface{}, error) {
-	if service.authEnabled {
-		err := service.updateAuthorizationPolicy(ctx, project)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error while updating authorization policy for project %s", project.Name)
-		}
-	}
-
-	blacklistedLabelsChanged, err := service.areBlacklistedLabelsChanged(project)
+	existingProject, err := service.FindByID(project.ID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("error fetching project with id %s: %w", project.ID, err)
 	}
-	if blacklistedLabelsChanged {
+
+	if service.areBlacklistedLabelsChanged(project, existingProject) {
 		return nil, nil,
 			fmt.Errorf("one or more labels are blacklisted or have been removed or changed values and cannot be updated")
 	}
 
+	if err := validateLabels(project.Labels, existingProject.Labels); err != nil {
+		return nil, nil, err
+	}
+
+	// projects created before the catalogue was enforced keep their stream and team until they are changed
+	if service.streamsService != nil &&
+		(project.Stream != existingProject.Stream || project.Team != existingProject.Team) {
+		if err := service.streamsService.ValidateProject(project); err != nil {
+			return nil, nil, err
+		}
+	}
+	if service.groupsService != nil {
+		if err := service.groupsService.ValidateProject(project); err != nil {
+			return nil, nil, err
+		}
+	}
+	if service.serviceAccountsService != nil {
+		if err := service.serviceAccountsService.ValidateProject(project); err != nil {
+			return nil, nil, err
+		}
+	}
+	// the expiries of members removed from the project are dropped together with them, so that a member granted
+	// the role again later is not removed by the sweeper
+	project.PruneMemberExpiries()
+
 	if service.webhookManager != nil && service.webhookManager.IsEventConfigured(ProjectUpdatedEvent) {
 		err = service.webhookManager.InvokeWebhooks(ctx, ProjectUpdatedEvent, project, func(p []byte) error {
 			// Expects webhook output to be a project object
@@ -164,7 +268,9 @@ func (service *projectsService) UpdateProject(ctx context.Context, project *mode
 			if err := json.Unmarshal(p, &tmpproject); err != nil {
 				return err
 			}
-			project, err = service.save(&tmpproject)
+			// the webhook response is only saved if the project has not been modified since it was read
+			tmpproject.Version = project.Version
+			project, err = service.save(ctx, models.ProjectUpdatedAction, existingProject, &tmpproject)
 			if err != nil {
 				return err
 			}
@@ -179,12 +285,21 @@ func (service *projectsService) UpdateProject(ctx context.Context, project *mode
 			return project, nil, err
 		}
 	} else {
-		project, err = service.save(project)
+		project, err = service.save(ctx, models.ProjectUpdatedAction, existingProject, project)
 		if err != nil {
 			return nil, nil, err
 		}
 	}
 
+	// the authorization policy is only updated once the project is saved, so that a concurrent update that failed
+	// the version check does not overwrite the policy
+	if service.authEnabled {
+		err := service.syncAuthorizationPolicy(ctx, project)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error while updating authorization policy for project %s", project.Name)
+		}
+	}
+
 	project, response, err := service.handleUpdateProjectRequest(project)
 	if err != nil {
 		return nil, nil, err
@@ -193,6 +308,24 @@ func (service *projectsService) UpdateProject(ctx context.Context, project *mode
 	return project, response, nil
 }
 
+func (service *projectsService) PatchProject(ctx context.Context, project *models.Project, patchType PatchType,
+	patch []byte) (*models.Project, map[string]interface{}, error) {
+	patchedProject, err := applyProjectPatch(project, patchType, patch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return service.UpdateProject(ctx, patchedProject)
+}
+
+func (service *projectsService) ListProjectHistory(projectID models.ID, options pagination.Options) (
+	[]*models.ProjectAuditLog, *pagination.Paging, error) {
+	auditLogs, count, err := service.auditLogRepository.List(projectID, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return auditLogs, pagination.ToPaging(options, count), nil
+}
+
 func (service *projectsService) FindByID(projectID models.ID) (*models.Project, error) {
 	return service.projectRepository.Get(projectID)
 }
@@ -201,12 +334,316 @@ func (service *projectsService) FindByName(projectName string) (*models.Project,
 	return service.projectRepository.GetByName(projectName)
 }
 
-func (service *projectsService) save(project *models.Project) (*models.Project, error) {
+// ArchiveProject hides the project from project listing. The project and its resources are kept as is and can still
+// be retrieved by ID or name.
+func (service *projectsService) ArchiveProject(ctx context.Context, project *models.Project) (*models.Project, error) {
+	if project.IsArchived() {
+		return project, nil
+	}
+
+	before := *project
+	archivedAt := time.Now()
+	project.ArchivedAt = &archivedAt
+	return service.save(ctx, models.ProjectArchivedAction, &before, project)
+}
+
+// UnarchiveProject makes an archived project visible again in project listing.
+func (service *projectsService) UnarchiveProject(ctx context.Context, project *models.Project) (*models.Project,
+	error) {
+	if !project.IsArchived() {
+		return project, nil
+	}
+
+	before := *project
+	project.ArchivedAt = nil
+	return service.save(ctx, models.ProjectUnarchivedAction, &before, project)
+}
+
+// DeleteProject permanently deletes a project together with its secret storages and its authorization policy. The
+// project's secrets are removed from the external secret storages once the project is deleted, so that a failure to
+// delete the project does not leave it without its secrets.
+func (service *projectsService) DeleteProject(ctx context.Context, project *models.Project) error {
+	// the project's secret storages are deleted together with the project, so they are listed beforehand
+	globalSecretStorages, err := service.secretStorageRepository.ListGlobal()
+	if err != nil {
+		return fmt.Errorf("error listing global secret storages: %w", err)
+	}
+	projectSecretStorages, err := service.secretStorageRepository.List(project.ID)
+	if err != nil {
+		return fmt.Errorf("error listing secret storages of project %s: %w", project.Name, err)
+	}
+
+	externalSecretStorages := make([]*models.SecretStorage, 0)
+	storageClients := make([]secretstorage.Client, 0)
+	for _, secretStorage := range append(globalSecretStorages, projectSecretStorages...) {
+		if secretStorage.Type == models.InternalSecretStorageType {
+			continue
+		}
+
+		storageClient, ok := service.storageClientRegistry.Get(secretStorage.ID)
+		if !ok {
+			return fmt.Errorf("secret storage client with id %d is not found", secretStorage.ID)
+		}
+		externalSecretStorages = append(externalSecretStorages, secretStorage)
+		storageClients = append(storageClients, storageClient)
+	}
+
+	err = service.projectRepository.DeleteWith(project.ID,
+		service.writeOptions(ctx, models.ProjectDeletedAction, project, nil))
+	if err != nil {
+		return err
+	}
+
+	// the project is already deleted at this point, so secrets that fail to be removed are logged to be cleaned up
+	// manually rather than failing the deletion
+	for idx, storageClient := range storageClients {
+		if err := storageClient.DeleteAll(project.Name); err != nil {
+			log.Errorf("error deleting secrets of deleted project %s from secret storage %s: %s", project.Name,
+				externalSecretStorages[idx].Name, err)
+		}
+	}
+	for _, secretStorage := range projectSecretStorages {
+		service.storageClientRegistry.Delete(secretStorage.ID)
+	}
+
+	if service.authEnabled && service.authorizationSyncService != nil {
+		if err := service.authorizationSyncService.SyncProject(ctx, project.ID); err != nil {
+			log.Warnf("%s, the removal will be retried", err)
+		}
+	} else if service.authEnabled {
+		err = service.removeAuthorizationPolicy(ctx, project)
+		if err != nil {
+			return fmt.Errorf("error while removing authorization policy for project %s: %w", project.Name, err)
+		}
+	}
+
+	// the project has already been deleted at this point, so failing to call the webhooks is logged rather than
+	// failing the deletion
+	if service.webhookManager == nil || !service.webhookManager.IsEventConfigured(ProjectDeletedEvent) {
+		return nil
+	}
+	_ = service.webhookManager.InvokeWebhooks(ctx, ProjectDeletedEvent, project, func(p []byte) error {
+		return nil
+	}, func(err error) error {
+		log.Errorf("error calling webhook - %s, err: %s", ProjectDeletedEvent, err.Error())
+		return err
+	},
+	)
+	return nil
+}
+
+// RenameProject changes the name of the project. The project's secrets in external secret storages are copied to the
+// paths of the new name before the project is renamed, and the copies are removed again if the rename fails. Once the
+// project is renamed, the secrets at the paths of the old name are deleted.
+func (service *projectsService) RenameProject(ctx context.Context, project *models.Project, name string) (
+	*models.Project, error) {
+	if project.Name == name {
+		return project, nil
+	}
+
+	renamedProject := *project
+	renamedProject.Name = name
+	if err := service.namingPolicy.Validate(&renamedProject); err != nil {
+		return nil, err
+	}
+
+	_, err := service.projectRepository.GetByName(name)
+	if err == nil {
+		return nil, apperrors.NewAlreadyExistsErrorf("project %s already exists", name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return nil, err
+	}
+
+	storageClients, err := service.externalSecretStorageClients(project)
+	if err != nil {
+		return nil, err
+	}
+
+	movedClients := make([]secretstorage.Client, 0, len(storageClients))
+	rollback := func() {
+		for _, storageClient := range movedClients {
+			if err := storageClient.DeleteAll(name); err != nil {
+				log.Errorf("error removing secrets of project %s copied to %s: %s", project.Name, name, err)
+			}
+		}
+	}
+
+	for _, storageClient := range storageClients {
+		secrets, err := storageClient.List(project.Name)
+		if err != nil {
+			rollback()
+			return nil, fmt.Errorf("error listing secrets of project %s: %w", project.Name, err)
+		}
+		if len(secrets) == 0 {
+			continue
+		}
+		if err := storageClient.SetAll(secrets, name); err != nil {
+			rollback()
+			return nil, fmt.Errorf("error copying secrets of project %s to %s: %w", project.Name, name, err)
+		}
+		movedClients = append(movedClients, storageClient)
+	}
+
+	savedProject, err := service.save(ctx, models.ProjectRenamedAction, project, &renamedProject)
+	if err != nil {
+		rollback()
+		return nil, err
+	}
+
+	for _, storageClient := range movedClients {
+		if err := storageClient.DeleteAll(project.Name); err != nil {
+			log.Errorf("error deleting secrets of project %s after renaming it to %s: %s", project.Name, name, err)
+		}
+	}
+
+	if service.webhookManager == nil || !service.webhookManager.IsEventConfigured(ProjectRenamedEvent) {
+		return savedProject, nil
+	}
+
+	err = service.webhookManager.InvokeWebhooks(ctx, ProjectRenamedEvent,
+		ProjectRenamedPayload{Project: savedProject, PreviousName: project.Name},
+		func(p []byte) error {
+			return nil
+		}, func(err error) error {
+			// Print error and return
+			log.Errorf("error calling webhook - %s, err: %s", ProjectRenamedEvent, err.Error())
+			return err
+		},
+	)
+	return savedProject, err
+}
+
+func (service *projectsService) GrantProjectAccess(ctx context.Context, project *models.Project, member string,
+	role models.ProjectRole) (*models.Project, error) {
+	if project.HasRole(member, role) {
+		return project, nil
+	}
+
+	updatedProject := *project
+	switch role {
+	case models.ProjectAdministratorRole:
+		updatedProject.Administrators = append(slices.Clone(project.Administrators), member)
+	case models.ProjectReaderRole:
+		updatedProject.Readers = append(slices.Clone(project.Readers), member)
+	default:
+		return nil, apperrors.NewInvalidArgumentErrorf("unknown project role %s", role)
+	}
+
+	savedProject, _, err := service.UpdateProject(ctx, &updatedProject)
+	return savedProject, err
+}
+
+func (service *projectsService) RemoveExpiredMembers(ctx context.Context, now time.Time) (int, error) {
+	projects, _, err := service.projectRepository.ListProjects(repository.ProjectFilter{
+		MembershipExpiredBefore: &now,
+		// the members of archived projects keep their access to the project resources, so they expire as well
+		IncludeArchived: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error listing projects with expired members: %w", err)
+	}
+
+	// a project failing to be updated, e.g. because of a concurrent update, does not prevent the others from being
+	// updated. It is retried on the next call.
+	removed, failed := 0, 0
+	for _, project := range projects {
+		expired := project.MemberExpiries.Expired(now)
+		if err := service.removeMembers(ctx, project, expired); err != nil {
+			log.Errorf("error removing expired members of project %s: %s", project.Name, err)
+			failed++
+			continue
+		}
+		removed += len(expired)
+	}
+	if failed > 0 {
+		return removed, fmt.Errorf("failed to remove the expired members of %d projects", failed)
+	}
+	return removed, nil
+}
+
+// removeMembers revokes the given memberships of the project and notifies the OnProjectMembersExpired webhooks
+func (service *projectsService) removeMembers(ctx context.Context, project *models.Project,
+	memberships models.ProjectMemberExpiries) error {
+	updatedProject := *project
+	updatedProject.RevokeMemberships(memberships)
+	savedProject, err := service.save(ctx, models.ProjectMembersExpiredAction, project, &updatedProject)
+	if err != nil {
+		return err
+	}
+	if service.authEnabled {
+		if err := service.syncAuthorizationPolicy(ctx, savedProject); err != nil {
+			return fmt.Errorf("error while updating authorization policy: %w", err)
+		}
+	}
+	for _, membership := range memberships {
+		log.Infof("removed %s from the %ss of project %s, the membership expired at %s", membership.Member,
+			membership.Role, project.Name, membership.ExpiresAt)
+	}
+
+	// the memberships have already been revoked at this point, so failing to call the webhooks is logged rather than
+	// failing the removal
+	if service.webhookManager == nil || !service.webhookManager.IsEventConfigured(ProjectMembersExpiredEvent) {
+		return nil
+	}
+	_ = service.webhookManager.InvokeWebhooks(ctx, ProjectMembersExpiredEvent,
+		ProjectMembersExpiredPayload{Project: savedProject, ExpiredMembers: memberships},
+		func(p []byte) error {
+			return nil
+		}, func(err error) error {
+			log.Errorf("error calling webhook - %s, err: %s", ProjectMembersExpiredEvent, err.Error())
+			return err
+		},
+	)
+	return nil
+}
+
+// externalSecretStorageClients returns the clients of the global and project secret storages that store the
+// project's secrets outside the MLP database
+func (service *projectsService) externalSecretStorageClients(project *models.Project) ([]secretstorage.Client,
+	error) {
+	globalSecretStorages, err := service.secretStorageRepository.ListGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("error listing global secret storages: %w", err)
+	}
+	projectSecretStorages, err := service.secretStorageRepository.List(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing secret storages of project %s: %w", project.Name, err)
+	}
+
+	storageClients := make([]secretstorage.Client, 0)
+	for _, secretStorage := range append(globalSecretStorages, projectSecretStorages...) {
+		if secretStorage.Type == models.InternalSecretStorageType {
+			continue
+		}
+
+		storageClient, ok := service.storageClientRegistry.Get(secretStorage.ID)
+		if !ok {
+			return nil, fmt.Errorf("secret storage client with id %d is not found", secretStorage.ID)
+		}
+		storageClients = append(storageClients, storageClient)
+	}
+	return storageClients, nil
+}
+
+// save persists the project and records the changes made to it since the before version in the audit log
+func (service *projectsService) save(ctx context.Context, action models.ProjectAuditAction, before *models.Project,
+	project *models.Project) (*models.Project, error) {
 	if strings.TrimSpace(project.MLFlowTrackingURL) == "" {
 		project.MLFlowTrackingURL = service.defaultMlflowTrackingServer
 	}
 
-	return service.projectRepository.Save(project)
+	return service.projectRepository.SaveWith(project, service.writeOptions(ctx, action, before, project))
+}
+
+// writeOptions returns the audit log and the authorization sync to be written in the same transaction as the change
+// of the project
+func (service *projectsService) writeOptions(ctx context.Context, action models.ProjectAuditAction,
+	before *models.Project, after *models.Project) repository.ProjectWriteOptions {
+	return repository.ProjectWriteOptions{
+		AuditLog:          service.newAuditLog(ctx, action, before, after),
+		SyncAuthorization: service.authEnabled && service.authorizationSyncService != nil,
+	}
 }
 
 func readPermissions(project *models.Project) []string {
@@ -222,24 +659,56 @@ func adminPermissions(project *models.Project) []string {
 	for _, method := range []string{"get", "put", "post", "patch", "delete"} {
 		permissions = append(permissions, fmt.Sprintf("mlp.projects.%d.%s", project.ID, method))
 	}
-	return permissions
+	// the secrets of the project are only managed by its administrators
+	for _, method := range []string{"post", "patch", "delete"} {
+		permissions = append(permissions, fmt.Sprintf("mlp.projects.%d.secrets.%s", project.ID, method))
+	}
+	return append(permissions, secretReaderPermissions(project)...)
+}
+
+// secretReaderPermissions allow reading the values of all secrets of the project, which readers of the project can
+// only list without their values
+func secretReaderPermissions(project *models.Project) []string {
+	return []string{secretsReadPermission(project.ID)}
+}
+
+// syncAuthorizationPolicy applies the authorization policy of the saved project. The failures are only logged if the
+// policy is recorded in the outbox together with the project, since the worker retries it.
+func (service *projectsService) syncAuthorizationPolicy(ctx context.Context, project *models.Project) error {
+	if service.authorizationSyncService == nil {
+		return service.updateAuthorizationPolicy(ctx, project)
+	}
+	if err := service.authorizationSyncService.SyncProject(ctx, project.ID); err != nil {
+		log.Warnf("%s, it will be retried", err)
+	}
+	return nil
 }
 
 func (service *projectsService) updateAuthorizationPolicy(ctx context.Context, project *models.Project) error {
+	updateRequest, err := projectAuthorizationPolicy(project)
+	if err != nil {
+		return err
+	}
+	return service.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// projectAuthorizationPolicy returns the update request granting the permissions of the project to its roles and
+// setting the members of its roles
+func projectAuthorizationPolicy(project *models.Project) (enforcer.AuthorizationUpdateRequest, error) {
 	updateRequest := enforcer.NewAuthorizationUpdateRequest()
 	rolesWithReadOnlyAccess, err := enforcer.ParseProjectRoles([]string{
 		enforcer.MLPProjectsReaderRole,
 		enforcer.MLPProjectReaderRole,
 	}, project)
 	if err != nil {
-		return err
+		return updateRequest, err
 	}
 	for _, role := range rolesWithReadOnlyAccess {
 		updateRequest.AddRolePermissions(role, readPermissions(project))
 	}
 	projectAdminRole, err := enforcer.ParseProjectRole(enforcer.MLPProjectAdminRole, project)
 	if err != nil {
-		return err
+		return updateRequest, err
 	}
 	if project.Administrators != nil {
 		updateRequest.SetRoleMembers(projectAdminRole, project.Administrators)
@@ -252,14 +721,14 @@ func (service *projectsService) updateAuthorizationPolicy(ctx context.Context, p
 		enforcer.MLPProjectAdminRole,
 	}, project)
 	if err != nil {
-		return err
+		return updateRequest, err
 	}
 	for _, role := range rolesWithAdminAccess {
 		updateRequest.AddRolePermissions(role, adminPermissions(project))
 	}
 	projectReaderRole, err := enforcer.ParseProjectRole(enforcer.MLPProjectReaderRole, project)
 	if err != nil {
-		return err
+		return updateRequest, err
 	}
 	if project.Readers != nil {
 		updateRequest.SetRoleMembers(projectReaderRole, project.Readers)
@@ -268,33 +737,92 @@ func (service *projectsService) updateAuthorizationPolicy(ctx context.Context, p
 
 	}
 
+	projectSecretReaderRole, err := enforcer.ParseProjectRole(enforcer.MLPProjectSecretReaderRole, project)
+	if err != nil {
+		return updateRequest, err
+	}
+	updateRequest.AddRolePermissions(projectSecretReaderRole, secretReaderPermissions(project))
+	if project.SecretReaders != nil {
+		updateRequest.SetRoleMembers(projectSecretReaderRole, project.SecretReaders)
+	} else {
+		updateRequest.SetRoleMembers(projectSecretReaderRole, []string{})
+	}
+
+	return updateRequest, nil
+}
+
+// removeAuthorizationPolicy revokes the project permissions from all roles and removes all members of the project
+// roles, reverting the changes made by updateAuthorizationPolicy.
+func (service *projectsService) removeAuthorizationPolicy(ctx context.Context, project *models.Project) error {
+	updateRequest, err := projectRemovalAuthorizationPolicy(project)
+	if err != nil {
+		return err
+	}
 	return service.authEnforcer.UpdateAuthorization(ctx, updateRequest)
 }
 
+// projectRemovalAuthorizationPolicy returns the update request reverting the policy of projectAuthorizationPolicy.
+// It only depends on the ID of the project.
+func projectRemovalAuthorizationPolicy(project *models.Project) (enforcer.AuthorizationUpdateRequest, error) {
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	roles, err := enforcer.ParseProjectRoles([]string{
+		enforcer.MLPAdminRole,
+		enforcer.MLPProjectsReaderRole,
+		enforcer.MLPProjectAdminRole,
+		enforcer.MLPProjectReaderRole,
+		enforcer.MLPProjectSecretReaderRole,
+	}, project)
+	if err != nil {
+		return updateRequest, err
+	}
+	for _, role := range roles {
+		updateRequest.RemoveRolePermissions(role, adminPermissions(project))
+	}
+
+	projectRoles, err := enforcer.ParseProjectRoles([]string{
+		enforcer.MLPProjectAdminRole,
+		enforcer.MLPProjectReaderRole,
+		enforcer.MLPProjectSecretReaderRole,
+	}, project)
+	if err != nil {
+		return updateRequest, err
+	}
+	for _, role := range projectRoles {
+		updateRequest.SetRoleMembers(role, []string{})
+	}
+
+	return updateRequest, nil
+}
+
+// applyAuthorizationFilter restricts the filter to projects in which the user is a member, unless the user has
+// access to all projects.
 // TODO: Evaluate if we should retrieve all permissions granted to a user as opposed to just roles
-func (service *projectsService) filterAuthorizedProjects(ctx context.Context, projects []*models.Project,
-	user string) ([]*models.Project, error) {
+func (service *projectsService) applyAuthorizationFilter(ctx context.Context, filter *repository.ProjectFilter,
+	user string) error {
 	if user == "" {
-		return nil, fmt.Errorf("authorization is enabled but user is not provided")
+		return fmt.Errorf("authorization is enabled but user is not provided")
 	}
 
 	roles, err := service.authEnforcer.GetUserRoles(ctx, user)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	for _, role := range roles {
 		if slices.Contains([]string{enforcer.MLPAdminRole, enforcer.MLPProjectsReaderRole}, role) {
-			return projects, nil
+			return nil
 		}
 	}
-	authorizedProjects := make([]*models.Project, 0)
-	for _, project := range projects {
-		if (project.Administrators != nil && slices.Contains(project.Administrators, user)) ||
-			(project.Readers != nil && slices.Contains(project.Readers, user)) {
-			authorizedProjects = append(authorizedProjects, project)
+	filter.Member = user
+	if service.groupsService != nil {
+		groups, err := service.groupsService.ListUserGroups(user)
+		if err != nil {
+			return err
+		}
+		for _, group := range groups {
+			filter.MemberGroups = append(filter.MemberGroups, models.GroupSubject(group))
 		}
 	}
-	return authorizedProjects, nil
+	return nil
 }
 
 func (service *projectsService) handleUpdateProjectRequest(project *models.Project) (*models.Project,
@@ -389,12 +917,8 @@ func processResponseTemplate(response *http.Response, templateString string) (ma
 }
 
 // areBlacklistedLabelsChanged check if any key in labels is blacklisted
-func (service *projectsService) areBlacklistedLabelsChanged(project *models.Project) (bool, error) {
-	existingProject, err := service.FindByID(project.ID)
-	if err != nil {
-		return false, fmt.Errorf("error fetching project with id %s: %w", project.ID, err)
-	}
-
+func (service *projectsService) areBlacklistedLabelsChanged(project *models.Project,
+	existingProject *models.Project) bool {
 	newLabelsMap := make(map[string]string)
 	for _, newLabel := range project.Labels {
 		newLabelsMap[newLabel.Key] = newLabel.Value
@@ -404,10 +928,41 @@ func (service *projectsService) areBlacklistedLabelsChanged(project *models.Proj
 		if service.labelsBlacklistMap[existingLabel.Key] {
 			newValue, exists := newLabelsMap[existingLabel.Key]
 			if !exists || newValue != existingLabel.Value {
-				return true, nil
+				return true
 			}
 		}
 	}
 
-	return false, nil
+	return false
+}
+
+// validateLabels checks that the labels are valid Kubernetes labels and that no label key is repeated. Labels that
+// are also in existingLabels are not validated, so that projects created before the validation was introduced can
+// still be updated.
+func validateLabels(labels models.Labels, existingLabels models.Labels) error {
+	existing := make(map[models.Label]bool)
+	for _, label := range existingLabels {
+		existing[label] = true
+	}
+
+	keyCount := make(map[string]int)
+	for _, label := range labels {
+		keyCount[label.Key]++
+	}
+
+	for _, label := range labels {
+		if existing[label] {
+			continue
+		}
+		if keyCount[label.Key] > 1 {
+			return apperrors.NewInvalidArgumentErrorf("label key %q is duplicated", label.Key)
+		}
+		if err := k8slabels.ValidateKey(label.Key); err != nil {
+			return apperrors.NewInvalidArgumentErrorf("%s", err)
+		}
+		if err := k8slabels.ValidateValue(label.Value); err != nil {
+			return apperrors.NewInvalidArgumentErrorf("%s", err)
+		}
+	}
+	return nil
 }
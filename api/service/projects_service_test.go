@@ -3,24 +3,33 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"bytes"
 	"io"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 
 	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/pkg/secretstorage"
+	ssmocks "github.com/caraml-dev/mlp/api/pkg/secretstorage/mocks"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/caraml-dev/mlp/api/config"
 	"github.com/caraml-dev/mlp/api/models"
 	"github.com/caraml-dev/mlp/api/pkg/webhooks"
+	"github.com/caraml-dev/mlp/api/repository"
 	"github.com/caraml-dev/mlp/api/repository/mocks"
+	servicemocks "github.com/caraml-dev/mlp/api/service/mocks"
 
 	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
 )
@@ -56,16 +65,24 @@ func TestProjectsService_CreateProject(t *testing.T) {
 			&enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
 					"mlp.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
-					"mlp.projects.reader":   {"mlp.projects.1.get"},
-					"mlp.projects.1.reader": {"mlp.projects.1.get"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.reader":          {"mlp.projects.1.get"},
+					"mlp.projects.1.reader":        {"mlp.projects.1.get"},
+					"mlp.projects.1.secret_reader": {"mlp.projects.1.secrets.read"},
 					"mlp.projects.1.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.1.reader":        {},
+					"mlp.projects.1.secret_reader": {},
 					"mlp.projects.1.administrator": {"user@email.com"},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 			false,
 			"",
@@ -102,23 +119,28 @@ func TestProjectsService_CreateProject(t *testing.T) {
 			nil,
 			nil,
 			true,
-			"unable to use reserved project name: infrastructure",
+			"project name infrastructure violates the naming policy: infrastructure is a reserved project name",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			storage := &mocks.ProjectRepository{}
-			storage.On("Save", tt.expResult).Return(tt.expResult, nil)
+			storage.On("SaveWith", tt.expResult, repository.ProjectWriteOptions{}).Return(tt.expResult, nil)
 
 			authEnforcer := &enforcerMock.Enforcer{}
 
 			projectsService, err := NewProjectsService(
-				MLFlowTrackingURL, storage, authEnforcer, tt.authEnabled, nil,
+				MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, tt.authEnabled, nil,
 				config.UpdateProjectConfig{
 					Endpoint:         "",
 					PayloadTemplate:  "",
 					ResponseTemplate: "",
 				},
+				config.NewDefaultConfig().ProjectNaming,
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -145,6 +167,39 @@ func TestProjectsService_CreateProject(t *testing.T) {
 	}
 }
 
+func TestProjectsService_CreateProjectWithAuthorizationSync(t *testing.T) {
+	project := &models.Project{ID: 1, Name: "my-project", Administrators: []string{"user@email.com"}}
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("SaveWith", project, repository.ProjectWriteOptions{SyncAuthorization: true}).Return(project, nil)
+	storage.On("Get", project.ID).Return(project, nil)
+	syncRepository := &mocks.AuthorizationSyncRepository{}
+	syncRepository.On("Apply", project.ID, mock.Anything).Return(
+		applySyncs(&models.AuthorizationSync{ID: 1, ProjectID: 1, Action: models.AuthorizationSyncUpdate}))
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, mock.Anything).Return(errors.New("keto is unavailable"))
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, true, nil,
+		config.UpdateProjectConfig{},
+		config.NewDefaultConfig().ProjectNaming,
+		nil,
+		nil,
+		nil,
+		NewAuthorizationSyncService(syncRepository, storage, authEnforcer),
+	)
+	require.NoError(t, err)
+
+	// the policy is retried by the worker, so the project is created even if it cannot be applied right away
+	res, err := projectsService.CreateProject(context.Background(), project)
+	require.NoError(t, err)
+	require.Equal(t, project, res)
+	storage.AssertExpectations(t)
+	syncRepository.AssertExpectations(t)
+	authEnforcer.AssertExpectations(t)
+	storage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
 func TestProjectsService_UpdateProject(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -184,16 +239,24 @@ func TestProjectsService_UpdateProject(t *testing.T) {
 			&enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
 					"mlp.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
-					"mlp.projects.reader":   {"mlp.projects.1.get"},
-					"mlp.projects.1.reader": {"mlp.projects.1.get"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.reader":          {"mlp.projects.1.get"},
+					"mlp.projects.1.reader":        {"mlp.projects.1.get"},
+					"mlp.projects.1.secret_reader": {"mlp.projects.1.secrets.read"},
 					"mlp.projects.1.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.1.reader":        {},
+					"mlp.projects.1.secret_reader": {},
 					"mlp.projects.1.administrator": {"user@email.com"},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 			"endpoint-url",
 			`{"project": "{{.Name}}", "administrators": "{{.Administrators}}"}`,
@@ -226,16 +289,24 @@ func TestProjectsService_UpdateProject(t *testing.T) {
 			&enforcer.AuthorizationUpdateRequest{
 				RolePermissions: map[string][]string{
 					"mlp.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
-					"mlp.projects.reader":   {"mlp.projects.1.get"},
-					"mlp.projects.1.reader": {"mlp.projects.1.get"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.reader":          {"mlp.projects.1.get"},
+					"mlp.projects.1.reader":        {"mlp.projects.1.get"},
+					"mlp.projects.1.secret_reader": {"mlp.projects.1.secrets.read"},
 					"mlp.projects.1.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
-						"mlp.projects.1.patch", "mlp.projects.1.delete"},
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
 				},
 				RoleMembers: map[string][]string{
 					"mlp.projects.1.reader":        {},
+					"mlp.projects.1.secret_reader": {},
 					"mlp.projects.1.administrator": {"user@email.com"},
 				},
+				RemovedRolePermissions: map[string][]string{},
+				GroupMembers:           map[string][]string{},
+				RemovedRoleMembers:     map[string][]string{},
+				RemovedGroupMembers:    map[string][]string{},
 			},
 			"",
 			`{"project": "{{.Name}}", "administrators": "{{.Administrators}}"}`,
@@ -275,7 +346,7 @@ func TestProjectsService_UpdateProject(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			storage := &mocks.ProjectRepository{}
-			storage.On("Save", tt.expResult).Return(tt.expResult, nil)
+			storage.On("SaveWith", tt.expResult, repository.ProjectWriteOptions{}).Return(tt.expResult, nil)
 			storage.On("Get", tt.existingProject.ID).Return(tt.existingProject, nil)
 
 			authEnforcer := &enforcerMock.Enforcer{}
@@ -304,13 +375,18 @@ func TestProjectsService_UpdateProject(t *testing.T) {
 			}
 
 			projectsService, err := NewProjectsService(
-				MLFlowTrackingURL, storage, authEnforcer, tt.authEnabled, nil,
+				MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, tt.authEnabled, nil,
 				config.UpdateProjectConfig{
 					Endpoint:         tt.updateProjectEndpoint,
 					PayloadTemplate:  tt.updateProjectPayload,
 					ResponseTemplate: tt.updateProjectResponse,
 					LabelsBlacklist:  tt.labelsBlacklist,
 				},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			assert.NoError(t, err)
 
@@ -331,6 +407,80 @@ func TestProjectsService_UpdateProject(t *testing.T) {
 	}
 }
 
+func TestProjectsService_UpdateProjectStaleVersion(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"user@email.com"},
+		Version:        1,
+	}
+	existingProject := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"user@email.com"},
+		Version:        2,
+	}
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("Get", project.ID).Return(existingProject, nil)
+	storage.On("SaveWith", project, repository.ProjectWriteOptions{}).Return(nil,
+		apperrors.NewPreconditionFailedErrorf("project my-project has been modified by another request"))
+
+	// the authorization policy must not be updated when the project could not be saved
+	authEnforcer := &enforcerMock.Enforcer{}
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, true, nil,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	_, _, err = projectsService.UpdateProject(context.Background(), project)
+	assert.ErrorIs(t, err, &apperrors.PreconditionFailedError{})
+
+	storage.AssertExpectations(t)
+	authEnforcer.AssertExpectations(t)
+}
+
+func TestProjectsService_UpdateProjectWithWebhookKeepsVersion(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"user@email.com"},
+		Version:        3,
+	}
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("Get", project.ID).Return(project, nil)
+	// the webhook response does not carry the version, so it has to be taken from the project that was read
+	storage.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+		return p.Version == 3 && p.Team == "dsp"
+	}), repository.ProjectWriteOptions{}).
+		Return(nil, apperrors.NewPreconditionFailedErrorf("project my-project has been modified by another request"))
+
+	webhookClient := &webhooks.MockWebhookClient{}
+	webhookClient.On("IsAsync").Return(false)
+	webhookClient.On("GetName").Return("webhook1")
+	webhookClient.On("IsFinalResponse").Return(true)
+	webhookClient.On("GetUseDataFrom").Return("")
+	webhookClient.On("Invoke", mock.Anything, mock.Anything).
+		Return([]byte(`{"id": 1, "name": "my-project", "administrators": ["user@email.com"], "team": "dsp"}`), nil)
+	whManager := &webhooks.SimpleWebhookManager{
+		SyncClients: map[webhooks.EventType][]webhooks.WebhookClient{
+			ProjectUpdatedEvent: {webhookClient},
+		},
+	}
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, nil, nil, nil, &enforcerMock.Enforcer{}, false, whManager,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	_, _, err = projectsService.UpdateProject(context.Background(), project)
+	assert.ErrorIs(t, err, &apperrors.PreconditionFailedError{})
+
+	storage.AssertExpectations(t)
+}
+
 func TestProjectsService_ListProjects(t *testing.T) {
 	project1 := &models.Project{
 		ID:                1,
@@ -347,87 +497,130 @@ func TestProjectsService_ListProjects(t *testing.T) {
 		Readers:           []string{"reader-2@email.com"},
 	}
 	allProjects := []*models.Project{project1, project2}
-	storage := &mocks.ProjectRepository{}
-	storage.On("ListProjects", "project-").Return(allProjects, nil)
+	// listProjects mimics the membership filtering done by the repository
+	listProjects := func(filter repository.ProjectFilter) ([]*models.Project, int, error) {
+		projects := make([]*models.Project, 0)
+		for _, project := range allProjects {
+			if filter.Member == "" || slices.Contains(project.Administrators, filter.Member) ||
+				slices.Contains(project.Readers, filter.Member) {
+				projects = append(projects, project)
+			}
+		}
+		return projects, len(projects), nil
+	}
+	one, ten := int32(1), int32(10)
 
 	tests := []struct {
 		name          string
-		projectFilter string
+		projectFilter repository.ProjectFilter
 		authEnabled   bool
+		expFilter     repository.ProjectFilter
 		expResult     []*models.Project
+		expPaging     *pagination.Paging
 		user          string
 		userRoles     []string
 	}{
 		{
 			"filter only by project name when auth is disabled",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			false,
+			repository.ProjectFilter{Name: "project-"},
 			allProjects,
+			nil,
 			"anonymous@email.com",
 			nil,
 		},
 		{
 			"filter by permission and project name when auth is enabled",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			true,
+			repository.ProjectFilter{Name: "project-", Member: "anonymous-user@email.com"},
 			[]*models.Project{},
+			nil,
 			"anonymous-user@email.com",
 			[]string{},
 		},
 		{
 			"allow project admin to read project, regardless of user roles return by enforcer",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			true,
+			repository.ProjectFilter{Name: "project-", Member: "admin-1@email.com"},
 			[]*models.Project{project1},
+			nil,
 			"admin-1@email.com",
 			[]string{"some roles"},
 		},
 		{
 			"allow project reader to read project, regardless of user roles return by enforcer",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			true,
+			repository.ProjectFilter{Name: "project-", Member: "reader-2@email.com"},
 			[]*models.Project{project2},
+			nil,
 			"reader-2@email.com",
 			[]string{"some roles"},
 		},
 		{
 			"allow mlp administrators to read all projects",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			true,
+			repository.ProjectFilter{Name: "project-"},
 			allProjects,
+			nil,
 			"mlp-admin@email.com",
 			[]string{"mlp.administrator"},
 		},
 		{
 			"allow project readers to read all projects",
-			"project-",
+			repository.ProjectFilter{Name: "project-"},
 			true,
+			repository.ProjectFilter{Name: "project-"},
 			allProjects,
+			nil,
 			"project-reader@email.com",
 			[]string{"mlp.projects.reader"},
 		},
+		{
+			"return paging when pagination is requested",
+			repository.ProjectFilter{Options: pagination.Options{Page: &one, PageSize: &ten}},
+			false,
+			repository.ProjectFilter{Options: pagination.Options{Page: &one, PageSize: &ten}},
+			allProjects,
+			&pagination.Paging{Page: 1, Pages: 1, Total: 2},
+			"anonymous@email.com",
+			nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			storage := &mocks.ProjectRepository{}
+			storage.On("ListProjects", tt.expFilter).Return(listProjects)
+
 			authEnforcer := &enforcerMock.Enforcer{}
 			if tt.authEnabled {
 				authEnforcer.On("GetUserRoles", mock.Anything, tt.user).Return(tt.userRoles, nil)
 			}
 
 			projectsService, err := NewProjectsService(
-				MLFlowTrackingURL, storage, authEnforcer, tt.authEnabled, nil,
+				MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, tt.authEnabled, nil,
 				config.UpdateProjectConfig{
 					Endpoint:         "",
 					PayloadTemplate:  "",
 					ResponseTemplate: "",
 				},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			assert.NoError(t, err)
 
-			res, err := projectsService.ListProjects(context.Background(), "project-", tt.user)
+			res, paging, err := projectsService.ListProjects(context.Background(), tt.projectFilter, tt.user)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expResult, res)
+			assert.Equal(t, tt.expPaging, paging)
 
 			storage.AssertExpectations(t)
 			authEnforcer.AssertExpectations(t)
@@ -451,12 +644,17 @@ func TestProjectsService_FindById(t *testing.T) {
 	authEnforcer := &enforcerMock.Enforcer{}
 
 	projectsService, err := NewProjectsService(
-		MLFlowTrackingURL, storage, authEnforcer, false, nil,
+		MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, false, nil,
 		config.UpdateProjectConfig{
 			Endpoint:         "",
 			PayloadTemplate:  "",
 			ResponseTemplate: "",
 		},
+		config.ProjectNamingConfig{},
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	assert.NoError(t, err)
 
@@ -467,6 +665,403 @@ func TestProjectsService_FindById(t *testing.T) {
 	storage.AssertExpectations(t)
 }
 
+func TestProjectsService_ArchiveProject(t *testing.T) {
+	archivedAt := time.Now()
+	tests := []struct {
+		name    string
+		archive bool
+		arg     *models.Project
+		expSave bool
+	}{
+		{
+			"success: archive project",
+			true,
+			&models.Project{ID: 1, Name: "my-project", MLFlowTrackingURL: MLFlowTrackingURL},
+			true,
+		},
+		{
+			"success: archive already archived project",
+			true,
+			&models.Project{ID: 1, Name: "my-project", MLFlowTrackingURL: MLFlowTrackingURL, ArchivedAt: &archivedAt},
+			false,
+		},
+		{
+			"success: unarchive project",
+			false,
+			&models.Project{ID: 1, Name: "my-project", MLFlowTrackingURL: MLFlowTrackingURL, ArchivedAt: &archivedAt},
+			true,
+		},
+		{
+			"success: unarchive project that is not archived",
+			false,
+			&models.Project{ID: 1, Name: "my-project", MLFlowTrackingURL: MLFlowTrackingURL},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &mocks.ProjectRepository{}
+			if tt.expSave {
+				storage.On("SaveWith", tt.arg, repository.ProjectWriteOptions{}).Return(tt.arg, nil)
+			}
+
+			projectsService, err := NewProjectsService(
+				MLFlowTrackingURL, storage, nil, nil, nil, nil, false, nil,
+				config.UpdateProjectConfig{},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			var res *models.Project
+			if tt.archive {
+				res, err = projectsService.ArchiveProject(context.Background(), tt.arg)
+			} else {
+				res, err = projectsService.UnarchiveProject(context.Background(), tt.arg)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.archive, res.IsArchived())
+
+			storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectsService_DeleteProject(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"user@email.com"},
+	}
+	projectID := project.ID
+	internalSecretStorage := &models.SecretStorage{
+		ID:    1,
+		Name:  "internal",
+		Type:  models.InternalSecretStorageType,
+		Scope: models.GlobalSecretStorageScope,
+	}
+	globalSecretStorage := &models.SecretStorage{
+		ID:    2,
+		Name:  "vault",
+		Type:  models.VaultSecretStorageType,
+		Scope: models.GlobalSecretStorageScope,
+	}
+	projectSecretStorage := &models.SecretStorage{
+		ID:        3,
+		Name:      "project-vault",
+		Type:      models.VaultSecretStorageType,
+		Scope:     models.ProjectSecretStorageScope,
+		ProjectID: &projectID,
+	}
+
+	tests := []struct {
+		name             string
+		authEnabled      bool
+		deleteError      error
+		deleteAllError   error
+		webhookError     error
+		expUpdateRequest *enforcer.AuthorizationUpdateRequest
+		wantErrorMsg     string
+	}{
+		{
+			name:        "success: auth enabled",
+			authEnabled: true,
+			expUpdateRequest: &enforcer.AuthorizationUpdateRequest{
+				RolePermissions: map[string][]string{},
+				RoleMembers: map[string][]string{
+					"mlp.projects.1.reader":        {},
+					"mlp.projects.1.secret_reader": {},
+					"mlp.projects.1.administrator": {},
+				},
+				RemovedRolePermissions: map[string][]string{
+					"mlp.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.reader": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.1.reader": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.1.secret_reader": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+					"mlp.projects.1.administrator": {"mlp.projects.1.get", "mlp.projects.1.put", "mlp.projects.1.post",
+						"mlp.projects.1.patch", "mlp.projects.1.delete", "mlp.projects.1.secrets.post",
+						"mlp.projects.1.secrets.patch", "mlp.projects.1.secrets.delete", "mlp.projects.1.secrets.read"},
+				},
+				GroupMembers:        map[string][]string{},
+				RemovedRoleMembers:  map[string][]string{},
+				RemovedGroupMembers: map[string][]string{},
+			},
+		},
+		{
+			name:        "success: auth disabled",
+			authEnabled: false,
+		},
+		{
+			name:           "success: unable to delete secrets from secret storage",
+			deleteAllError: errors.New("vault is unavailable"),
+		},
+		{
+			name:         "success: unable to call webhooks",
+			webhookError: errors.New("webhook failed"),
+		},
+		{
+			name:         "failed: unable to delete project",
+			deleteError:  errors.New("database is unavailable"),
+			wantErrorMsg: "database is unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageRepository := &mocks.SecretStorageRepository{}
+			storageRepository.On("ListGlobal").
+				Return([]*models.SecretStorage{internalSecretStorage, globalSecretStorage}, nil)
+			storageRepository.On("List", project.ID).
+				Return([]*models.SecretStorage{projectSecretStorage}, nil)
+
+			// the secrets are only deleted once the project is deleted, and a storage failing to delete them does
+			// not prevent the others from doing so
+			globalStorageClient := &ssmocks.Client{}
+			projectStorageClient := &ssmocks.Client{}
+			if tt.deleteError == nil {
+				globalStorageClient.On("DeleteAll", project.Name).Return(tt.deleteAllError)
+				projectStorageClient.On("DeleteAll", project.Name).Return(nil)
+			}
+
+			registry, err := secretstorage.NewRegistry([]*models.SecretStorage{})
+			require.NoError(t, err)
+			registry.Set(globalSecretStorage.ID, globalStorageClient)
+			registry.Set(projectSecretStorage.ID, projectStorageClient)
+
+			storage := &mocks.ProjectRepository{}
+			authEnforcer := &enforcerMock.Enforcer{}
+			storage.On("DeleteWith", project.ID, repository.ProjectWriteOptions{}).Return(tt.deleteError)
+			if tt.expUpdateRequest != nil {
+				authEnforcer.On("UpdateAuthorization", mock.Anything, *tt.expUpdateRequest).Return(nil)
+			}
+			webhookManager := &webhooks.MockWebhookManager{}
+			webhookManager.On("IsEventConfigured", ProjectDeletedEvent).Return(tt.webhookError != nil).Maybe()
+			if tt.webhookError != nil {
+				webhookManager.On("InvokeWebhooks", mock.Anything, ProjectDeletedEvent, project, mock.Anything,
+					mock.Anything).Return(tt.webhookError)
+			}
+
+			projectsService, err := NewProjectsService(
+				MLFlowTrackingURL, storage, storageRepository, nil, registry, authEnforcer, tt.authEnabled,
+				webhookManager,
+				config.UpdateProjectConfig{},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			err = projectsService.DeleteProject(context.Background(), project)
+			if tt.wantErrorMsg != "" {
+				assert.EqualError(t, err, tt.wantErrorMsg)
+				_, ok := registry.Get(projectSecretStorage.ID)
+				assert.True(t, ok)
+			} else {
+				require.NoError(t, err)
+				_, ok := registry.Get(projectSecretStorage.ID)
+				assert.False(t, ok)
+			}
+
+			storage.AssertExpectations(t)
+			authEnforcer.AssertExpectations(t)
+			globalStorageClient.AssertExpectations(t)
+			projectStorageClient.AssertExpectations(t)
+			webhookManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectsService_RenameProject(t *testing.T) {
+	project := &models.Project{
+		ID:             1,
+		Name:           "my-project",
+		Administrators: []string{"user@email.com"},
+		Version:        1,
+	}
+	projectID := project.ID
+	globalSecretStorage := &models.SecretStorage{
+		ID:    2,
+		Name:  "vault",
+		Type:  models.VaultSecretStorageType,
+		Scope: models.GlobalSecretStorageScope,
+	}
+	projectSecretStorage := &models.SecretStorage{
+		ID:        3,
+		Name:      "project-vault",
+		Type:      models.VaultSecretStorageType,
+		Scope:     models.ProjectSecretStorageScope,
+		ProjectID: &projectID,
+	}
+	secrets := map[string]string{"secret": "value"}
+
+	tests := []struct {
+		name           string
+		newName        string
+		existing       *models.Project
+		setAllError    error
+		saveError      error
+		expRolledBack  bool
+		expSecretsMove bool
+		wantErrorMsg   string
+	}{
+		{
+			name:           "success",
+			newName:        "new-project",
+			expSecretsMove: true,
+		},
+		{
+			name:         "failed: name already used",
+			newName:      "other-project",
+			existing:     &models.Project{ID: 2, Name: "other-project"},
+			wantErrorMsg: "project other-project already exists",
+		},
+		{
+			name:    "failed: reserved name",
+			newName: "infrastructure",
+			wantErrorMsg: "project name infrastructure violates the naming policy: infrastructure is a reserved " +
+				"project name",
+		},
+		{
+			name:          "failed: unable to copy secrets",
+			newName:       "new-project",
+			setAllError:   errors.New("vault is unavailable"),
+			expRolledBack: true,
+			wantErrorMsg:  "error copying secrets of project my-project to new-project: vault is unavailable",
+		},
+		{
+			name:          "failed: unable to save project",
+			newName:       "new-project",
+			saveError:     apperrors.NewPreconditionFailedErrorf("project my-project has been modified"),
+			expRolledBack: true,
+			wantErrorMsg:  "project my-project has been modified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageRepository := &mocks.SecretStorageRepository{}
+			storageRepository.On("ListGlobal").Return([]*models.SecretStorage{globalSecretStorage}, nil)
+			storageRepository.On("List", project.ID).Return([]*models.SecretStorage{projectSecretStorage}, nil)
+
+			// only the global secret storage has secrets of the project, which are copied first
+			globalStorageClient := &ssmocks.Client{}
+			globalStorageClient.On("List", project.Name).Return(secrets, nil).Maybe()
+			globalStorageClient.On("SetAll", secrets, tt.newName).Return(tt.setAllError).Maybe()
+			projectStorageClient := &ssmocks.Client{}
+			projectStorageClient.On("List", project.Name).Return(map[string]string{}, nil).Maybe()
+			if tt.expSecretsMove {
+				globalStorageClient.On("DeleteAll", project.Name).Return(nil)
+			}
+			if tt.expRolledBack && tt.setAllError == nil {
+				globalStorageClient.On("DeleteAll", tt.newName).Return(nil)
+			}
+
+			registry, err := secretstorage.NewRegistry([]*models.SecretStorage{})
+			require.NoError(t, err)
+			registry.Set(globalSecretStorage.ID, globalStorageClient)
+			registry.Set(projectSecretStorage.ID, projectStorageClient)
+
+			storage := &mocks.ProjectRepository{}
+			if tt.existing != nil {
+				storage.On("GetByName", tt.newName).Return(tt.existing, nil)
+			} else {
+				storage.On("GetByName", tt.newName).
+					Return(nil, apperrors.NewNotFoundErrorf("project with name %s not found", tt.newName))
+			}
+			storage.On("SaveWith", mock.MatchedBy(func(p *models.Project) bool {
+				return p.Name == tt.newName && p.Version == project.Version
+			}), repository.ProjectWriteOptions{}).
+				Return(func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project {
+					if tt.saveError != nil {
+						return nil
+					}
+					return p
+				}, tt.saveError).Maybe()
+
+			projectsService, err := NewProjectsService(
+				MLFlowTrackingURL, storage, storageRepository, nil, registry, &enforcerMock.Enforcer{}, false, nil,
+				config.UpdateProjectConfig{},
+				config.NewDefaultConfig().ProjectNaming,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			renamed, err := projectsService.RenameProject(context.Background(), project, tt.newName)
+			if tt.wantErrorMsg != "" {
+				assert.EqualError(t, err, tt.wantErrorMsg)
+				if tt.saveError == nil {
+					storage.AssertNotCalled(t, "Save", mock.Anything)
+				}
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.newName, renamed.Name)
+				assert.Equal(t, "my-project", project.Name)
+			}
+
+			globalStorageClient.AssertExpectations(t)
+			projectStorageClient.AssertNotCalled(t, "SetAll", mock.Anything, mock.Anything)
+			projectStorageClient.AssertNotCalled(t, "DeleteAll", mock.Anything)
+		})
+	}
+}
+
+func TestProjectsService_RenameProjectWithWebhook(t *testing.T) {
+	project := &models.Project{ID: 1, Name: "my-project"}
+
+	storageRepository := &mocks.SecretStorageRepository{}
+	storageRepository.On("ListGlobal").Return([]*models.SecretStorage{}, nil)
+	storageRepository.On("List", project.ID).Return([]*models.SecretStorage{}, nil)
+
+	storage := &mocks.ProjectRepository{}
+	storage.On("GetByName", "new-project").
+		Return(nil, apperrors.NewNotFoundErrorf("project with name new-project not found"))
+	storage.On("SaveWith", mock.Anything, repository.ProjectWriteOptions{}).Return(
+		func(p *models.Project, _ repository.ProjectWriteOptions) *models.Project { return p }, nil)
+
+	webhookClient := &webhooks.MockWebhookClient{}
+	webhookClient.On("GetName").Return("webhook1")
+	webhookClient.On("IsFinalResponse").Return(false)
+	webhookClient.On("GetUseDataFrom").Return("")
+	webhookClient.On("Invoke", mock.Anything, mock.MatchedBy(func(payload []byte) bool {
+		var renamed map[string]interface{}
+		return json.Unmarshal(payload, &renamed) == nil &&
+			renamed["name"] == "new-project" && renamed["previous_name"] == "my-project"
+	})).Return([]byte(`{}`), nil)
+	whManager := &webhooks.SimpleWebhookManager{
+		SyncClients: map[webhooks.EventType][]webhooks.WebhookClient{
+			ProjectRenamedEvent: {webhookClient},
+		},
+	}
+
+	projectsService, err := NewProjectsService(
+		MLFlowTrackingURL, storage, storageRepository, nil, nil, &enforcerMock.Enforcer{}, false, whManager,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+
+	_, err = projectsService.RenameProject(context.Background(), project, "new-project")
+	require.NoError(t, err)
+	webhookClient.AssertExpectations(t)
+}
+
 func TestProjectsService_CreateWithWebhook(t *testing.T) {
 
 	tests := []struct {
@@ -537,8 +1132,8 @@ func TestProjectsService_CreateWithWebhook(t *testing.T) {
 			mockClient1.On("IsFinalResponse").Return(true)
 			mockClient1.On("GetUseDataFrom").Return("")
 			storage := &mocks.ProjectRepository{}
-			storage.On("Save", test.arg).Return(test.arg, nil).Once()
-			storage.On("Save", test.expResult).Return(test.expResult, nil).Maybe()
+			storage.On("SaveWith", test.arg, repository.ProjectWriteOptions{}).Return(test.arg, nil).Once()
+			storage.On("SaveWith", test.expResult, repository.ProjectWriteOptions{}).Return(test.expResult, nil).Maybe()
 			mockClient1.On("Invoke", mock.Anything, mock.Anything).Return(test.whResponse, nil)
 			whManager := &webhooks.SimpleWebhookManager{
 				SyncClients: map[webhooks.EventType][]webhooks.WebhookClient{
@@ -548,12 +1143,12 @@ func TestProjectsService_CreateWithWebhook(t *testing.T) {
 				},
 			}
 			mockClient1.On("Invoke", mock.Anything, mock.Anything).Return(test.whResponse, nil)
-			projectsService, err := NewProjectsService(MLFlowTrackingURL, storage, authEnforcer, false, whManager,
+			projectsService, err := NewProjectsService(MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, false, whManager,
 				config.UpdateProjectConfig{
 					Endpoint:         "",
 					PayloadTemplate:  "",
 					ResponseTemplate: "",
-				})
+				}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
 			assert.NoError(t, err)
 			res, err := projectsService.CreateProject(context.Background(), test.arg)
 			if test.wantError {
@@ -664,7 +1259,7 @@ func TestProjectsService_UpdateProjectWithWebhook(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			storage := &mocks.ProjectRepository{}
-			storage.On("Save", tt.expResult).Return(tt.expResult, nil)
+			storage.On("SaveWith", tt.expResult, repository.ProjectWriteOptions{}).Return(tt.expResult, nil)
 			storage.On("Get", tt.existingProject.ID).Return(tt.existingProject, nil)
 
 			authEnforcer := &enforcerMock.Enforcer{}
@@ -673,7 +1268,7 @@ func TestProjectsService_UpdateProjectWithWebhook(t *testing.T) {
 			mockClient1.On("GetName").Return("webhook1")
 			mockClient1.On("IsFinalResponse").Return(true)
 			mockClient1.On("GetUseDataFrom").Return("")
-			storage.On("Save", tt.expResult).Return(tt.expResult, nil).Maybe()
+			storage.On("SaveWith", tt.expResult, repository.ProjectWriteOptions{}).Return(tt.expResult, nil).Maybe()
 			mockClient1.On("Invoke", mock.Anything, mock.Anything).Return(tt.whResponse, nil)
 			whManager := &webhooks.SimpleWebhookManager{
 				SyncClients: map[webhooks.EventType][]webhooks.WebhookClient{
@@ -705,13 +1300,18 @@ func TestProjectsService_UpdateProjectWithWebhook(t *testing.T) {
 			}
 
 			projectsService, err := NewProjectsService(
-				MLFlowTrackingURL, storage, authEnforcer, false, whManager,
+				MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, false, whManager,
 				config.UpdateProjectConfig{
 					Endpoint:         tt.updateProjectEndpoint,
 					PayloadTemplate:  tt.updateProjectPayload,
 					ResponseTemplate: tt.updateProjectResponse,
 					LabelsBlacklist:  tt.labelsBlacklist,
 				},
+				config.ProjectNamingConfig{},
+				nil,
+				nil,
+				nil,
+				nil,
 			)
 			assert.NoError(t, err)
 
@@ -828,7 +1428,7 @@ func TestProjectsService_UpdateProjectWithWebhookEventNotSet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			storage := &mocks.ProjectRepository{}
-			storage.On("Save", tt.expResult).Return(tt.expResult, nil)
+			storage.On("SaveWith", tt.expResult, repository.ProjectWriteOptions{}).Return(tt.expResult, nil)
 			storage.On("Get", tt.existingProject.ID).Return(tt.existingProject, nil)
 
 			authEnforcer := &enforcerMock.Enforcer{}
@@ -846,12 +1446,12 @@ func TestProjectsService_UpdateProjectWithWebhookEventNotSet(t *testing.T) {
 				},
 			}
 			mockClient1.On("Invoke", mock.Anything, mock.Anything).Return(tt.whResponse, nil)
-			projectsService, err := NewProjectsService(MLFlowTrackingURL, storage, authEnforcer, false, whManager,
+			projectsService, err := NewProjectsService(MLFlowTrackingURL, storage, nil, nil, nil, authEnforcer, false, whManager,
 				config.UpdateProjectConfig{
 					Endpoint:         "",
 					PayloadTemplate:  "",
 					ResponseTemplate: "",
-				})
+				}, config.ProjectNamingConfig{}, nil, nil, nil, nil)
 
 			assert.NoError(t, err)
 
@@ -948,3 +1548,92 @@ func Test_processResponseTemplate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, exp, result, "Response should match the expected")
 }
+
+func Test_validateLabels(t *testing.T) {
+	tests := []struct {
+		name           string
+		labels         models.Labels
+		existingLabels models.Labels
+		wantErrorMsg   string
+	}{
+		{
+			name:   "valid labels",
+			labels: models.Labels{{Key: "caraml.dev/team", Value: "dsp"}, {Key: "env", Value: ""}},
+		},
+		{
+			name:         "invalid key",
+			labels:       models.Labels{{Key: "my team", Value: "dsp"}},
+			wantErrorMsg: `label key "my team" must have a name of at most 63 characters`,
+		},
+		{
+			name:         "invalid value",
+			labels:       models.Labels{{Key: "team", Value: "data science"}},
+			wantErrorMsg: `label value "data science" must be at most 63 characters`,
+		},
+		{
+			name:         "duplicated key",
+			labels:       models.Labels{{Key: "team", Value: "dsp"}, {Key: "team", Value: "growth"}},
+			wantErrorMsg: `label key "team" is duplicated`,
+		},
+		{
+			name:           "unchanged invalid label",
+			labels:         models.Labels{{Key: "my team", Value: "dsp"}, {Key: "env", Value: "prod"}},
+			existingLabels: models.Labels{{Key: "my team", Value: "dsp"}},
+		},
+		{
+			name:           "changed invalid label",
+			labels:         models.Labels{{Key: "my team", Value: "growth"}},
+			existingLabels: models.Labels{{Key: "my team", Value: "dsp"}},
+			wantErrorMsg:   `label key "my team" must have a name of at most 63 characters`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLabels(tt.labels, tt.existingLabels)
+			if tt.wantErrorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErrorMsg)
+			assert.ErrorAs(t, err, new(*apperrors.InvalidArgumentError))
+		})
+	}
+}
+
+func TestProjectsService_ValidatesStreamAndTeam(t *testing.T) {
+	validationErr := apperrors.NewValidationError("project does not belong to a known stream and team",
+		apperrors.FieldViolation{Field: "team", Rule: "known_team", Message: "team rsk does not exist in stream credit"})
+	streamsService := &servicemocks.StreamsService{}
+	streamsService.On("ValidateProject", mock.MatchedBy(func(project *models.Project) bool {
+		return project.Team == "rsk"
+	})).Return(validationErr)
+
+	existingProject := &models.Project{ID: 1, Name: "project", Stream: "credit", Team: "legacy-team", Version: 1}
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("Get", models.ID(1)).Return(existingProject, nil)
+	projectRepository.On("SaveWith", mock.Anything, repository.ProjectWriteOptions{}).Return(existingProject, nil)
+
+	projectsService, err := NewProjectsService(MLFlowTrackingURL, projectRepository, nil, nil, nil, nil, false, nil,
+		config.UpdateProjectConfig{}, config.ProjectNamingConfig{}, streamsService, nil, nil, nil)
+	require.NoError(t, err)
+
+	_, err = projectsService.CreateProject(context.Background(),
+		&models.Project{Name: "new-project", Stream: "credit", Team: "rsk"})
+	assert.Equal(t, validationErr, err)
+
+	// a project keeps a team that is not in the catalogue as long as the team is not changed
+	_, _, err = projectsService.UpdateProject(context.Background(), &models.Project{
+		ID:      1,
+		Name:    "project",
+		Stream:  "credit",
+		Team:    "legacy-team",
+		Readers: []string{"reader@example.com"},
+		Version: 1,
+	})
+	require.NoError(t, err)
+
+	_, _, err = projectsService.UpdateProject(context.Background(),
+		&models.Project{ID: 1, Name: "project", Stream: "credit", Team: "rsk", Version: 1})
+	assert.Equal(t, validationErr, err)
+	streamsService.AssertNumberOfCalls(t, "ValidateProject", 2)
+}
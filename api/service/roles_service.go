@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// RolesService manages the custom roles and their assignments in projects. The permissions of a custom role are
+// templates expanded with the ID of each project in which it is assigned, and are granted in Keto to the members of
+// the assignment through the role mlp.projects.<project ID>.roles.<role name>.
+type RolesService interface {
+	// ListRoles returns all custom roles ordered by name
+	ListRoles() ([]*models.Role, error)
+	FindRoleByID(id models.ID) (*models.Role, error)
+	CreateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// UpdateRole updates the role and the permissions granted by its assignments. A role assigned in projects cannot
+	// be renamed, nor restricted to a project in which it is not assigned.
+	UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// DeleteRole deletes the role. A role assigned in projects cannot be deleted.
+	DeleteRole(ctx context.Context, role *models.Role) error
+	// ListRoleAssignments returns the assignments of custom roles in the project
+	ListRoleAssignments(projectID models.ID) ([]*models.RoleAssignment, error)
+	FindRoleAssignment(projectID models.ID, roleID models.ID) (*models.RoleAssignment, error)
+	// AssignRole grants the role to the members in the project, replacing the members of an existing assignment
+	AssignRole(ctx context.Context, project *models.Project, role *models.Role,
+		members []string) (*models.RoleAssignment, error)
+	// UnassignRole revokes the role from all members of the assignment
+	UnassignRole(ctx context.Context, assignment *models.RoleAssignment) error
+}
+
+func NewRolesService(
+	roleRepository repository.RoleRepository,
+	assignmentRepository repository.RoleAssignmentRepository,
+	authEnforcer enforcer.Enforcer,
+	authEnabled bool) RolesService {
+	return &rolesService{
+		roleRepository:       roleRepository,
+		assignmentRepository: assignmentRepository,
+		authEnforcer:         authEnforcer,
+		authEnabled:          authEnabled,
+	}
+}
+
+type rolesService struct {
+	roleRepository       repository.RoleRepository
+	assignmentRepository repository.RoleAssignmentRepository
+	authEnforcer         enforcer.Enforcer
+	authEnabled          bool
+}
+
+func (s *rolesService) ListRoles() ([]*models.Role, error) {
+	return s.roleRepository.List()
+}
+
+func (s *rolesService) FindRoleByID(id models.ID) (*models.Role, error) {
+	return s.roleRepository.Get(id)
+}
+
+func (s *rolesService) CreateRole(_ context.Context, role *models.Role) (*models.Role, error) {
+	if err := validateRolePermissions(role); err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleNameAvailable(role.Name); err != nil {
+		return nil, err
+	}
+
+	// the permissions are only granted in Keto once the role is assigned in a project
+	role, err := s.roleRepository.Save(role)
+	if err != nil {
+		return nil, fmt.Errorf("error creating role %s: %w", role.Name, err)
+	}
+	return role, nil
+}
+
+func (s *rolesService) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if err := validateRolePermissions(role); err != nil {
+		return nil, err
+	}
+	existingRole, err := s.roleRepository.Get(role.ID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.assignmentRepository.ListByRole(role.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.Name != existingRole.Name {
+		if len(assignments) > 0 {
+			return nil, apperrors.NewInvalidArgumentErrorf("role %s is assigned in %d projects and cannot be renamed",
+				existingRole.Name, len(assignments))
+		}
+		if err := s.checkRoleNameAvailable(role.Name); err != nil {
+			return nil, err
+		}
+	}
+	for _, assignment := range assignments {
+		if !role.IsAssignableIn(assignment.ProjectID) {
+			return nil, apperrors.NewInvalidArgumentErrorf(
+				"role %s is assigned in project with ID %d and cannot be restricted to another project",
+				existingRole.Name, assignment.ProjectID)
+		}
+	}
+
+	role, err = s.roleRepository.Save(role)
+	if err != nil {
+		return nil, fmt.Errorf("error updating role %s: %w", existingRole.Name, err)
+	}
+	if err := s.updateRolePermissions(ctx, existingRole, role, assignments); err != nil {
+		return nil, fmt.Errorf("error while updating authorization policy for role %s: %w", role.Name, err)
+	}
+	return role, nil
+}
+
+func (s *rolesService) DeleteRole(_ context.Context, role *models.Role) error {
+	assignments, err := s.assignmentRepository.ListByRole(role.ID)
+	if err != nil {
+		return err
+	}
+	if len(assignments) > 0 {
+		return apperrors.NewInvalidArgumentErrorf("role %s is assigned in %d projects and cannot be deleted",
+			role.Name, len(assignments))
+	}
+
+	if err := s.roleRepository.Delete(role.ID); err != nil {
+		return fmt.Errorf("error deleting role %s: %w", role.Name, err)
+	}
+	return nil
+}
+
+func (s *rolesService) ListRoleAssignments(projectID models.ID) ([]*models.RoleAssignment, error) {
+	return s.assignmentRepository.ListByProject(projectID)
+}
+
+func (s *rolesService) FindRoleAssignment(projectID models.ID, roleID models.ID) (*models.RoleAssignment, error) {
+	return s.assignmentRepository.Get(roleID, projectID)
+}
+
+func (s *rolesService) AssignRole(ctx context.Context, project *models.Project, role *models.Role,
+	members []string) (*models.RoleAssignment, error) {
+	if !role.IsAssignableIn(project.ID) {
+		return nil, apperrors.NewInvalidArgumentErrorf("role %s cannot be assigned in project %s", role.Name,
+			project.Name)
+	}
+
+	assignment, err := s.assignmentRepository.Get(role.ID, project.ID)
+	if errors.Is(err, &apperrors.NotFoundError{}) {
+		assignment = &models.RoleAssignment{RoleID: role.ID, ProjectID: project.ID}
+	} else if err != nil {
+		return nil, err
+	}
+	assignment.Members = members
+
+	assignment, err = s.assignmentRepository.Save(assignment)
+	if err != nil {
+		return nil, fmt.Errorf("error assigning role %s in project %s: %w", role.Name, project.Name, err)
+	}
+	assignment.Role = role
+	if err := s.updateAuthorizationPolicy(ctx, role, assignment); err != nil {
+		return nil, fmt.Errorf("error while updating authorization policy for role %s in project %s: %w",
+			role.Name, project.Name, err)
+	}
+	return assignment, nil
+}
+
+func (s *rolesService) UnassignRole(ctx context.Context, assignment *models.RoleAssignment) error {
+	role, err := s.roleRepository.Get(assignment.RoleID)
+	if err != nil {
+		return err
+	}
+	if err := s.assignmentRepository.Delete(assignment.ID); err != nil {
+		return fmt.Errorf("error unassigning role %s: %w", role.Name, err)
+	}
+	if err := s.removeAuthorizationPolicy(ctx, role, assignment); err != nil {
+		return fmt.Errorf("error while removing authorization policy of role %s: %w", role.Name, err)
+	}
+	return nil
+}
+
+func (s *rolesService) checkRoleNameAvailable(name string) error {
+	_, err := s.roleRepository.GetByName(name)
+	if err == nil {
+		return apperrors.NewAlreadyExistsErrorf("role %s already exists", name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return err
+	}
+	return nil
+}
+
+// updateAuthorizationPolicy grants the permissions of the role in the project of the assignment to its members
+func (s *rolesService) updateAuthorizationPolicy(ctx context.Context, role *models.Role,
+	assignment *models.RoleAssignment) error {
+	if !s.authEnabled {
+		return nil
+	}
+	updateRequest, err := roleAssignmentAuthorizationPolicy(role, assignment)
+	if err != nil {
+		return err
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// roleAssignmentAuthorizationPolicy returns the update request granting the permissions of the role in the project of
+// the assignment to its members
+func roleAssignmentAuthorizationPolicy(role *models.Role,
+	assignment *models.RoleAssignment) (enforcer.AuthorizationUpdateRequest, error) {
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	ketoRole, permissions, err := expandRole(role, assignment.ProjectID)
+	if err != nil {
+		return updateRequest, err
+	}
+	updateRequest.AddRolePermissions(ketoRole, permissions)
+	updateRequest.SetRoleMembers(ketoRole, assignment.Members)
+	return updateRequest, nil
+}
+
+// removeAuthorizationPolicy reverts the changes made by updateAuthorizationPolicy
+func (s *rolesService) removeAuthorizationPolicy(ctx context.Context, role *models.Role,
+	assignment *models.RoleAssignment) error {
+	if !s.authEnabled {
+		return nil
+	}
+	ketoRole, permissions, err := expandRole(role, assignment.ProjectID)
+	if err != nil {
+		return err
+	}
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	updateRequest.RemoveRolePermissions(ketoRole, permissions)
+	updateRequest.SetRoleMembers(ketoRole, []string{})
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// updateRolePermissions replaces the permissions granted by the assignments of the role when its permission templates
+// change. The members of the assignments are left unchanged.
+func (s *rolesService) updateRolePermissions(ctx context.Context, before *models.Role, role *models.Role,
+	assignments []*models.RoleAssignment) error {
+	if !s.authEnabled || len(assignments) == 0 || slices.Equal(before.Permissions, role.Permissions) {
+		return nil
+	}
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	for _, assignment := range assignments {
+		ketoRole, oldPermissions, err := expandRole(before, assignment.ProjectID)
+		if err != nil {
+			return err
+		}
+		_, permissions, err := expandRole(role, assignment.ProjectID)
+		if err != nil {
+			return err
+		}
+		removedPermissions := make([]string, 0)
+		for _, permission := range oldPermissions {
+			if !slices.Contains(permissions, permission) {
+				removedPermissions = append(removedPermissions, permission)
+			}
+		}
+		updateRequest.RemoveRolePermissions(ketoRole, removedPermissions)
+		updateRequest.AddRolePermissions(ketoRole, permissions)
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// expandRole returns the Keto role granted to the members of the role in the project, and its permissions
+func expandRole(role *models.Role, projectID models.ID) (string, []string, error) {
+	ketoRole, err := enforcer.ParseRole(enforcer.MLPProjectCustomRole, map[string]string{
+		"ProjectId": projectID.String(),
+		"RoleName":  role.Name,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, permissionTemplate := range role.Permissions {
+		permission, err := enforcer.ParseRole(permissionTemplate, map[string]string{"ProjectId": projectID.String()})
+		if err != nil {
+			return "", nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return ketoRole, permissions, nil
+}
+
+// rolePermissionTemplate matches the permission templates that can be granted by a custom role. The templates must
+// start with the literal prefix mlp.projects.{{ .ProjectId }}. followed by a fixed suffix, so that they cannot
+// contain any other template actions.
+var rolePermissionTemplate = regexp.MustCompile(`^mlp\.projects\.\{\{ \.ProjectId \}\}\.[a-z0-9._*-]+$`)
+
+// validateRolePermissions checks that the permission templates of the role only grant permissions on the project in
+// which the role is assigned, so that assigning a role never grants access to other projects
+func validateRolePermissions(role *models.Role) error {
+	violations := make([]apperrors.FieldViolation, 0)
+	for _, permissionTemplate := range role.Permissions {
+		if !rolePermissionTemplate.MatchString(permissionTemplate) {
+			violations = append(violations, apperrors.FieldViolation{
+				Field: "permissions",
+				Rule:  "project_permission",
+				Message: fmt.Sprintf("permission %s must be a permission on the project of the role, "+
+					"e.g. mlp.projects.{{ .ProjectId }}.get", permissionTemplate),
+			})
+		}
+	}
+	if len(violations) > 0 {
+		return apperrors.NewValidationError("role grants invalid permissions", violations...)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestRolesService_CreateRole(t *testing.T) {
+	roleRepository := &mocks.RoleRepository{}
+	roleRepository.On("GetByName", "deployer").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	roleRepository.On("GetByName", "reader").Return(&models.Role{ID: 1, Name: "reader"}, nil)
+	roleRepository.On("Save", mock.Anything).Return(func(role *models.Role) (*models.Role, error) {
+		role.ID = 2
+		return role, nil
+	})
+	s := NewRolesService(roleRepository, &mocks.RoleAssignmentRepository{}, nil, false)
+
+	role, err := s.CreateRole(context.Background(), &models.Role{
+		Name:        "deployer",
+		Permissions: []string{"mlp.projects.{{ .ProjectId }}.get", "mlp.projects.{{ .ProjectId }}.deployments.post"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(2), role.ID)
+
+	_, err = s.CreateRole(context.Background(), &models.Role{
+		Name:        "reader",
+		Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"},
+	})
+	assert.EqualError(t, err, "role reader already exists")
+
+	_, err = s.CreateRole(context.Background(), &models.Role{
+		Name: "deployer",
+		Permissions: []string{
+			"mlp.projects.{{ .ProjectId }}.get",
+			"mlp.projects.post",
+			"mlp.projects.1.get",
+			"mlp.projects.{{ .ProjectId }}",
+			"mlp.projects.{{ .ProjectId }}.{{ .Unknown }}",
+			"mlp.projects.{{ .ProjectId",
+			"mlp.projects.{{ .ProjectId }}.{{ if true }}get{{ end }}",
+			"mlp.projects.{{ .ProjectId }}.{{ printf \"%s\" \"get\" }}",
+			"mlp.projects.{{ .ProjectId }}.Secrets.get",
+			"{{ \"mlp.projects.\" }}{{ .ProjectId }}.get",
+		},
+	})
+	var validationErr *apperrors.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Violations, 9)
+	assert.Equal(t, "project_permission", validationErr.Violations[0].Rule)
+}
+
+func TestRolesService_UpdateRole(t *testing.T) {
+	projectID := models.ID(5)
+	existingRole := &models.Role{ID: 2, Name: "deployer", Permissions: []string{
+		"mlp.projects.{{ .ProjectId }}.get",
+		"mlp.projects.{{ .ProjectId }}.deployments.post",
+	}}
+	assignments := []*models.RoleAssignment{{ID: 1, RoleID: 2, ProjectID: 3, Members: []string{"a@example.com"}}}
+	tests := map[string]struct {
+		role           *models.Role
+		expectedUpdate *enforcer.AuthorizationUpdateRequest
+		expectedErr    string
+	}{
+		"update permissions": {
+			role: &models.Role{ID: 2, Name: "deployer", Permissions: []string{
+				"mlp.projects.{{ .ProjectId }}.get",
+				"mlp.projects.{{ .ProjectId }}.deployments.put",
+			}},
+			expectedUpdate: &enforcer.AuthorizationUpdateRequest{
+				RolePermissions: map[string][]string{
+					"mlp.projects.3.roles.deployer": {"mlp.projects.3.get", "mlp.projects.3.deployments.put"},
+				},
+				RoleMembers: map[string][]string{},
+				RemovedRolePermissions: map[string][]string{
+					"mlp.projects.3.roles.deployer": {"mlp.projects.3.deployments.post"},
+				},
+				GroupMembers:        map[string][]string{},
+				RemovedRoleMembers:  map[string][]string{},
+				RemovedGroupMembers: map[string][]string{},
+			},
+		},
+		"rename assigned role": {
+			role:        &models.Role{ID: 2, Name: "releaser", Permissions: existingRole.Permissions},
+			expectedErr: "role deployer is assigned in 1 projects and cannot be renamed",
+		},
+		"restrict role to another project": {
+			role:        &models.Role{ID: 2, Name: "deployer", Permissions: existingRole.Permissions, ProjectID: &projectID},
+			expectedErr: "role deployer is assigned in project with ID 3 and cannot be restricted to another project",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			roleRepository := &mocks.RoleRepository{}
+			roleRepository.On("Get", models.ID(2)).Return(existingRole, nil)
+			roleRepository.On("Save", tt.role).Return(tt.role, nil)
+			assignmentRepository := &mocks.RoleAssignmentRepository{}
+			assignmentRepository.On("ListByRole", models.ID(2)).Return(assignments, nil)
+			authEnforcer := &enforcerMock.Enforcer{}
+			if tt.expectedUpdate != nil {
+				authEnforcer.On("UpdateAuthorization", mock.Anything, *tt.expectedUpdate).Return(nil)
+			}
+
+			s := NewRolesService(roleRepository, assignmentRepository, authEnforcer, true)
+			role, err := s.UpdateRole(context.Background(), tt.role)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.role, role)
+			authEnforcer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRolesService_DeleteRole(t *testing.T) {
+	assignmentRepository := &mocks.RoleAssignmentRepository{}
+	assignmentRepository.On("ListByRole", models.ID(1)).Return([]*models.RoleAssignment{}, nil)
+	assignmentRepository.On("ListByRole", models.ID(2)).Return([]*models.RoleAssignment{{ID: 1}}, nil)
+	roleRepository := &mocks.RoleRepository{}
+	roleRepository.On("Delete", models.ID(1)).Return(nil)
+	s := NewRolesService(roleRepository, assignmentRepository, nil, false)
+
+	assert.NoError(t, s.DeleteRole(context.Background(), &models.Role{ID: 1, Name: "unused"}))
+	assert.EqualError(t, s.DeleteRole(context.Background(), &models.Role{ID: 2, Name: "deployer"}),
+		"role deployer is assigned in 1 projects and cannot be deleted")
+	roleRepository.AssertNumberOfCalls(t, "Delete", 1)
+}
+
+func TestRolesService_AssignRole(t *testing.T) {
+	project := &models.Project{ID: 3, Name: "my-project"}
+	otherProjectID := models.ID(4)
+	role := &models.Role{ID: 2, Name: "deployer", Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"}}
+
+	assignmentRepository := &mocks.RoleAssignmentRepository{}
+	assignmentRepository.On("Get", models.ID(2), models.ID(3)).Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	assignmentRepository.On("Save", mock.Anything).Return(func(assignment *models.RoleAssignment) (
+		*models.RoleAssignment, error) {
+		assignment.ID = 7
+		return assignment, nil
+	})
+	expectedUpdate := enforcer.NewAuthorizationUpdateRequest()
+	expectedUpdate.AddRolePermissions("mlp.projects.3.roles.deployer", []string{"mlp.projects.3.get"})
+	expectedUpdate.SetRoleMembers("mlp.projects.3.roles.deployer", []string{"a@example.com", "group:credit-risk"})
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, expectedUpdate).Return(nil)
+	s := NewRolesService(&mocks.RoleRepository{}, assignmentRepository, authEnforcer, true)
+
+	assignment, err := s.AssignRole(context.Background(), project, role,
+		[]string{"a@example.com", "group:credit-risk"})
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(7), assignment.ID)
+	assert.Equal(t, project.ID, assignment.ProjectID)
+	assert.Equal(t, role, assignment.Role)
+	authEnforcer.AssertExpectations(t)
+
+	_, err = s.AssignRole(context.Background(), project,
+		&models.Role{ID: 3, Name: "other-deployer", ProjectID: &otherProjectID}, []string{"a@example.com"})
+	assert.EqualError(t, err, "role other-deployer cannot be assigned in project my-project")
+}
+
+func TestRolesService_UnassignRole(t *testing.T) {
+	role := &models.Role{ID: 2, Name: "deployer", Permissions: []string{"mlp.projects.{{ .ProjectId }}.get"}}
+	roleRepository := &mocks.RoleRepository{}
+	roleRepository.On("Get", models.ID(2)).Return(role, nil)
+	assignmentRepository := &mocks.RoleAssignmentRepository{}
+	assignmentRepository.On("Delete", models.ID(7)).Return(nil)
+	expectedUpdate := enforcer.NewAuthorizationUpdateRequest()
+	expectedUpdate.RemoveRolePermissions("mlp.projects.3.roles.deployer", []string{"mlp.projects.3.get"})
+	expectedUpdate.SetRoleMembers("mlp.projects.3.roles.deployer", []string{})
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, expectedUpdate).Return(nil)
+	s := NewRolesService(roleRepository, assignmentRepository, authEnforcer, true)
+
+	err := s.UnassignRole(context.Background(), &models.RoleAssignment{ID: 7, RoleID: 2, ProjectID: 3,
+		Members: []string{"a@example.com"}})
+	require.NoError(t, err)
+	authEnforcer.AssertExpectations(t)
+}
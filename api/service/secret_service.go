@@ -1,12 +1,14 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	apperror "github.com/caraml-dev/mlp/api/pkg/errors"
 
 	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
 	"github.com/caraml-dev/mlp/api/pkg/secretstorage"
 	"github.com/caraml-dev/mlp/api/repository"
 	"github.com/caraml-dev/mlp/api/util"
@@ -24,6 +26,10 @@ type SecretService interface {
 	List(projectID models.ID) ([]*models.Secret, error)
 	// Delete deletes a secret given its secretID
 	Delete(secretID models.ID) error
+	// IsReadable returns true if the user is allowed to read the value of the secret
+	IsReadable(ctx context.Context, user string, secret *models.Secret) (bool, error)
+	// RedactUnreadable removes the values of the secrets that the user is not allowed to read
+	RedactUnreadable(ctx context.Context, user string, secrets []*models.Secret) error
 }
 
 func NewSecretService(secretRepository repository.SecretRepository,
@@ -31,6 +37,8 @@ func NewSecretService(secretRepository repository.SecretRepository,
 	projectRepository repository.ProjectRepository,
 	storageClientRegistry *secretstorage.Registry,
 	defaultSecretStorage *models.SecretStorage,
+	authEnforcer enforcer.Enforcer,
+	authEnabled bool,
 ) SecretService {
 	return &secretService{
 		secretRepository:  secretRepository,
@@ -39,6 +47,9 @@ func NewSecretService(secretRepository repository.SecretRepository,
 
 		storageClientRegistry: storageClientRegistry,
 		defaultSecretStorage:  defaultSecretStorage,
+
+		authEnforcer: authEnforcer,
+		authEnabled:  authEnabled,
 	}
 }
 
@@ -49,6 +60,9 @@ type secretService struct {
 
 	storageClientRegistry *secretstorage.Registry
 	defaultSecretStorage  *models.SecretStorage
+
+	authEnforcer enforcer.Enforcer
+	authEnabled  bool
 }
 
 func (ss *secretService) FindByID(secretID models.ID) (*models.Secret, error) {
@@ -240,6 +254,60 @@ func (ss *secretService) Delete(secretID models.ID) error {
 	return nil
 }
 
+// IsReadable returns true if the user can read all secrets of the project of the secret, e.g. as an administrator or
+// a secret reader of the project, or has been granted the permission to read this secret only
+func (ss *secretService) IsReadable(ctx context.Context, user string, secret *models.Secret) (bool, error) {
+	if !ss.authEnabled {
+		return true, nil
+	}
+	allowed, err := ss.authEnforcer.IsUserGrantedPermission(ctx, user, secretsReadPermission(secret.ProjectID))
+	if err != nil || allowed {
+		return allowed, err
+	}
+	return ss.authEnforcer.IsUserGrantedPermission(ctx, user, secretReadPermission(secret))
+}
+
+func (ss *secretService) RedactUnreadable(ctx context.Context, user string, secrets []*models.Secret) error {
+	if !ss.authEnabled {
+		return nil
+	}
+	// the permission to read all secrets is checked once per project, most users either have it or not
+	readableProjects := make(map[models.ID]bool)
+	for _, secret := range secrets {
+		readAll, ok := readableProjects[secret.ProjectID]
+		if !ok {
+			var err error
+			readAll, err = ss.authEnforcer.IsUserGrantedPermission(ctx, user, secretsReadPermission(secret.ProjectID))
+			if err != nil {
+				return err
+			}
+			readableProjects[secret.ProjectID] = readAll
+		}
+		if readAll {
+			continue
+		}
+		readable, err := ss.authEnforcer.IsUserGrantedPermission(ctx, user, secretReadPermission(secret))
+		if err != nil {
+			return err
+		}
+		if !readable {
+			secret.Data = ""
+			secret.Redacted = true
+		}
+	}
+	return nil
+}
+
+// secretsReadPermission is the permission to read the values of all secrets of a project
+func secretsReadPermission(projectID models.ID) string {
+	return fmt.Sprintf("mlp.projects.%d.secrets.read", projectID)
+}
+
+// secretReadPermission is the permission to read the value of a single secret, identified by its name
+func secretReadPermission(secret *models.Secret) string {
+	return fmt.Sprintf("mlp.projects.%d.secrets.%s.read", secret.ProjectID, secret.Name)
+}
+
 // migrateSecret migrate secret from one secret storage to another
 func (ss *secretService) migrateSecret(oldSecret *models.Secret, newSecret *models.Secret) (*models.Secret, error) {
 	newSecretStorage, err := ss.storageRepository.Get(*newSecret.SecretStorageID)
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/caraml-dev/mlp/api/models"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
 	"github.com/caraml-dev/mlp/api/pkg/secretstorage"
 	ssmocks "github.com/caraml-dev/mlp/api/pkg/secretstorage/mocks"
 	"github.com/caraml-dev/mlp/api/repository/mocks"
@@ -117,7 +121,9 @@ func TestSecretService_FindByID(t *testing.T) {
 				storageRepository,
 				projectRepository,
 				ssClientRegistry,
-				vaultSecretStorage)
+				vaultSecretStorage,
+				nil,
+				false)
 			result, err := secretService.FindByID(tt.args.secretID)
 			if tt.expectedError != "" {
 				assert.EqualError(t, err, tt.expectedError)
@@ -258,7 +264,9 @@ func TestSecretService_Create(t *testing.T) {
 				storageRepository,
 				projectRepository,
 				ssClientRegistry,
-				vaultSecretStorage)
+				vaultSecretStorage,
+				nil,
+				false)
 			result, err := secretService.Create(tt.secret)
 			if tt.expectedError != "" {
 				assert.EqualError(t, err, tt.expectedError)
@@ -353,7 +361,8 @@ func TestSecretService_Delete(t *testing.T) {
 			ssClientRegistry.Set(internalSecretStorage.ID, ssClient)
 			ssClientRegistry.Set(vaultSecretStorage.ID, ssClient)
 
-			secretService := NewSecretService(secretRepository, nil, nil, ssClientRegistry, vaultSecretStorage)
+			secretService := NewSecretService(secretRepository, nil, nil, ssClientRegistry, vaultSecretStorage, nil,
+				false)
 
 			err = secretService.Delete(tt.secretID)
 			if tt.expectedError == "" {
@@ -439,7 +448,9 @@ func TestSecretService_List(t *testing.T) {
 		storageRepository,
 		projectRepository,
 		ssClientRegistry,
-		vaultSecretStorage)
+		vaultSecretStorage,
+		nil,
+		false)
 	actual, err := secretService.List(project.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, secrets, actual)
@@ -582,7 +593,9 @@ func TestSecretService_Update(t *testing.T) {
 				storageRepository,
 				projectRepository,
 				ssClientRegistry,
-				vaultSecretStorage)
+				vaultSecretStorage,
+				nil,
+				false)
 			got, err := secretService.Update(tt.args.secret)
 			if tt.expectedError != "" {
 				assert.EqualError(t, err, tt.expectedError)
@@ -592,3 +605,104 @@ func TestSecretService_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretService_IsReadable(t *testing.T) {
+	secret := &models.Secret{ID: 1, ProjectID: 1, Name: "db-password", Data: "password"}
+	tests := []struct {
+		name           string
+		authEnabled    bool
+		enforcerResult func(e *enforcerMock.Enforcer)
+		expected       bool
+		expectedErr    string
+	}{
+		{
+			name:        "auth disabled",
+			authEnabled: false,
+			expected:    true,
+		},
+		{
+			name:        "secret reader of the project",
+			authEnabled: true,
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@email.com", "mlp.projects.1.secrets.read").
+					Return(true, nil)
+			},
+			expected: true,
+		},
+		{
+			name:        "reader of the secret",
+			authEnabled: true,
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@email.com", "mlp.projects.1.secrets.read").
+					Return(false, nil)
+				e.On("IsUserGrantedPermission", mock.Anything, "user@email.com",
+					"mlp.projects.1.secrets.db-password.read").Return(true, nil)
+			},
+			expected: true,
+		},
+		{
+			name:        "reader of the project",
+			authEnabled: true,
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@email.com", mock.Anything).Return(false, nil)
+			},
+			expected: false,
+		},
+		{
+			name:        "enforcer error",
+			authEnabled: true,
+			enforcerResult: func(e *enforcerMock.Enforcer) {
+				e.On("IsUserGrantedPermission", mock.Anything, "user@email.com", "mlp.projects.1.secrets.read").
+					Return(false, errors.New("keto is unavailable"))
+			},
+			expectedErr: "keto is unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authEnforcer := &enforcerMock.Enforcer{}
+			if tt.enforcerResult != nil {
+				tt.enforcerResult(authEnforcer)
+			}
+			secretService := NewSecretService(nil, nil, nil, nil, nil, authEnforcer, tt.authEnabled)
+
+			readable, err := secretService.IsReadable(context.Background(), "user@email.com", secret)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, readable)
+			authEnforcer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSecretService_RedactUnreadable(t *testing.T) {
+	secrets := []*models.Secret{
+		{ID: 1, ProjectID: 1, Name: "db-password", Data: "password"},
+		{ID: 2, ProjectID: 1, Name: "api-key", Data: "key"},
+		{ID: 3, ProjectID: 2, Name: "db-password", Data: "other-password"},
+	}
+
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("IsUserGrantedPermission", mock.Anything, "user@email.com", "mlp.projects.1.secrets.read").
+		Return(false, nil).Once()
+	authEnforcer.On("IsUserGrantedPermission", mock.Anything, "user@email.com",
+		"mlp.projects.1.secrets.db-password.read").Return(false, nil)
+	authEnforcer.On("IsUserGrantedPermission", mock.Anything, "user@email.com",
+		"mlp.projects.1.secrets.api-key.read").Return(true, nil)
+	authEnforcer.On("IsUserGrantedPermission", mock.Anything, "user@email.com", "mlp.projects.2.secrets.read").
+		Return(true, nil).Once()
+	secretService := NewSecretService(nil, nil, nil, nil, nil, authEnforcer, true)
+
+	err := secretService.RedactUnreadable(context.Background(), "user@email.com", secrets)
+	require.NoError(t, err)
+	assert.Equal(t, "", secrets[0].Data)
+	assert.True(t, secrets[0].Redacted)
+	assert.Equal(t, "key", secrets[1].Data)
+	assert.False(t, secrets[1].Redacted)
+	assert.Equal(t, "other-password", secrets[2].Data)
+	assert.False(t, secrets[2].Redacted)
+	authEnforcer.AssertExpectations(t)
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/pagination"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// defaultTokenLifetime is the lifetime of the tokens created without an expiry
+const defaultTokenLifetime = 90 * 24 * time.Hour
+
+// ServiceAccountsService manages the service accounts of projects and their tokens. A service account is granted a
+// role by adding its subject, e.g. serviceaccount:12, to the administrators or the readers of a project.
+type ServiceAccountsService interface {
+	// ListServiceAccounts returns the service accounts of the project ordered by name
+	ListServiceAccounts(projectID models.ID) ([]*models.ServiceAccount, error)
+	FindServiceAccountByID(projectID models.ID, id models.ID) (*models.ServiceAccount, error)
+	CreateServiceAccount(ctx context.Context, project *models.Project,
+		serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error)
+	// DeleteServiceAccount deletes the service account together with its tokens. A service account granted a role in
+	// any project, including the archived projects and the custom roles, cannot be deleted.
+	DeleteServiceAccount(ctx context.Context, serviceAccount *models.ServiceAccount) error
+	// ListTokens returns the tokens of the service account, most recent first, without the tokens themselves
+	ListTokens(serviceAccount *models.ServiceAccount) ([]*models.ServiceAccountToken, error)
+	FindTokenByID(serviceAccount *models.ServiceAccount, id models.ID) (*models.ServiceAccountToken, error)
+	// CreateToken generates a new token for the service account. The returned token is the only time the bearer
+	// token is available, only its hash is stored.
+	CreateToken(ctx context.Context, serviceAccount *models.ServiceAccount,
+		token *models.ServiceAccountToken) (*models.ServiceAccountToken, error)
+	RevokeToken(ctx context.Context, token *models.ServiceAccountToken) (*models.ServiceAccountToken, error)
+	// AuthenticateToken returns the subject of the service account of the bearer token, or an unauthenticated error
+	// if the token is unknown, expired or revoked
+	AuthenticateToken(token string) (string, error)
+	// ValidateProject checks that the service accounts granted access to the project exist and belong to the project
+	ValidateProject(project *models.Project) error
+}
+
+func NewServiceAccountsService(
+	serviceAccountRepository repository.ServiceAccountRepository,
+	tokenRepository repository.ServiceAccountTokenRepository,
+	projectRepository repository.ProjectRepository,
+	assignmentRepository repository.RoleAssignmentRepository) ServiceAccountsService {
+	return &serviceAccountsService{
+		serviceAccountRepository: serviceAccountRepository,
+		tokenRepository:          tokenRepository,
+		projectRepository:        projectRepository,
+		assignmentRepository:     assignmentRepository,
+		now:                      time.Now,
+	}
+}
+
+type serviceAccountsService struct {
+	serviceAccountRepository repository.ServiceAccountRepository
+	tokenRepository          repository.ServiceAccountTokenRepository
+	projectRepository        repository.ProjectRepository
+	assignmentRepository     repository.RoleAssignmentRepository
+	now                      func() time.Time
+}
+
+func (s *serviceAccountsService) ListServiceAccounts(projectID models.ID) ([]*models.ServiceAccount, error) {
+	return s.serviceAccountRepository.List(projectID)
+}
+
+func (s *serviceAccountsService) FindServiceAccountByID(projectID models.ID,
+	id models.ID) (*models.ServiceAccount, error) {
+	serviceAccount, err := s.serviceAccountRepository.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if serviceAccount.ProjectID != projectID {
+		return nil, apperrors.NewNotFoundErrorf("service account with ID %d not found", id)
+	}
+	return serviceAccount, nil
+}
+
+func (s *serviceAccountsService) CreateServiceAccount(ctx context.Context, project *models.Project,
+	serviceAccount *models.ServiceAccount) (*models.ServiceAccount, error) {
+	_, err := s.serviceAccountRepository.GetByName(project.ID, serviceAccount.Name)
+	if err == nil {
+		return nil, apperrors.NewAlreadyExistsErrorf("service account %s already exists in project %s",
+			serviceAccount.Name, project.Name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return nil, err
+	}
+
+	serviceAccount.ProjectID = project.ID
+	serviceAccount.CreatedBy = requestctx.Actor(ctx)
+	serviceAccount, err = s.serviceAccountRepository.Save(serviceAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error creating service account %s: %w", serviceAccount.Name, err)
+	}
+	return serviceAccount, nil
+}
+
+func (s *serviceAccountsService) DeleteServiceAccount(_ context.Context, serviceAccount *models.ServiceAccount) error {
+	page, pageSize := int32(1), int32(1)
+	_, count, err := s.projectRepository.ListProjects(repository.ProjectFilter{
+		Subject:         serviceAccount.Subject,
+		IncludeArchived: true,
+		Options:         pagination.Options{Page: &page, PageSize: &pageSize},
+	})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewInvalidArgumentErrorf(
+			"service account %s is granted access to %d projects and cannot be deleted", serviceAccount.Name, count)
+	}
+	assignments, err := s.assignmentRepository.ListByMember(serviceAccount.Subject)
+	if err != nil {
+		return err
+	}
+	if len(assignments) > 0 {
+		return apperrors.NewInvalidArgumentErrorf(
+			"service account %s is assigned %d custom roles and cannot be deleted", serviceAccount.Name,
+			len(assignments))
+	}
+
+	if err := s.serviceAccountRepository.Delete(serviceAccount.ID); err != nil {
+		return fmt.Errorf("error deleting service account %s: %w", serviceAccount.Name, err)
+	}
+	return nil
+}
+
+func (s *serviceAccountsService) ListTokens(
+	serviceAccount *models.ServiceAccount) ([]*models.ServiceAccountToken, error) {
+	return s.tokenRepository.List(serviceAccount.ID)
+}
+
+func (s *serviceAccountsService) FindTokenByID(serviceAccount *models.ServiceAccount,
+	id models.ID) (*models.ServiceAccountToken, error) {
+	return s.tokenRepository.Get(serviceAccount.ID, id)
+}
+
+func (s *serviceAccountsService) CreateToken(ctx context.Context, serviceAccount *models.ServiceAccount,
+	token *models.ServiceAccountToken) (*models.ServiceAccountToken, error) {
+	now := s.now()
+	if token.ExpiresAt == nil {
+		expiresAt := now.Add(defaultTokenLifetime)
+		token.ExpiresAt = &expiresAt
+	} else if !token.ExpiresAt.After(now) {
+		return nil, apperrors.NewInvalidArgumentErrorf("expiry of the token must be in the future")
+	}
+
+	bearerToken, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating token: %w", err)
+	}
+	token.ServiceAccountID = serviceAccount.ID
+	token.TokenHash = hashToken(bearerToken)
+	token.RevokedAt = nil
+	token.CreatedBy = requestctx.Actor(ctx)
+
+	token, err = s.tokenRepository.Save(token)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token for service account %s: %w", serviceAccount.Name, err)
+	}
+	token.Token = bearerToken
+	return token, nil
+}
+
+func (s *serviceAccountsService) RevokeToken(_ context.Context,
+	token *models.ServiceAccountToken) (*models.ServiceAccountToken, error) {
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+	revokedAt := s.now()
+	token.RevokedAt = &revokedAt
+	return s.tokenRepository.Save(token)
+}
+
+func (s *serviceAccountsService) AuthenticateToken(bearerToken string) (string, error) {
+	if !strings.HasPrefix(bearerToken, models.ServiceAccountTokenPrefix) {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid service account token")
+	}
+	token, err := s.tokenRepository.GetByHash(hashToken(bearerToken))
+	if errors.Is(err, &apperrors.NotFoundError{}) {
+		return "", apperrors.NewUnauthenticatedErrorf("invalid service account token")
+	}
+	if err != nil {
+		return "", err
+	}
+	if !token.IsActive(s.now()) {
+		return "", apperrors.NewUnauthenticatedErrorf("service account token has expired or has been revoked")
+	}
+	return models.ServiceAccountSubject(token.ServiceAccountID), nil
+}
+
+func (s *serviceAccountsService) ValidateProject(project *models.Project) error {
+	violations := make([]apperrors.FieldViolation, 0)
+	for _, subjects := range []struct {
+		field    string
+		subjects []string
+	}{
+		{"administrators", project.Administrators},
+		{"readers", project.Readers},
+		{"secret_readers", project.SecretReaders},
+	} {
+		for _, subject := range subjects.subjects {
+			id, ok := models.ParseServiceAccountSubject(subject)
+			if !ok {
+				continue
+			}
+			serviceAccount, err := s.serviceAccountRepository.Get(id)
+			if errors.Is(err, &apperrors.NotFoundError{}) {
+				violations = append(violations, apperrors.FieldViolation{
+					Field:   subjects.field,
+					Rule:    "known_service_account",
+					Message: fmt.Sprintf("service account %d does not exist", id),
+				})
+			} else if err != nil {
+				return err
+			} else if serviceAccount.ProjectID != project.ID {
+				violations = append(violations, apperrors.FieldViolation{
+					Field:   subjects.field,
+					Rule:    "project_service_account",
+					Message: fmt.Sprintf("service account %d does not belong to the project", id),
+				})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return apperrors.NewValidationError("project is granted to unknown service accounts", violations...)
+	}
+	return nil
+}
+
+// generateToken returns a new random bearer token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return models.ServiceAccountTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hash of the bearer token that is stored instead of the token
+func hashToken(bearerToken string) string {
+	hash := sha256.Sum256([]byte(bearerToken))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/models"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/pkg/requestctx"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestServiceAccountsService_CreateToken(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+	expired := now.Add(-time.Hour)
+	tests := map[string]struct {
+		token             *models.ServiceAccountToken
+		expectedExpiresAt time.Time
+		expectedErr       string
+	}{
+		"default expiry": {
+			token:             &models.ServiceAccountToken{Name: "ci"},
+			expectedExpiresAt: now.Add(defaultTokenLifetime),
+		},
+		"given expiry": {
+			token:             &models.ServiceAccountToken{Name: "ci", ExpiresAt: &expiresAt},
+			expectedExpiresAt: expiresAt,
+		},
+		"expiry in the past": {
+			token:       &models.ServiceAccountToken{Name: "ci", ExpiresAt: &expired},
+			expectedErr: "expiry of the token must be in the future",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tokenRepository := &mocks.ServiceAccountTokenRepository{}
+			tokenRepository.On("Save", mock.Anything).Return(
+				func(token *models.ServiceAccountToken) *models.ServiceAccountToken { return token }, nil)
+
+			s := NewServiceAccountsService(&mocks.ServiceAccountRepository{}, tokenRepository,
+				&mocks.ProjectRepository{}, &mocks.RoleAssignmentRepository{}).(*serviceAccountsService)
+			s.now = func() time.Time { return now }
+			token, err := s.CreateToken(requestctx.WithActor(context.Background(), "admin@example.com"),
+				&models.ServiceAccount{ID: 3, Name: "ci-pipeline"}, tt.token)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.ID(3), token.ServiceAccountID)
+			assert.Equal(t, tt.expectedExpiresAt, *token.ExpiresAt)
+			assert.Equal(t, "admin@example.com", token.CreatedBy)
+			assert.True(t, len(token.Token) > len(models.ServiceAccountTokenPrefix))
+			assert.Equal(t, hashToken(token.Token), token.TokenHash)
+		})
+	}
+}
+
+func TestServiceAccountsService_AuthenticateToken(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+	expired := now.Add(-time.Hour)
+	tests := map[string]struct {
+		bearerToken     string
+		token           *models.ServiceAccountToken
+		expectedSubject string
+		expectedErr     string
+	}{
+		"active token": {
+			bearerToken:     "mlp_active",
+			token:           &models.ServiceAccountToken{ServiceAccountID: 3, ExpiresAt: &expiresAt},
+			expectedSubject: "serviceaccount:3",
+		},
+		"expired token": {
+			bearerToken: "mlp_expired",
+			token:       &models.ServiceAccountToken{ServiceAccountID: 3, ExpiresAt: &expired},
+			expectedErr: "service account token has expired or has been revoked",
+		},
+		"revoked token": {
+			bearerToken: "mlp_revoked",
+			token:       &models.ServiceAccountToken{ServiceAccountID: 3, ExpiresAt: &expiresAt, RevokedAt: &expired},
+			expectedErr: "service account token has expired or has been revoked",
+		},
+		"unknown token": {
+			bearerToken: "mlp_unknown",
+			expectedErr: "invalid service account token",
+		},
+		"not a service account token": {
+			bearerToken: "eyJhbGciOiJSUzI1NiJ9",
+			expectedErr: "invalid service account token",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tokenRepository := &mocks.ServiceAccountTokenRepository{}
+			if tt.token != nil {
+				tokenRepository.On("GetByHash", hashToken(tt.bearerToken)).Return(tt.token, nil)
+			} else {
+				tokenRepository.On("GetByHash", mock.Anything).Return(nil, apperrors.NewNotFoundErrorf("not found"))
+			}
+
+			s := NewServiceAccountsService(&mocks.ServiceAccountRepository{}, tokenRepository,
+				&mocks.ProjectRepository{}, &mocks.RoleAssignmentRepository{}).(*serviceAccountsService)
+			s.now = func() time.Time { return now }
+			subject, err := s.AuthenticateToken(tt.bearerToken)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.True(t, errors.Is(err, &apperrors.UnauthenticatedError{}))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSubject, subject)
+		})
+	}
+}
+
+func TestServiceAccountsService_ValidateProject(t *testing.T) {
+	serviceAccountRepository := &mocks.ServiceAccountRepository{}
+	serviceAccountRepository.On("Get", models.ID(3)).Return(
+		&models.ServiceAccount{ID: 3, ProjectID: 1, Name: "ci-pipeline"}, nil)
+	serviceAccountRepository.On("Get", models.ID(4)).Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	serviceAccountRepository.On("Get", models.ID(5)).Return(
+		&models.ServiceAccount{ID: 5, ProjectID: 2, Name: "ci-pipeline"}, nil)
+
+	s := NewServiceAccountsService(serviceAccountRepository, &mocks.ServiceAccountTokenRepository{},
+		&mocks.ProjectRepository{}, &mocks.RoleAssignmentRepository{})
+	require.NoError(t, s.ValidateProject(&models.Project{
+		ID:             1,
+		Administrators: []string{"admin@example.com", "serviceaccount:3"},
+	}))
+
+	err := s.ValidateProject(&models.Project{
+		ID:             1,
+		Administrators: []string{"admin@example.com"},
+		Readers:        []string{"serviceaccount:3", "serviceaccount:4"},
+		SecretReaders:  []string{"serviceaccount:5"},
+	})
+	var validationErr *apperrors.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Violations, 2)
+	assert.Equal(t, "readers", validationErr.Violations[0].Field)
+	assert.Equal(t, "known_service_account", validationErr.Violations[0].Rule)
+	assert.Equal(t, "secret_readers", validationErr.Violations[1].Field)
+	assert.Equal(t, "project_service_account", validationErr.Violations[1].Rule)
+}
+
+func TestServiceAccountsService_DeleteServiceAccount(t *testing.T) {
+	serviceAccount := &models.ServiceAccount{ID: 3, ProjectID: 1, Name: "ci-pipeline", Subject: "serviceaccount:3"}
+
+	tests := map[string]struct {
+		projectCount    int
+		assignmentCount int
+		expectedErr     string
+	}{
+		"not granted": {},
+		"granted a project role": {
+			projectCount: 1,
+			expectedErr:  "service account ci-pipeline is granted access to 1 projects and cannot be deleted",
+		},
+		"assigned a custom role": {
+			assignmentCount: 2,
+			expectedErr:     "service account ci-pipeline is assigned 2 custom roles and cannot be deleted",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			projectRepository := &mocks.ProjectRepository{}
+			projectRepository.On("ListProjects", mock.MatchedBy(func(filter repository.ProjectFilter) bool {
+				return filter.Subject == "serviceaccount:3" && filter.IncludeArchived
+			})).Return([]*models.Project{}, tt.projectCount, nil)
+			assignmentRepository := &mocks.RoleAssignmentRepository{}
+			assignmentRepository.On("ListByMember", "serviceaccount:3").Return(
+				make([]*models.RoleAssignment, tt.assignmentCount), nil)
+			serviceAccountRepository := &mocks.ServiceAccountRepository{}
+			serviceAccountRepository.On("Delete", models.ID(3)).Return(nil)
+
+			s := NewServiceAccountsService(serviceAccountRepository, &mocks.ServiceAccountTokenRepository{},
+				projectRepository, assignmentRepository)
+			err := s.DeleteServiceAccount(context.Background(), serviceAccount)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				serviceAccountRepository.AssertNotCalled(t, "Delete", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			serviceAccountRepository.AssertExpectations(t)
+		})
+	}
+}
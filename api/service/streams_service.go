@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+)
+
+// StreamsService manages the catalogue of streams and teams that projects belong to
+type StreamsService interface {
+	// ListStreams returns all streams ordered by name
+	ListStreams() ([]*models.Stream, error)
+	FindStreamByID(id models.ID) (*models.Stream, error)
+	CreateStream(ctx context.Context, stream *models.Stream) (*models.Stream, error)
+	// UpdateStream updates the stream. A stream used by projects cannot be renamed.
+	UpdateStream(ctx context.Context, stream *models.Stream) (*models.Stream, error)
+	// DeleteStream deletes the stream. A stream that has teams or is used by projects cannot be deleted.
+	DeleteStream(ctx context.Context, stream *models.Stream) error
+	// ListTeams returns the teams ordered by name, restricted to the teams of the stream if streamID is set
+	ListTeams(streamID *models.ID) ([]*models.Team, error)
+	FindTeamByID(id models.ID) (*models.Team, error)
+	CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
+	// UpdateTeam updates the team. A team used by projects cannot be renamed or moved to another stream.
+	UpdateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
+	// DeleteTeam deletes the team. A team used by projects cannot be deleted.
+	DeleteTeam(ctx context.Context, team *models.Team) error
+	// ValidateProject checks that the stream and the team of the project are part of the catalogue, unless custom
+	// streams or teams are allowed or the catalogue is empty
+	ValidateProject(project *models.Project) error
+	// SyncStreams adds the streams and teams of the given configuration that are missing from the catalogue
+	SyncStreams(ctx context.Context, streams config.Streams) error
+}
+
+func NewStreamsService(
+	streamRepository repository.StreamRepository,
+	teamRepository repository.TeamRepository,
+	projectRepository repository.ProjectRepository,
+	authEnforcer enforcer.Enforcer,
+	authEnabled bool,
+	allowCustomStream bool,
+	allowCustomTeam bool) StreamsService {
+	return &streamsService{
+		streamRepository:  streamRepository,
+		teamRepository:    teamRepository,
+		projectRepository: projectRepository,
+		authEnforcer:      authEnforcer,
+		authEnabled:       authEnabled,
+		allowCustomStream: allowCustomStream,
+		allowCustomTeam:   allowCustomTeam,
+	}
+}
+
+type streamsService struct {
+	streamRepository  repository.StreamRepository
+	teamRepository    repository.TeamRepository
+	projectRepository repository.ProjectRepository
+	authEnforcer      enforcer.Enforcer
+	authEnabled       bool
+	allowCustomStream bool
+	allowCustomTeam   bool
+}
+
+func (s *streamsService) ListStreams() ([]*models.Stream, error) {
+	return s.streamRepository.List()
+}
+
+func (s *streamsService) FindStreamByID(id models.ID) (*models.Stream, error) {
+	return s.streamRepository.Get(id)
+}
+
+func (s *streamsService) CreateStream(ctx context.Context, stream *models.Stream) (*models.Stream, error) {
+	if err := s.checkStreamNameAvailable(stream.Name); err != nil {
+		return nil, err
+	}
+
+	stream, err := s.streamRepository.Save(stream)
+	if err != nil {
+		return nil, fmt.Errorf("error creating stream %s: %w", stream.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, streamResource, stream.ID, stream.Owners); err != nil {
+		return nil, fmt.Errorf("error while creating authorization policy for stream %s: %w", stream.Name, err)
+	}
+	return stream, nil
+}
+
+func (s *streamsService) UpdateStream(ctx context.Context, stream *models.Stream) (*models.Stream, error) {
+	existingStream, err := s.streamRepository.Get(stream.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stream.Name != existingStream.Name {
+		if err := s.checkNotUsedByProjects(repository.ProjectFilter{Stream: existingStream.Name},
+			"stream %s is used by %d projects and cannot be renamed", existingStream.Name); err != nil {
+			return nil, err
+		}
+		if err := s.checkStreamNameAvailable(stream.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	stream, err = s.streamRepository.Save(stream)
+	if err != nil {
+		return nil, fmt.Errorf("error updating stream %s: %w", existingStream.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, streamResource, stream.ID, stream.Owners); err != nil {
+		return nil, fmt.Errorf("error while updating authorization policy for stream %s: %w", stream.Name, err)
+	}
+	return stream, nil
+}
+
+func (s *streamsService) DeleteStream(ctx context.Context, stream *models.Stream) error {
+	teams, err := s.teamRepository.List(&stream.ID)
+	if err != nil {
+		return err
+	}
+	if len(teams) > 0 {
+		return apperrors.NewInvalidArgumentErrorf("stream %s has %d teams and cannot be deleted", stream.Name,
+			len(teams))
+	}
+	if err := s.checkNotUsedByProjects(repository.ProjectFilter{Stream: stream.Name},
+		"stream %s is used by %d projects and cannot be deleted", stream.Name); err != nil {
+		return err
+	}
+
+	if err := s.streamRepository.Delete(stream.ID); err != nil {
+		return fmt.Errorf("error deleting stream %s: %w", stream.Name, err)
+	}
+	if err := s.removeAuthorizationPolicy(ctx, streamResource, stream.ID); err != nil {
+		return fmt.Errorf("error while removing authorization policy of stream %s: %w", stream.Name, err)
+	}
+	return nil
+}
+
+func (s *streamsService) ListTeams(streamID *models.ID) ([]*models.Team, error) {
+	return s.teamRepository.List(streamID)
+}
+
+func (s *streamsService) FindTeamByID(id models.ID) (*models.Team, error) {
+	return s.teamRepository.Get(id)
+}
+
+func (s *streamsService) CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
+	stream, err := s.findTeamStream(team)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTeamNameAvailable(stream, team.Name); err != nil {
+		return nil, err
+	}
+
+	team, err = s.teamRepository.Save(team)
+	if err != nil {
+		return nil, fmt.Errorf("error creating team %s: %w", team.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, teamResource, team.ID, team.Owners); err != nil {
+		return nil, fmt.Errorf("error while creating authorization policy for team %s: %w", team.Name, err)
+	}
+	return team, nil
+}
+
+func (s *streamsService) UpdateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
+	existingTeam, err := s.teamRepository.Get(team.ID)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.findTeamStream(team)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.Name != existingTeam.Name || team.StreamID != existingTeam.StreamID {
+		existingStream, err := s.streamRepository.Get(existingTeam.StreamID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkNotUsedByProjects(
+			repository.ProjectFilter{Stream: existingStream.Name, Team: existingTeam.Name},
+			"team %s is used by %d projects and cannot be renamed or moved to another stream",
+			existingTeam.Name); err != nil {
+			return nil, err
+		}
+		if err := s.checkTeamNameAvailable(stream, team.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	team, err = s.teamRepository.Save(team)
+	if err != nil {
+		return nil, fmt.Errorf("error updating team %s: %w", existingTeam.Name, err)
+	}
+	if err := s.updateAuthorizationPolicy(ctx, teamResource, team.ID, team.Owners); err != nil {
+		return nil, fmt.Errorf("error while updating authorization policy for team %s: %w", team.Name, err)
+	}
+	return team, nil
+}
+
+func (s *streamsService) DeleteTeam(ctx context.Context, team *models.Team) error {
+	stream, err := s.streamRepository.Get(team.StreamID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkNotUsedByProjects(repository.ProjectFilter{Stream: stream.Name, Team: team.Name},
+		"team %s is used by %d projects and cannot be deleted", team.Name); err != nil {
+		return err
+	}
+
+	if err := s.teamRepository.Delete(team.ID); err != nil {
+		return fmt.Errorf("error deleting team %s: %w", team.Name, err)
+	}
+	if err := s.removeAuthorizationPolicy(ctx, teamResource, team.ID); err != nil {
+		return fmt.Errorf("error while removing authorization policy of team %s: %w", team.Name, err)
+	}
+	return nil
+}
+
+func (s *streamsService) ValidateProject(project *models.Project) error {
+	if s.allowCustomStream {
+		return nil
+	}
+	stream, err := s.streamRepository.GetByName(project.Stream)
+	if err != nil {
+		if errors.Is(err, &apperrors.NotFoundError{}) {
+			// projects can use any stream and team until a catalogue is configured
+			streams, err := s.streamRepository.List()
+			if err != nil {
+				return err
+			}
+			if len(streams) == 0 {
+				return nil
+			}
+			return apperrors.NewValidationError("project does not belong to a known stream and team",
+				apperrors.FieldViolation{
+					Field:   "stream",
+					Rule:    "known_stream",
+					Message: fmt.Sprintf("stream %s does not exist", project.Stream),
+				})
+		}
+		return err
+	}
+
+	if s.allowCustomTeam {
+		return nil
+	}
+	if _, err := s.teamRepository.GetByName(stream.ID, project.Team); err != nil {
+		if errors.Is(err, &apperrors.NotFoundError{}) {
+			return apperrors.NewValidationError("project does not belong to a known stream and team",
+				apperrors.FieldViolation{
+					Field:   "team",
+					Rule:    "known_team",
+					Message: fmt.Sprintf("team %s does not exist in stream %s", project.Team, project.Stream),
+				})
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *streamsService) SyncStreams(ctx context.Context, streams config.Streams) error {
+	for streamName, teamNames := range streams {
+		stream, err := s.streamRepository.GetByName(streamName)
+		if errors.Is(err, &apperrors.NotFoundError{}) {
+			stream, err = s.CreateStream(ctx, &models.Stream{Name: streamName})
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, teamName := range teamNames {
+			_, err := s.teamRepository.GetByName(stream.ID, teamName)
+			if errors.Is(err, &apperrors.NotFoundError{}) {
+				_, err = s.CreateTeam(ctx, &models.Team{StreamID: stream.ID, Name: teamName})
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *streamsService) checkStreamNameAvailable(name string) error {
+	_, err := s.streamRepository.GetByName(name)
+	if err == nil {
+		return apperrors.NewAlreadyExistsErrorf("stream %s already exists", name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return err
+	}
+	return nil
+}
+
+func (s *streamsService) checkTeamNameAvailable(stream *models.Stream, name string) error {
+	_, err := s.teamRepository.GetByName(stream.ID, name)
+	if err == nil {
+		return apperrors.NewAlreadyExistsErrorf("team %s already exists in stream %s", name, stream.Name)
+	}
+	if !errors.Is(err, &apperrors.NotFoundError{}) {
+		return err
+	}
+	return nil
+}
+
+// findTeamStream returns the stream of the team, which is referenced by the request body rather than by the path
+// and is therefore reported as an invalid argument if it does not exist
+func (s *streamsService) findTeamStream(team *models.Team) (*models.Stream, error) {
+	stream, err := s.streamRepository.Get(team.StreamID)
+	if err != nil {
+		if errors.Is(err, &apperrors.NotFoundError{}) {
+			return nil, apperrors.NewInvalidArgumentErrorf("stream with ID %d does not exist", team.StreamID)
+		}
+		return nil, err
+	}
+	return stream, nil
+}
+
+// checkNotUsedByProjects returns an invalid argument error built from the format and the name if any active project
+// matches the filter
+func (s *streamsService) checkNotUsedByProjects(filter repository.ProjectFilter, format string, name string) error {
+	page, pageSize := int32(1), int32(1)
+	filter.Page, filter.PageSize = &page, &pageSize
+	_, count, err := s.projectRepository.ListProjects(filter)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewInvalidArgumentErrorf(format, name, count)
+	}
+	return nil
+}
+
+// updateAuthorizationPolicy allows the owners of the resource and the MLP administrators to update and delete it
+func (s *streamsService) updateAuthorizationPolicy(ctx context.Context, resource ownedResource, id models.ID,
+	owners []string) error {
+	if !s.authEnabled {
+		return nil
+	}
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	if err := resource.grantOwners(updateRequest, id, owners); err != nil {
+		return err
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
+
+// removeAuthorizationPolicy reverts the changes made by updateAuthorizationPolicy
+func (s *streamsService) removeAuthorizationPolicy(ctx context.Context, resource ownedResource, id models.ID) error {
+	if !s.authEnabled {
+		return nil
+	}
+	updateRequest := enforcer.NewAuthorizationUpdateRequest()
+	if err := resource.revokeOwners(updateRequest, id); err != nil {
+		return err
+	}
+	return s.authEnforcer.UpdateAuthorization(ctx, updateRequest)
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/mlp/api/config"
+	"github.com/caraml-dev/mlp/api/models"
+	"github.com/caraml-dev/mlp/api/pkg/authz/enforcer"
+	enforcerMock "github.com/caraml-dev/mlp/api/pkg/authz/enforcer/mocks"
+	apperrors "github.com/caraml-dev/mlp/api/pkg/errors"
+	"github.com/caraml-dev/mlp/api/repository"
+	"github.com/caraml-dev/mlp/api/repository/mocks"
+)
+
+func TestStreamsService_CreateStream(t *testing.T) {
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("GetByName", "credit").Return(nil, apperrors.NewNotFoundErrorf("not found")).Once()
+	streamRepository.On("Save", mock.Anything).Return(&models.Stream{
+		ID:     3,
+		Name:   "credit",
+		Owners: []string{"owner@example.com"},
+	}, nil)
+
+	expectedUpdate := enforcer.NewAuthorizationUpdateRequest()
+	expectedUpdate.SetRoleMembers("mlp.streams.3.owner", []string{"owner@example.com"})
+	expectedUpdate.AddRolePermissions(enforcer.MLPAdminRole, []string{"mlp.streams.3.put", "mlp.streams.3.delete"})
+	expectedUpdate.AddRolePermissions("mlp.streams.3.owner", []string{"mlp.streams.3.put", "mlp.streams.3.delete"})
+	authEnforcer := &enforcerMock.Enforcer{}
+	authEnforcer.On("UpdateAuthorization", mock.Anything, expectedUpdate).Return(nil)
+
+	s := NewStreamsService(streamRepository, &mocks.TeamRepository{}, &mocks.ProjectRepository{}, authEnforcer, true,
+		true, true)
+	stream, err := s.CreateStream(context.Background(), &models.Stream{
+		Name:   "credit",
+		Owners: []string{"owner@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(3), stream.ID)
+	authEnforcer.AssertExpectations(t)
+
+	streamRepository.On("GetByName", "credit").Return(stream, nil)
+	_, err = s.CreateStream(context.Background(), &models.Stream{Name: "credit"})
+	assert.True(t, errors.Is(err, &apperrors.AlreadyExistsError{}))
+}
+
+func TestStreamsService_UpdateStream(t *testing.T) {
+	existingStream := &models.Stream{ID: 1, Name: "credit"}
+	tests := map[string]struct {
+		projectCount int
+		expectedErr  string
+	}{
+		"rename unused stream": {},
+		"rename stream used by projects": {
+			projectCount: 2,
+			expectedErr:  "stream credit is used by 2 projects and cannot be renamed",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			streamRepository := &mocks.StreamRepository{}
+			streamRepository.On("Get", models.ID(1)).Return(existingStream, nil)
+			streamRepository.On("GetByName", "lending").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+			streamRepository.On("Save", mock.Anything).Return(&models.Stream{ID: 1, Name: "lending"}, nil)
+			projectRepository := &mocks.ProjectRepository{}
+			projectRepository.On("ListProjects", mock.MatchedBy(func(filter repository.ProjectFilter) bool {
+				return filter.Stream == "credit"
+			})).Return([]*models.Project{}, tt.projectCount, nil)
+
+			s := NewStreamsService(streamRepository, &mocks.TeamRepository{}, projectRepository, nil, false, true, true)
+			stream, err := s.UpdateStream(context.Background(), &models.Stream{ID: 1, Name: "lending"})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.True(t, errors.Is(err, &apperrors.InvalidArgumentError{}))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "lending", stream.Name)
+		})
+	}
+}
+
+func TestStreamsService_DeleteStream(t *testing.T) {
+	stream := &models.Stream{ID: 1, Name: "credit"}
+
+	teamRepository := &mocks.TeamRepository{}
+	teamRepository.On("List", &stream.ID).Return([]*models.Team{{ID: 1, StreamID: 1, Name: "risk"}}, nil)
+	s := NewStreamsService(&mocks.StreamRepository{}, teamRepository, &mocks.ProjectRepository{}, nil, false, true,
+		true)
+	err := s.DeleteStream(context.Background(), stream)
+	assert.EqualError(t, err, "stream credit has 1 teams and cannot be deleted")
+
+	teamRepository = &mocks.TeamRepository{}
+	teamRepository.On("List", &stream.ID).Return([]*models.Team{}, nil)
+	projectRepository := &mocks.ProjectRepository{}
+	projectRepository.On("ListProjects", mock.Anything).Return([]*models.Project{}, 0, nil)
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("Delete", models.ID(1)).Return(nil)
+	s = NewStreamsService(streamRepository, teamRepository, projectRepository, nil, false, true, true)
+	require.NoError(t, s.DeleteStream(context.Background(), stream))
+	streamRepository.AssertExpectations(t)
+}
+
+func TestStreamsService_CreateTeam(t *testing.T) {
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("Get", models.ID(1)).Return(&models.Stream{ID: 1, Name: "credit"}, nil)
+	streamRepository.On("Get", models.ID(2)).Return(nil, apperrors.NewNotFoundErrorf("stream with ID 2 not found"))
+	teamRepository := &mocks.TeamRepository{}
+	teamRepository.On("GetByName", models.ID(1), "risk").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	teamRepository.On("Save", mock.Anything).Return(&models.Team{ID: 4, StreamID: 1, Name: "risk"}, nil)
+
+	s := NewStreamsService(streamRepository, teamRepository, &mocks.ProjectRepository{}, nil, false, true, true)
+	team, err := s.CreateTeam(context.Background(), &models.Team{StreamID: 1, Name: "risk"})
+	require.NoError(t, err)
+	assert.Equal(t, models.ID(4), team.ID)
+
+	_, err = s.CreateTeam(context.Background(), &models.Team{StreamID: 2, Name: "risk"})
+	assert.EqualError(t, err, "stream with ID 2 does not exist")
+	assert.True(t, errors.Is(err, &apperrors.InvalidArgumentError{}))
+}
+
+func TestStreamsService_ValidateProject(t *testing.T) {
+	tests := map[string]struct {
+		allowCustomStream bool
+		allowCustomTeam   bool
+		emptyCatalogue    bool
+		project           *models.Project
+		expectedField     string
+	}{
+		"known stream and team": {
+			project: &models.Project{Stream: "credit", Team: "risk"},
+		},
+		"unknown stream": {
+			project:       &models.Project{Stream: "crdit", Team: "risk"},
+			expectedField: "stream",
+		},
+		"unknown team": {
+			project:       &models.Project{Stream: "credit", Team: "rsk"},
+			expectedField: "team",
+		},
+		"custom stream allowed": {
+			allowCustomStream: true,
+			project:           &models.Project{Stream: "crdit", Team: "rsk"},
+		},
+		"custom team allowed": {
+			allowCustomTeam: true,
+			project:         &models.Project{Stream: "credit", Team: "rsk"},
+		},
+		"empty catalogue": {
+			emptyCatalogue: true,
+			project:        &models.Project{Stream: "crdit", Team: "rsk"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			streamRepository := &mocks.StreamRepository{}
+			streamRepository.On("GetByName", "credit").Return(&models.Stream{ID: 1, Name: "credit"}, nil)
+			streamRepository.On("GetByName", mock.Anything).Return(nil, apperrors.NewNotFoundErrorf("not found"))
+			if tt.emptyCatalogue {
+				streamRepository.On("List").Return([]*models.Stream{}, nil)
+			} else {
+				streamRepository.On("List").Return([]*models.Stream{{ID: 1, Name: "credit"}}, nil)
+			}
+			teamRepository := &mocks.TeamRepository{}
+			teamRepository.On("GetByName", models.ID(1), "risk").Return(&models.Team{ID: 1, Name: "risk"}, nil)
+			teamRepository.On("GetByName", models.ID(1), mock.Anything).Return(nil,
+				apperrors.NewNotFoundErrorf("not found"))
+
+			s := NewStreamsService(streamRepository, teamRepository, &mocks.ProjectRepository{}, nil, false,
+				tt.allowCustomStream, tt.allowCustomTeam)
+			err := s.ValidateProject(tt.project)
+			if tt.expectedField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *apperrors.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			require.Len(t, validationErr.Violations, 1)
+			assert.Equal(t, tt.expectedField, validationErr.Violations[0].Field)
+		})
+	}
+}
+
+func TestStreamsService_SyncStreams(t *testing.T) {
+	streamRepository := &mocks.StreamRepository{}
+	streamRepository.On("GetByName", "credit").Return(&models.Stream{ID: 1, Name: "credit"}, nil)
+	streamRepository.On("GetByName", "marketing").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	streamRepository.On("Save", mock.MatchedBy(func(stream *models.Stream) bool {
+		return stream.Name == "marketing"
+	})).Return(&models.Stream{ID: 2, Name: "marketing"}, nil).Once()
+	streamRepository.On("Get", models.ID(2)).Return(&models.Stream{ID: 2, Name: "marketing"}, nil)
+	teamRepository := &mocks.TeamRepository{}
+	teamRepository.On("GetByName", models.ID(1), "risk").Return(&models.Team{ID: 1, Name: "risk"}, nil)
+	teamRepository.On("GetByName", models.ID(2), "campaigns").Return(nil, apperrors.NewNotFoundErrorf("not found"))
+	teamRepository.On("Save", mock.MatchedBy(func(team *models.Team) bool {
+		return team.StreamID == 2 && team.Name == "campaigns"
+	})).Return(&models.Team{ID: 2, StreamID: 2, Name: "campaigns"}, nil).Once()
+
+	s := NewStreamsService(streamRepository, teamRepository, &mocks.ProjectRepository{}, nil, false, true, true)
+	err := s.SyncStreams(context.Background(), config.Streams{
+		"credit":    {"risk"},
+		"marketing": {"campaigns"},
+	})
+	require.NoError(t, err)
+	streamRepository.AssertExpectations(t)
+	teamRepository.AssertExpectations(t)
+}
@@ -84,6 +84,19 @@ func NewValidator() *validator.Validate {
 			return t
 		})
 
+	_ = instance.RegisterTranslation("oneof", EN, func(ut ut.Translator) error {
+		return ut.Add("oneof", "{0} should be one of {1}", true)
+	},
+		func(ut ut.Translator, fe validator.FieldError) string {
+			fld := fe.StructField()
+			param := fe.Param()
+			t, err := ut.T(fe.Tag(), fld, param)
+			if err != nil {
+				return fe.(error).Error()
+			}
+			return t
+		})
+
 	return instance
 }
 
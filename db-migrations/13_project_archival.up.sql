@@ -0,0 +1 @@
+ALTER TABLE projects ADD COLUMN archived_at timestamp;
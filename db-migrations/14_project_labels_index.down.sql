@@ -0,0 +1 @@
+DROP INDEX IF EXISTS projects_labels_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS projects_labels_idx ON projects USING GIN (labels jsonb_path_ops);
@@ -0,0 +1 @@
+ALTER TABLE projects ADD COLUMN version integer NOT NULL DEFAULT 1;
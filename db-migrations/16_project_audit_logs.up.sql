@@ -0,0 +1,13 @@
+-- Audit logs are kept when the project is deleted, hence project_id is not a foreign key
+CREATE TABLE IF NOT EXISTS project_audit_logs
+(
+    id          serial PRIMARY KEY,
+    project_id  integer      NOT NULL,
+    action      varchar(32)  NOT NULL,
+    actor       varchar(256) NOT NULL DEFAULT '',
+    request_id  varchar(128) NOT NULL DEFAULT '',
+    changes     jsonb        NOT NULL DEFAULT '{}',
+    created_at  timestamp    NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX project_audit_logs_project_id_idx ON project_audit_logs (project_id, created_at);
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS streams
+(
+    id          serial PRIMARY KEY,
+    name        varchar(64)    NOT NULL UNIQUE,
+    description text           NOT NULL DEFAULT '',
+    owners      varchar(256)[],
+    created_at  timestamp      NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamp      NOT NULL DEFAULT current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS teams
+(
+    id          serial PRIMARY KEY,
+    stream_id   integer        NOT NULL REFERENCES streams (id),
+    name        varchar(64)    NOT NULL,
+    description text           NOT NULL DEFAULT '',
+    owners      varchar(256)[],
+    created_at  timestamp      NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamp      NOT NULL DEFAULT current_timestamp,
+    UNIQUE (stream_id, name)
+);
+
+-- Backfill the streams and teams already used by projects
+INSERT INTO streams (name)
+SELECT DISTINCT stream
+FROM projects
+WHERE stream <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO teams (stream_id, name)
+SELECT DISTINCT streams.id, projects.team
+FROM projects
+         JOIN streams ON streams.name = projects.stream
+WHERE projects.team <> ''
+ON CONFLICT DO NOTHING;
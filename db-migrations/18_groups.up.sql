@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS groups
+(
+    id          serial PRIMARY KEY,
+    name        varchar(64)    NOT NULL UNIQUE,
+    description text           NOT NULL DEFAULT '',
+    members     varchar(256)[],
+    owners      varchar(256)[],
+    created_at  timestamp      NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamp      NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS groups_members_idx ON groups USING gin (members);
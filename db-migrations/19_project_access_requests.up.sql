@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS project_access_requests
+(
+    id             serial PRIMARY KEY,
+    project_id     integer      NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    requester      varchar(256) NOT NULL,
+    role           varchar(32)  NOT NULL,
+    reason         text         NOT NULL DEFAULT '',
+    status         varchar(32)  NOT NULL DEFAULT 'pending',
+    reviewer       varchar(256) NOT NULL DEFAULT '',
+    review_comment text         NOT NULL DEFAULT '',
+    reviewed_at    timestamp,
+    created_at     timestamp    NOT NULL DEFAULT current_timestamp,
+    updated_at     timestamp    NOT NULL DEFAULT current_timestamp
+);
+
+-- A user can only have one pending request per project
+CREATE UNIQUE INDEX project_access_requests_pending_idx ON project_access_requests (project_id, requester)
+    WHERE status = 'pending';
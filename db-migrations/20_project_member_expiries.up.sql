@@ -0,0 +1 @@
+ALTER TABLE projects ADD COLUMN member_expiries jsonb NOT NULL DEFAULT '[]';
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS service_accounts
+(
+    id          serial PRIMARY KEY,
+    project_id  integer      NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    name        varchar(64)  NOT NULL,
+    description text         NOT NULL DEFAULT '',
+    created_by  varchar(256) NOT NULL DEFAULT '',
+    created_at  timestamp    NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamp    NOT NULL DEFAULT current_timestamp,
+    UNIQUE (project_id, name)
+);
+
+-- Only the SHA-256 hash of a token is stored, the token itself is returned once when it is created
+CREATE TABLE IF NOT EXISTS service_account_tokens
+(
+    id                 serial PRIMARY KEY,
+    service_account_id integer      NOT NULL REFERENCES service_accounts (id) ON DELETE CASCADE,
+    name               varchar(64)  NOT NULL DEFAULT '',
+    token_hash         char(64)     NOT NULL UNIQUE,
+    expires_at         timestamp    NOT NULL,
+    revoked_at         timestamp,
+    created_by         varchar(256) NOT NULL DEFAULT '',
+    created_at         timestamp    NOT NULL DEFAULT current_timestamp
+);
@@ -0,0 +1 @@
+ALTER TABLE projects ADD COLUMN secret_readers varchar(256)[];
@@ -0,0 +1,22 @@
+-- Custom roles grant a set of permissions on a project to the members they are assigned to in that project
+CREATE TABLE IF NOT EXISTS roles
+(
+    id          serial PRIMARY KEY,
+    name        varchar(64)    NOT NULL UNIQUE,
+    description text           NOT NULL DEFAULT '',
+    permissions varchar(256)[] NOT NULL,
+    project_id  integer REFERENCES projects (id) ON DELETE CASCADE,
+    created_at  timestamp      NOT NULL DEFAULT current_timestamp,
+    updated_at  timestamp      NOT NULL DEFAULT current_timestamp
+);
+
+CREATE TABLE IF NOT EXISTS role_assignments
+(
+    id         serial PRIMARY KEY,
+    role_id    integer        NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    project_id integer        NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    members    varchar(256)[] NOT NULL,
+    created_at timestamp      NOT NULL DEFAULT current_timestamp,
+    updated_at timestamp      NOT NULL DEFAULT current_timestamp,
+    UNIQUE (role_id, project_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS authorization_group_members;
+DROP TABLE IF EXISTS authorization_role_members;
+DROP TABLE IF EXISTS authorization_role_permissions;
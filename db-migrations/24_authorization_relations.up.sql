@@ -0,0 +1,29 @@
+-- Relations of the database authorization backend, the alternative to Keto: the permissions are granted to the
+-- members of roles, which are users or groups (group:<name>) of users
+CREATE TABLE IF NOT EXISTS authorization_role_permissions
+(
+    role       varchar(256) NOT NULL,
+    permission varchar(256) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE INDEX IF NOT EXISTS authorization_role_permissions_permission_idx
+    ON authorization_role_permissions (permission);
+
+CREATE TABLE IF NOT EXISTS authorization_role_members
+(
+    role   varchar(256) NOT NULL,
+    member varchar(256) NOT NULL,
+    PRIMARY KEY (role, member)
+);
+
+CREATE INDEX IF NOT EXISTS authorization_role_members_member_idx ON authorization_role_members (member);
+
+CREATE TABLE IF NOT EXISTS authorization_group_members
+(
+    group_name varchar(256) NOT NULL,
+    member     varchar(256) NOT NULL,
+    PRIMARY KEY (group_name, member)
+);
+
+CREATE INDEX IF NOT EXISTS authorization_group_members_member_idx ON authorization_group_members (member);
@@ -0,0 +1,17 @@
+-- Log of the permission checks written by the database sink of the authorization decision log
+CREATE TABLE IF NOT EXISTS authorization_decisions
+(
+    id          bigserial PRIMARY KEY,
+    subject     varchar(256) NOT NULL,
+    permission  varchar(256) NOT NULL,
+    allowed     boolean      NOT NULL,
+    cache_hit   boolean      NOT NULL DEFAULT false,
+    latency_ms  double precision NOT NULL DEFAULT 0,
+    error       text         NOT NULL DEFAULT '',
+    request_id  varchar(128) NOT NULL DEFAULT '',
+    created_at  timestamp    NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX authorization_decisions_created_at_idx ON authorization_decisions (created_at);
+CREATE INDEX authorization_decisions_subject_idx ON authorization_decisions (subject, created_at);
+CREATE INDEX authorization_decisions_permission_idx ON authorization_decisions (permission varchar_pattern_ops);
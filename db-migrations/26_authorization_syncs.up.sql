@@ -0,0 +1,19 @@
+-- Outbox of the changes of the authorization policies of the projects, recorded in the same transaction as the
+-- projects and deleted once applied. Syncs are kept when the project is deleted, hence project_id is not a foreign key.
+CREATE TABLE IF NOT EXISTS authorization_syncs
+(
+    id              bigserial PRIMARY KEY,
+    project_id      integer      NOT NULL,
+    action          varchar(16)  NOT NULL,
+    status          varchar(16)  NOT NULL DEFAULT 'pending',
+    attempts        integer      NOT NULL DEFAULT 0,
+    last_error      text         NOT NULL DEFAULT '',
+    next_attempt_at timestamp    NOT NULL DEFAULT current_timestamp,
+    -- claimed_until is the end of the lease of the worker applying the sync, which is applied outside of a transaction
+    claimed_until   timestamp,
+    created_at      timestamp    NOT NULL DEFAULT current_timestamp,
+    updated_at      timestamp    NOT NULL DEFAULT current_timestamp
+);
+
+CREATE INDEX authorization_syncs_project_id_idx ON authorization_syncs (project_id, id);
+CREATE INDEX authorization_syncs_next_attempt_at_idx ON authorization_syncs (next_attempt_at);
@@ -10,6 +10,7 @@ require (
 	github.com/aws/aws-sdk-go-v2 v1.30.6-0.20240906182417-827d25db0048
 	github.com/aws/aws-sdk-go-v2/config v1.8.3
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.61.2
+	github.com/evanphx/json-patch/v5 v5.6.0
 	github.com/gavv/httpexpect/v2 v2.15.0
 	github.com/getsentry/raven-go v0.2.0
 	github.com/go-playground/locales v0.14.0
@@ -18,6 +19,7 @@ require (
 	github.com/go-playground/validator/v10 v10.11.1
 	github.com/golang-migrate/migrate/v4 v4.11.0
 	github.com/google/go-cmp v0.5.9
+	github.com/google/uuid v1.3.0
 	github.com/gorilla/mux v1.7.4
 	github.com/hashicorp/vault/api v1.9.0
 	github.com/hashicorp/vault/api/auth/gcp v0.4.0
@@ -45,6 +47,7 @@ require (
 	golang.org/x/sync v0.1.0
 	google.golang.org/api v0.106.0
 	gopkg.in/errgo.v2 v2.1.0
+	gopkg.in/square/go-jose.v2 v2.5.1
 	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/client-go v0.26.0
 	sigs.k8s.io/yaml v1.3.0
@@ -87,7 +90,6 @@ require (
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/google/gofuzz v1.1.0 // indirect
-	github.com/google/uuid v1.3.0 // indirect
 	github.com/googleapis/enterprise-certificate-proxy v0.2.1 // indirect
 	github.com/googleapis/gax-go/v2 v2.7.0 // indirect
 	github.com/gorilla/websocket v1.4.2 // indirect
@@ -153,7 +155,6 @@ require (
 	gopkg.in/DATA-DOG/go-sqlmock.v1 v1.3.0 // indirect
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
-	gopkg.in/square/go-jose.v2 v2.5.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/apimachinery v0.26.0 // indirect
 	k8s.io/klog/v2 v2.80.1 // indirect